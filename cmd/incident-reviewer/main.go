@@ -8,15 +8,46 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
 	"github.com/gaqzi/incident-reviewer/internal/app"
 )
 
 func main() {
 	cfg := app.NewConfig()
+	cfg.PublicationRulesPath = os.Getenv("PUBLICATION_RULES")
+	cfg.IncidentSourcesPath = os.Getenv("INCIDENT_WEBHOOKS")
+	if dir := os.Getenv("ATTACHMENTS_DIR"); dir != "" {
+		cfg.AttachmentsDir = dir
+	}
+	if dir := os.Getenv("OUTBOX_DIR"); dir != "" {
+		cfg.OutboxDir = dir
+	}
+	cfg.SecureCookies = os.Getenv("INSECURE_COOKIES") == ""
+	if email := os.Getenv("ADMIN_EMAIL"); email != "" {
+		cfg.AdminEmail = email
+	}
+	cfg.AdminPassword = os.Getenv("ADMIN_PASSWORD")
+	if path := os.Getenv("ADMIN_PASSWORD_FILE"); path != "" {
+		cfg.AdminPasswordFile = path
+	}
+	cfg.OIDC.IssuerURL = os.Getenv("OIDC_ISSUER_URL")
+	cfg.OIDC.ClientID = os.Getenv("OIDC_CLIENT_ID")
+	cfg.OIDC.ClientSecret = os.Getenv("OIDC_CLIENT_SECRET")
+	cfg.OIDC.RedirectURL = os.Getenv("OIDC_REDIRECT_URL")
+	cfg.OIDC.GroupsClaim = os.Getenv("OIDC_GROUPS_CLAIM")
+	cfg.LocalLogin = os.Getenv("DISABLE_LOCAL_LOGIN") == ""
+	cfg.LinkByEmail = os.Getenv("OIDC_LINK_BY_EMAIL") != ""
+	groupRoles, err := accounts.ParseGroupRoles(os.Getenv("OIDC_ROLE_GROUPS"))
+	if err != nil {
+		slog.Error("failed to read OIDC_ROLE_GROUPS", "error", err)
+		os.Exit(1)
+	}
+	cfg.GroupRoles = groupRoles
 	ctx, cancel := context.WithCancel(context.Background())
 	server, err := app.Start(ctx, cfg)
 	if err != nil {
 		slog.Error("failed to start server", "error", err)
+		os.Exit(1)
 	}
 
 	slog.Info("server started", "addr", "http://"+server.Config.Addr)
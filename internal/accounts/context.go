@@ -0,0 +1,19 @@
+package accounts
+
+import (
+	"context"
+)
+
+type userCtxKey struct{}
+
+// WithUser returns a context with the signed-in user.
+func WithUser(ctx context.Context, u User) context.Context {
+	return context.WithValue(ctx, userCtxKey{}, u)
+}
+
+// UserFrom returns the signed-in user, and false if nobody is signed in.
+func UserFrom(ctx context.Context) (User, bool) {
+	u, ok := ctx.Value(userCtxKey{}).(User)
+
+	return u, ok
+}
@@ -0,0 +1,99 @@
+package accounts
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+// Identity is who a user is according to an external identity provider, like an OpenID Connect provider.
+type Identity struct {
+	// Issuer and Subject together uniquely identify the user with the provider.
+	Issuer  string
+	Subject string
+
+	Email string
+	// EmailVerified is whether the provider has checked that the email belongs to them,
+	// it's required for linking to an existing user by email.
+	EmailVerified bool
+	Name          string
+	Groups        []string
+}
+
+// ErrIncompleteIdentity is returned when an Identity doesn't have enough to sign in with.
+var ErrIncompleteIdentity = failure.New(failure.Invalid, "identity needs an issuer, subject and email")
+
+// ErrIdentityMismatch is returned when the email of an Identity belongs to a user linked to another identity.
+var ErrIdentityMismatch = failure.New(failure.Conflict, "email belongs to a user linked to another identity")
+
+// ErrIdentityNotLinkable is returned when the email of an Identity belongs to an existing user it can't be linked to,
+// which is unless linking by email is turned on with WithEmailLinking and the provider has verified the email.
+var ErrIdentityNotLinkable = failure.New(failure.Conflict, "email belongs to an existing user who can't be linked to the identity")
+
+func (i Identity) validate() error {
+	if i.Issuer == "" || i.Subject == "" || i.Email == "" {
+		return ErrIncompleteIdentity
+	}
+
+	return nil
+}
+
+// applyIdentity links the user to the identity and updates the details the provider is in charge of.
+func (u User) applyIdentity(i Identity) User {
+	u.Issuer = i.Issuer
+	u.Subject = i.Subject
+	u.Email = i.Email
+	if i.Name != "" {
+		u.Name = i.Name
+	} else if u.Name == "" {
+		u.Name = i.Email
+	}
+	u.Groups = slices.Clone(i.Groups)
+
+	return u
+}
+
+// GroupRoles maps the groups from an identity provider to the role people in them get.
+type GroupRoles map[string]actor.Role
+
+// ParseGroupRoles reads a comma separated list of group=role, like "sre-leads=admin,sre=facilitator".
+func ParseGroupRoles(s string) (GroupRoles, error) {
+	ret := make(GroupRoles)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		group, role, ok := strings.Cut(pair, "=")
+		group, role = strings.TrimSpace(group), strings.TrimSpace(role)
+		if !ok || group == "" {
+			return nil, fmt.Errorf("expected group=role but got %q", pair)
+		}
+		if !slices.Contains(actor.Roles, actor.Role(role)) {
+			return nil, fmt.Errorf("unknown role %q for group %q", role, group)
+		}
+
+		ret[group] = actor.Role(role)
+	}
+
+	return ret, nil
+}
+
+// RoleFor returns the most allowed role of the groups, ok is false when none of the groups have a role.
+func (gr GroupRoles) RoleFor(groups []string) (role actor.Role, ok bool) {
+	best := len(actor.Roles)
+	for _, g := range groups {
+		if i := slices.Index(actor.Roles, gr[g]); i >= 0 && i < best {
+			best = i
+		}
+	}
+
+	if best == len(actor.Roles) {
+		return "", false
+	}
+
+	return actor.Roles[best], true
+}
@@ -0,0 +1,156 @@
+// Package oidc signs people in through an OpenID Connect provider using the authorization code flow.
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+)
+
+type Config struct {
+	// IssuerURL is where the provider's discovery document is, OIDC is turned off when it's empty.
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is where the provider sends people back to after signing in, it has to be registered with the provider.
+	RedirectURL string
+
+	// Scopes are requested in addition to "openid", defaults to "email" and "profile".
+	Scopes []string
+	// NameClaim is the claim with the display name of the user, defaults to "name".
+	NameClaim string
+	// GroupsClaim is the claim with the groups the user is part of, defaults to "groups".
+	// The provider might need to be configured to include the groups in the ID token.
+	GroupsClaim string
+}
+
+// Enabled is true when there's enough configured to sign in through a provider.
+func (c Config) Enabled() bool {
+	return c.IssuerURL != ""
+}
+
+func (c Config) withDefaults() Config {
+	if len(c.Scopes) == 0 {
+		c.Scopes = []string{"email", "profile"}
+	}
+	if c.NameClaim == "" {
+		c.NameClaim = "name"
+	}
+	if c.GroupsClaim == "" {
+		c.GroupsClaim = "groups"
+	}
+
+	return c
+}
+
+type Provider struct {
+	config   Config
+	oauth    oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+// NewProvider fetches the provider's discovery document, so it fails when the provider can't be reached.
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	cfg = cfg.withDefaults()
+	if cfg.IssuerURL == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("oidc needs an issuer URL, client ID and redirect URL")
+	}
+
+	provider, err := gooidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider %q: %w", cfg.IssuerURL, err)
+	}
+
+	return &Provider{
+		config: cfg,
+		oauth: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       append([]string{gooidc.ScopeOpenID}, cfg.Scopes...),
+		},
+		verifier: provider.Verifier(&gooidc.Config{ClientID: cfg.ClientID}),
+	}, nil
+}
+
+// AuthCodeURL is where to send people to sign in with the provider.
+// The state and nonce have to be kept by the caller and passed back into Exchange.
+func (p *Provider) AuthCodeURL(state, nonce string) string {
+	return p.oauth.AuthCodeURL(state, gooidc.Nonce(nonce))
+}
+
+// Exchange trades the code the provider sent back for an ID token, verifies it, and maps its claims to an identity.
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (accounts.Identity, error) {
+	token, err := p.oauth.Exchange(ctx, code)
+	if err != nil {
+		return accounts.Identity{}, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return accounts.Identity{}, errors.New("no id_token in the token response")
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return accounts.Identity{}, fmt.Errorf("failed to verify id token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return accounts.Identity{}, errors.New("id token nonce doesn't match")
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return accounts.Identity{}, fmt.Errorf("failed to decode id token claims: %w", err)
+	}
+
+	return p.identity(idToken.Issuer, idToken.Subject, claims)
+}
+
+// identity maps the claims, the email has to be verified when the provider says whether it is,
+// and it only counts as verified when the provider says so.
+func (p *Provider) identity(issuer, subject string, claims map[string]any) (accounts.Identity, error) {
+	verified, ok := claims["email_verified"].(bool)
+	if ok && !verified {
+		return accounts.Identity{}, errors.New("the email hasn't been verified with the provider")
+	}
+
+	email, _ := claims["email"].(string)
+	name, _ := claims[p.config.NameClaim].(string)
+	if name == "" {
+		name, _ = claims["preferred_username"].(string)
+	}
+
+	return accounts.Identity{
+		Issuer:        issuer,
+		Subject:       subject,
+		Email:         email,
+		EmailVerified: verified,
+		Name:          name,
+		Groups:        stringsClaim(claims[p.config.GroupsClaim]),
+	}, nil
+}
+
+// stringsClaim handles providers that send a single group as a string instead of a list.
+func stringsClaim(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		ret := make([]string, 0, len(v))
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+		return ret
+	default:
+		return nil
+	}
+}
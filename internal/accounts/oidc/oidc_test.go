@@ -0,0 +1,143 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/internal/accounts/oidc"
+	"github.com/gaqzi/incident-reviewer/test"
+)
+
+func startProvider(t *testing.T, cfg oidc.Config) (*oidc.Provider, *test.OIDCProvider) {
+	t.Helper()
+
+	idp, err := test.StartOIDCProvider()
+	require.NoError(t, err)
+	t.Cleanup(idp.Close)
+
+	cfg.IssuerURL = idp.URL
+	cfg.ClientID = idp.ClientID
+	cfg.ClientSecret = idp.ClientSecret
+	cfg.RedirectURL = "http://localhost/login/oidc/callback"
+	provider, err := oidc.NewProvider(context.Background(), cfg)
+	require.NoError(t, err)
+
+	return provider, idp
+}
+
+// signIn follows the redirect to the provider and returns the code it sends back.
+func signIn(t *testing.T, provider *oidc.Provider, state, nonce string) string {
+	t.Helper()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(provider.AuthCodeURL(state, nonce))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, state, callback.Query().Get("state"))
+
+	return callback.Query().Get("code")
+}
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("maps the claims of the ID token to an identity", func(t *testing.T) {
+		provider, idp := startProvider(t, oidc.Config{})
+		idp.SignInAs(map[string]any{
+			"sub":            "1234",
+			"email":          "fran@example.com",
+			"email_verified": true,
+			"name":           "Fran Facilitator",
+			"groups":         []string{"sre", "incident-commanders"},
+		})
+
+		actual, err := provider.Exchange(ctx, signIn(t, provider, "a state", "a nonce"), "a nonce")
+
+		require.NoError(t, err)
+		require.Equal(t, accounts.Identity{
+			Issuer:        idp.URL,
+			Subject:       "1234",
+			Email:         "fran@example.com",
+			EmailVerified: true,
+			Name:          "Fran Facilitator",
+			Groups:        []string{"sre", "incident-commanders"},
+		}, actual)
+	})
+
+	t.Run("an email is only verified when the provider says so", func(t *testing.T) {
+		provider, idp := startProvider(t, oidc.Config{})
+		idp.SignInAs(map[string]any{"sub": "1234", "email": "fran@example.com"})
+
+		actual, err := provider.Exchange(ctx, signIn(t, provider, "a state", "a nonce"), "a nonce")
+
+		require.NoError(t, err)
+		require.False(t, actual.EmailVerified)
+	})
+
+	t.Run("uses the configured claims for the name and groups", func(t *testing.T) {
+		provider, idp := startProvider(t, oidc.Config{NameClaim: "display_name", GroupsClaim: "roles"})
+		idp.SignInAs(map[string]any{
+			"sub":          "1234",
+			"email":        "fran@example.com",
+			"display_name": "Fran",
+			"roles":        "sre",
+		})
+
+		actual, err := provider.Exchange(ctx, signIn(t, provider, "a state", "a nonce"), "a nonce")
+
+		require.NoError(t, err)
+		require.Equal(t, "Fran", actual.Name)
+		require.Equal(t, []string{"sre"}, actual.Groups, "expected a single group to be turned into a list")
+	})
+
+	t.Run("rejects an ID token for another sign in", func(t *testing.T) {
+		provider, idp := startProvider(t, oidc.Config{})
+		idp.SignInAs(map[string]any{"sub": "1234", "email": "fran@example.com"})
+
+		_, err := provider.Exchange(ctx, signIn(t, provider, "a state", "a nonce"), "another nonce")
+
+		require.ErrorContains(t, err, "nonce")
+	})
+
+	t.Run("rejects an email the provider says isn't verified", func(t *testing.T) {
+		provider, idp := startProvider(t, oidc.Config{})
+		idp.SignInAs(map[string]any{"sub": "1234", "email": "fran@example.com", "email_verified": false})
+
+		_, err := provider.Exchange(ctx, signIn(t, provider, "a state", "a nonce"), "a nonce")
+
+		require.ErrorContains(t, err, "hasn't been verified")
+	})
+
+	t.Run("a code can only be used once", func(t *testing.T) {
+		provider, idp := startProvider(t, oidc.Config{})
+		idp.SignInAs(map[string]any{"sub": "1234", "email": "fran@example.com"})
+		code := signIn(t, provider, "a state", "a nonce")
+		_, err := provider.Exchange(ctx, code, "a nonce")
+		require.NoError(t, err)
+
+		_, err = provider.Exchange(ctx, code, "a nonce")
+
+		require.ErrorContains(t, err, "failed to exchange code")
+	})
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Run("fails when the provider can't be discovered", func(t *testing.T) {
+		_, err := oidc.NewProvider(context.Background(), oidc.Config{
+			IssuerURL:   "http://127.0.0.1:1",
+			ClientID:    "incident-reviewer",
+			RedirectURL: "http://localhost/login/oidc/callback",
+		})
+
+		require.ErrorContains(t, err, "failed to discover oidc provider")
+	})
+}
@@ -0,0 +1,382 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
+)
+
+type Service struct {
+	users      UserStorage
+	sessions   SessionStorage
+	tokens     TokenStorage
+	sessionTTL time.Duration
+	groupRoles GroupRoles
+	// linkByEmail allows an identity to take over the existing user with the same email, see WithEmailLinking.
+	linkByEmail bool
+}
+
+type Option func(s *Service)
+
+// WithSessionTTL changes how long sessions are valid, DefaultSessionTTL is used otherwise.
+func WithSessionTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.sessionTTL = ttl
+	}
+}
+
+// WithGroupRoles gives people signing in through an identity provider the role of their groups.
+func WithGroupRoles(groupRoles GroupRoles) Option {
+	return func(s *Service) {
+		s.groupRoles = groupRoles
+	}
+}
+
+// WithEmailLinking lets people signing in through an identity provider for the first time
+// be linked to the existing user with the same email, as long as the provider has verified the email.
+// Without it they can't sign in through the provider until that user is removed.
+func WithEmailLinking() Option {
+	return func(s *Service) {
+		s.linkByEmail = true
+	}
+}
+
+func NewService(users UserStorage, sessions SessionStorage, tokens TokenStorage, opts ...Option) *Service {
+	s := Service{
+		users:      users,
+		sessions:   sessions,
+		tokens:     tokens,
+		sessionTTL: DefaultSessionTTL,
+	}
+
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	return &s
+}
+
+// Save validates and stores the user.
+func (s *Service) Save(ctx context.Context, u User) (User, error) {
+	u.Email = NormalizeEmail(u.Email)
+	if err := validate.Struct(ctx, u); err != nil {
+		return u, fmt.Errorf("failed to validate user: %w", err)
+	}
+
+	u, err := s.users.Save(ctx, u.updateTimestamps())
+	if err != nil {
+		return u, fmt.Errorf("failed to store user: %w", err)
+	}
+
+	return u, nil
+}
+
+// Register creates a new user who can sign in with the email and password.
+func (s *Service) Register(ctx context.Context, email, name, password string) (User, error) {
+	u := NewUser()
+	u.Email = email
+	u.Name = name
+
+	u, err := u.SetPassword(password)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to set password: %w", err)
+	}
+
+	u, err = s.Save(ctx, u)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to register user: %w", err)
+	}
+
+	return u, nil
+}
+
+// CreateUser adds someone who can sign in with the email and password, only admins can add users.
+func (s *Service) CreateUser(ctx context.Context, email, name, password string, role actor.Role) (User, error) {
+	if err := actor.Require(ctx, actor.Admin); err != nil {
+		return User{}, fmt.Errorf("only admins can add users: %w", err)
+	}
+
+	u := NewUser()
+	u.Email = email
+	u.Name = name
+	u.Role = role
+
+	u, err := u.SetPassword(password)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to set password: %w", err)
+	}
+
+	u, err = s.Save(ctx, u)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to add user: %w", err)
+	}
+
+	return u, nil
+}
+
+// ChangePassword changes the password of whoever is signed in, after checking they know the current one.
+func (s *Service) ChangePassword(ctx context.Context, current, password string) (User, error) {
+	userID := actor.ID(ctx)
+	if userID == uuid.Nil {
+		return User{}, fmt.Errorf("only users can change their password: %w", actor.ErrForbidden)
+	}
+
+	u, err := s.users.Get(ctx, userID)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !u.CheckPassword(current) {
+		return User{}, ErrWrongPassword
+	}
+
+	u, err = u.SetPassword(password)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to set password: %w", err)
+	}
+
+	u, err = s.Save(ctx, u)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to change password: %w", err)
+	}
+
+	return u, nil
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (User, error) {
+	u, err := s.users.Get(ctx, id)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return u, nil
+}
+
+func (s *Service) All(ctx context.Context) ([]User, error) {
+	ret, err := s.users.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all users: %w", err)
+	}
+
+	return ret, nil
+}
+
+// SetRole changes what the user is allowed to do, only admins can change roles.
+func (s *Service) SetRole(ctx context.Context, id uuid.UUID, role actor.Role) (User, error) {
+	if err := actor.Require(ctx, actor.Admin); err != nil {
+		return User{}, fmt.Errorf("only admins can change roles: %w", err)
+	}
+
+	u, err := s.users.Get(ctx, id)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	u.Role = role
+
+	u, err = s.Save(ctx, u)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to change role: %w", err)
+	}
+
+	return u, nil
+}
+
+// Login checks the credentials and starts a session, returning the token to hand to the client.
+func (s *Service) Login(ctx context.Context, email, password string) (string, error) {
+	u, err := s.users.GetByEmail(ctx, NormalizeEmail(email))
+	if err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	if !u.CheckPassword(password) {
+		return "", ErrInvalidCredentials
+	}
+
+	return s.StartSession(ctx, u)
+}
+
+// StartSession signs in the user without checking any credentials,
+// it's for when the user has been verified some other way.
+func (s *Service) StartSession(ctx context.Context, u User) (string, error) {
+	session, token := newSession(u.ID, s.sessionTTL)
+	if _, err := s.sessions.Save(ctx, session); err != nil {
+		return "", fmt.Errorf("failed to store session: %w", err)
+	}
+
+	return token, nil
+}
+
+// SignInWithIdentity starts a session for the user an external identity provider has verified.
+// The user is found by the identity, or linked by its verified email to an existing user who isn't linked yet
+// when WithEmailLinking is used, or created when nobody has the email. The name, email and groups are updated from the identity every time,
+// and so is the role when one of the groups has been given a role with WithGroupRoles.
+func (s *Service) SignInWithIdentity(ctx context.Context, i Identity) (string, error) {
+	if err := i.validate(); err != nil {
+		return "", err
+	}
+	i.Email = NormalizeEmail(i.Email)
+
+	u, err := s.users.GetBySubject(ctx, i.Issuer, i.Subject)
+	switch {
+	case errors.Is(err, failure.NotFound):
+		if u, err = s.userForNewIdentity(ctx, i); err != nil {
+			return "", err
+		}
+	case err != nil:
+		return "", fmt.Errorf("failed to get user by identity: %w", err)
+	}
+
+	u = u.applyIdentity(i)
+	if role, ok := s.groupRoles.RoleFor(i.Groups); ok {
+		u.Role = role
+	}
+
+	u, err = s.Save(ctx, u)
+	if err != nil {
+		return "", fmt.Errorf("failed to update user from identity: %w", err)
+	}
+
+	return s.StartSession(ctx, u)
+}
+
+// userForNewIdentity is who signs in the first time someone uses the identity,
+// a new user unless someone already has the email and can be linked to it.
+func (s *Service) userForNewIdentity(ctx context.Context, i Identity) (User, error) {
+	u, err := s.users.GetByEmail(ctx, i.Email)
+	switch {
+	case errors.Is(err, failure.NotFound):
+		return NewUser(), nil
+	case err != nil:
+		return User{}, fmt.Errorf("failed to get user by email: %w", err)
+	case u.Subject != "":
+		return User{}, ErrIdentityMismatch
+	case !s.linkByEmail || !i.EmailVerified:
+		return User{}, ErrIdentityNotLinkable
+	}
+
+	return u, nil
+}
+
+// Authenticate returns the user the session token belongs to.
+func (s *Service) Authenticate(ctx context.Context, token string) (User, error) {
+	if token == "" {
+		return User{}, ErrInvalidSession
+	}
+
+	session, err := s.sessions.Get(ctx, HashToken(token))
+	if err != nil {
+		return User{}, ErrInvalidSession
+	}
+
+	if session.IsExpired(time.Now()) {
+		// Best effort cleanup, the session is invalid either way.
+		_ = s.sessions.Delete(ctx, session.TokenHash)
+		return User{}, ErrInvalidSession
+	}
+
+	u, err := s.users.Get(ctx, session.UserID)
+	if err != nil {
+		return User{}, errors.Join(ErrInvalidSession, err)
+	}
+
+	return u, nil
+}
+
+// Logout ends the session.
+func (s *Service) Logout(ctx context.Context, token string) error {
+	if err := s.sessions.Delete(ctx, HashToken(token)); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	return nil
+}
+
+// tokenLastUsedPrecision is how often using a token is recorded, so not every request has to store it.
+const tokenLastUsedPrecision = time.Minute
+
+// CreateToken creates an API token for whoever is signed in and returns the secret,
+// which is only available now since only its hash is stored.
+func (s *Service) CreateToken(ctx context.Context, name string, scope TokenScope) (Token, string, error) {
+	userID := actor.ID(ctx)
+	if userID == uuid.Nil {
+		return Token{}, "", fmt.Errorf("only users can create tokens: %w", actor.ErrForbidden)
+	}
+
+	token, secret := newToken(userID, name, scope)
+	if err := validate.Struct(ctx, token); err != nil {
+		return Token{}, "", fmt.Errorf("failed to validate token: %w", err)
+	}
+
+	token, err := s.tokens.Save(ctx, token)
+	if err != nil {
+		return Token{}, "", fmt.Errorf("failed to store token: %w", err)
+	}
+
+	return token, secret, nil
+}
+
+// Tokens returns the API tokens of whoever is signed in, including the revoked ones.
+func (s *Service) Tokens(ctx context.Context) ([]Token, error) {
+	ret, err := s.tokens.ForUser(ctx, actor.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tokens: %w", err)
+	}
+
+	return ret, nil
+}
+
+// RevokeToken stops the token from being used, only its user and admins can revoke it.
+func (s *Service) RevokeToken(ctx context.Context, id uuid.UUID) (Token, error) {
+	token, err := s.tokens.Get(ctx, id)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	if err := actor.Require(ctx, func(a actor.Actor) bool { return a.ID == token.UserID || actor.Admin(a) }); err != nil {
+		return Token{}, fmt.Errorf("only the owner of a token can revoke it: %w", err)
+	}
+	if token.IsRevoked() {
+		return token, nil
+	}
+	token.RevokedAt = time.Now()
+
+	token, err = s.tokens.Save(ctx, token)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	return token, nil
+}
+
+// AuthenticateToken returns the user the API token belongs to and the token, to know what it's allowed to do.
+func (s *Service) AuthenticateToken(ctx context.Context, secret string) (User, Token, error) {
+	if secret == "" {
+		return User{}, Token{}, ErrInvalidToken
+	}
+
+	token, err := s.tokens.GetBySecretHash(ctx, HashToken(secret))
+	if err != nil || token.IsRevoked() {
+		return User{}, Token{}, ErrInvalidToken
+	}
+
+	u, err := s.users.Get(ctx, token.UserID)
+	if err != nil {
+		return User{}, Token{}, errors.Join(ErrInvalidToken, err)
+	}
+
+	if now := time.Now(); now.Sub(token.LastUsedAt) >= tokenLastUsedPrecision {
+		token.LastUsedAt = now
+		// Best effort, the token is valid either way.
+		if saved, err := s.tokens.Save(ctx, token); err == nil {
+			token = saved
+		}
+	}
+
+	return u, token, nil
+}
@@ -0,0 +1,449 @@
+package accounts_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/internal/accounts/storage"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func newService(opts ...accounts.Option) *accounts.Service {
+	return accounts.NewService(storage.NewUserMemoryStore(), storage.NewSessionMemoryStore(), storage.NewTokenMemoryStore(), opts...)
+}
+
+// failingUserStore fails to look up users by their identity.
+type failingUserStore struct {
+	*storage.UserMemoryStore
+}
+
+func (failingUserStore) GetBySubject(context.Context, string, string) (accounts.User, error) {
+	return accounts.User{}, errors.New("storage is down")
+}
+
+func TestService_Register(t *testing.T) {
+	t.Run("normalizes the email and sets the timestamps", func(t *testing.T) {
+		service := newService()
+
+		u, err := service.Register(context.Background(), " Fran@Example.com", "Fran", "a long enough password")
+
+		require.NoError(t, err)
+		require.Equal(t, "fran@example.com", u.Email)
+		require.NotZero(t, u.CreatedAt)
+	})
+
+	t.Run("the same email can't be registered twice", func(t *testing.T) {
+		service := newService()
+		_, err := service.Register(context.Background(), "fran@example.com", "Fran", "a long enough password")
+		require.NoError(t, err)
+
+		_, err = service.Register(context.Background(), "FRAN@example.com", "Fran again", "a long enough password")
+
+		require.ErrorIs(t, err, storage.ErrEmailTaken)
+	})
+
+	t.Run("returns validation errors for an invalid email", func(t *testing.T) {
+		_, err := newService().Register(context.Background(), "not an email", "Fran", "a long enough password")
+
+		require.ErrorContains(t, err, "failed to validate user:")
+	})
+}
+
+func TestService_Login(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("with the right credentials a session is started for the user", func(t *testing.T) {
+		service := newService()
+		u, err := service.Save(ctx, a.User().WithPassword("a long enough password").Build())
+		require.NoError(t, err)
+
+		token, err := service.Login(ctx, "Facilitator@example.com", "a long enough password")
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+
+		actual, err := service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, u.ID, actual.ID)
+	})
+
+	t.Run("the same error is returned for an unknown email and a wrong password", func(t *testing.T) {
+		service := newService()
+		_, err := service.Save(ctx, a.User().WithPassword("a long enough password").Build())
+		require.NoError(t, err)
+
+		_, err = service.Login(ctx, "nobody@example.com", "a long enough password")
+		require.ErrorIs(t, err, accounts.ErrInvalidCredentials)
+
+		_, err = service.Login(ctx, "facilitator@example.com", "the wrong password")
+		require.ErrorIs(t, err, accounts.ErrInvalidCredentials)
+	})
+}
+
+func TestService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("an unknown token is an invalid session", func(t *testing.T) {
+		_, err := newService().Authenticate(ctx, "unknown")
+
+		require.ErrorIs(t, err, accounts.ErrInvalidSession)
+	})
+
+	t.Run("an expired session is an invalid session", func(t *testing.T) {
+		service := newService(accounts.WithSessionTTL(-time.Second))
+		u, err := service.Save(ctx, a.User().Build())
+		require.NoError(t, err)
+		token, err := service.StartSession(ctx, u)
+		require.NoError(t, err)
+
+		_, err = service.Authenticate(ctx, token)
+
+		require.ErrorIs(t, err, accounts.ErrInvalidSession)
+	})
+
+	t.Run("after logging out the session is no longer valid", func(t *testing.T) {
+		service := newService()
+		u, err := service.Save(ctx, a.User().Build())
+		require.NoError(t, err)
+		token, err := service.StartSession(ctx, u)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Logout(ctx, token))
+		_, err = service.Authenticate(ctx, token)
+
+		require.ErrorIs(t, err, accounts.ErrInvalidSession)
+	})
+}
+
+func TestService_SignInWithIdentity(t *testing.T) {
+	ctx := context.Background()
+	identity := accounts.Identity{
+		Issuer:        "https://idp.example.com",
+		Subject:       "1234",
+		Email:         "Fran@Example.com",
+		EmailVerified: true,
+		Name:          "Fran Facilitator",
+		Groups:        []string{"sre"},
+	}
+
+	t.Run("creates a user the first time someone signs in", func(t *testing.T) {
+		service := newService()
+
+		token, err := service.SignInWithIdentity(ctx, identity)
+		require.NoError(t, err)
+
+		actual, err := service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, "fran@example.com", actual.Email)
+		require.Equal(t, "Fran Facilitator", actual.Name)
+		require.Equal(t, []string{"sre"}, actual.Groups)
+		require.Equal(t, "1234", actual.Subject)
+		require.False(t, actual.CheckPassword(""), "expected the user to not be able to sign in with a password")
+	})
+
+	t.Run("the same user is updated from the identity on the next sign in", func(t *testing.T) {
+		service := newService()
+		first, err := service.SignInWithIdentity(ctx, identity)
+		require.NoError(t, err)
+		before, err := service.Authenticate(ctx, first)
+		require.NoError(t, err)
+
+		changed := identity
+		changed.Email = "fran.f@example.com"
+		changed.Groups = []string{"sre", "incident-commanders"}
+		second, err := service.SignInWithIdentity(ctx, changed)
+		require.NoError(t, err)
+
+		after, err := service.Authenticate(ctx, second)
+		require.NoError(t, err)
+		require.Equal(t, before.ID, after.ID)
+		require.Equal(t, "fran.f@example.com", after.Email)
+		require.Equal(t, []string{"sre", "incident-commanders"}, after.Groups)
+	})
+
+	t.Run("links to an existing user with the same email who can still sign in with their password", func(t *testing.T) {
+		service := newService(accounts.WithEmailLinking())
+		u, err := service.Save(ctx, a.User().WithEmail("fran@example.com").WithPassword("a long enough password").Build())
+		require.NoError(t, err)
+
+		token, err := service.SignInWithIdentity(ctx, identity)
+		require.NoError(t, err)
+
+		actual, err := service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, u.ID, actual.ID)
+		_, err = service.Login(ctx, "fran@example.com", "a long enough password")
+		require.NoError(t, err)
+	})
+
+	t.Run("doesn't link to an existing user by email unless it's turned on", func(t *testing.T) {
+		service := newService()
+		_, err := service.Save(ctx, a.User().WithEmail("fran@example.com").WithPassword("a long enough password").Build())
+		require.NoError(t, err)
+
+		_, err = service.SignInWithIdentity(ctx, identity)
+
+		require.ErrorIs(t, err, accounts.ErrIdentityNotLinkable)
+	})
+
+	t.Run("doesn't link to an existing user when the provider hasn't verified the email", func(t *testing.T) {
+		service := newService(accounts.WithEmailLinking())
+		admin, err := service.Save(ctx, a.User().WithEmail("fran@example.com").WithRole(actor.RoleAdmin).WithPassword("a long enough password").Build())
+		require.NoError(t, err)
+		unverified := identity
+		unverified.EmailVerified = false
+
+		_, err = service.SignInWithIdentity(ctx, unverified)
+
+		require.ErrorIs(t, err, accounts.ErrIdentityNotLinkable)
+		actual, err := service.Get(ctx, admin.ID)
+		require.NoError(t, err)
+		require.Empty(t, actual.Subject, "expected the admin to not be linked to the identity")
+	})
+
+	t.Run("fails when the user can't be looked up by the identity", func(t *testing.T) {
+		service := accounts.NewService(failingUserStore{storage.NewUserMemoryStore()}, storage.NewSessionMemoryStore(), storage.NewTokenMemoryStore())
+
+		_, err := service.SignInWithIdentity(ctx, identity)
+
+		require.ErrorContains(t, err, "failed to get user by identity: storage is down")
+	})
+
+	t.Run("doesn't take over a user linked to another identity", func(t *testing.T) {
+		service := newService(accounts.WithEmailLinking())
+		_, err := service.Save(ctx, a.User().WithEmail("fran@example.com").WithIdentity("https://idp.example.com", "5678").Build())
+		require.NoError(t, err)
+
+		_, err = service.SignInWithIdentity(ctx, identity)
+
+		require.ErrorIs(t, err, accounts.ErrIdentityMismatch)
+	})
+
+	t.Run("an identity without an email can't sign in", func(t *testing.T) {
+		incomplete := identity
+		incomplete.Email = ""
+
+		_, err := newService().SignInWithIdentity(ctx, incomplete)
+
+		require.ErrorIs(t, err, accounts.ErrIncompleteIdentity)
+	})
+}
+
+func TestService_SetRole(t *testing.T) {
+	ctx := context.Background()
+	admin := actor.With(ctx, a.Actor().WithRole(actor.RoleAdmin).Build())
+
+	t.Run("an admin can change the role of a user", func(t *testing.T) {
+		service := newService()
+		u, err := service.Save(ctx, a.User().WithRole(actor.RoleViewer).Build())
+		require.NoError(t, err)
+
+		actual, err := service.SetRole(admin, u.ID, actor.RoleContributor)
+
+		require.NoError(t, err)
+		require.Equal(t, actor.RoleContributor, actual.Role)
+	})
+
+	t.Run("nobody else can change roles", func(t *testing.T) {
+		service := newService()
+		u, err := service.Save(ctx, a.User().WithRole(actor.RoleViewer).Build())
+		require.NoError(t, err)
+
+		_, err = service.SetRole(actor.With(ctx, u.Actor()), u.ID, actor.RoleAdmin)
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
+	t.Run("an unknown role isn't allowed", func(t *testing.T) {
+		service := newService()
+		u, err := service.Save(ctx, a.User().Build())
+		require.NoError(t, err)
+
+		_, err = service.SetRole(admin, u.ID, actor.Role("superuser"))
+
+		require.ErrorContains(t, err, "failed to validate user:")
+	})
+}
+
+func TestService_CreateUser(t *testing.T) {
+	ctx := context.Background()
+	admin := actor.With(ctx, a.Actor().WithRole(actor.RoleAdmin).Build())
+
+	t.Run("an admin can add someone who can then sign in", func(t *testing.T) {
+		service := newService()
+
+		u, err := service.CreateUser(admin, "Fran@example.com", "Fran", "a long enough password", actor.RoleContributor)
+
+		require.NoError(t, err)
+		require.Equal(t, "fran@example.com", u.Email)
+		require.Equal(t, actor.RoleContributor, u.Role)
+		_, err = service.Login(ctx, "fran@example.com", "a long enough password")
+		require.NoError(t, err)
+	})
+
+	t.Run("nobody else can add users", func(t *testing.T) {
+		facilitator := actor.With(ctx, a.Actor().WithRole(actor.RoleFacilitator).Build())
+
+		_, err := newService().CreateUser(facilitator, "fran@example.com", "Fran", "a long enough password", actor.RoleViewer)
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
+	t.Run("a password that's too short isn't allowed", func(t *testing.T) {
+		_, err := newService().CreateUser(admin, "fran@example.com", "Fran", "short", actor.RoleViewer)
+
+		require.ErrorContains(t, err, "failed to set password:")
+	})
+}
+
+func TestService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("the new password is used for signing in after it's changed", func(t *testing.T) {
+		service := newService()
+		u, err := service.Save(ctx, a.User().WithPassword("a long enough password").Build())
+		require.NoError(t, err)
+
+		_, err = service.ChangePassword(actor.With(ctx, u.Actor()), "a long enough password", "another long password")
+
+		require.NoError(t, err)
+		_, err = service.Login(ctx, u.Email, "a long enough password")
+		require.ErrorIs(t, err, accounts.ErrInvalidCredentials)
+		_, err = service.Login(ctx, u.Email, "another long password")
+		require.NoError(t, err)
+	})
+
+	t.Run("the current password has to be given", func(t *testing.T) {
+		service := newService()
+		u, err := service.Save(ctx, a.User().WithPassword("a long enough password").Build())
+		require.NoError(t, err)
+
+		_, err = service.ChangePassword(actor.With(ctx, u.Actor()), "not my password", "another long password")
+
+		require.ErrorIs(t, err, accounts.ErrWrongPassword)
+	})
+
+	t.Run("only users can change their password", func(t *testing.T) {
+		_, err := newService().ChangePassword(ctx, "a long enough password", "another long password")
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+}
+
+func TestService_Tokens(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("a created token authenticates as its user until it's revoked", func(t *testing.T) {
+		service := newService()
+		u, err := service.Save(ctx, a.User().Build())
+		require.NoError(t, err)
+		userCtx := actor.With(ctx, u.Actor())
+
+		token, secret, err := service.CreateToken(userCtx, "deploy script", accounts.ScopeReadOnly)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(secret, accounts.TokenPrefix), "expected the secret to be recognizable")
+		require.NotContains(t, token.SecretHash, secret, "expected only the hash to be stored")
+
+		authenticated, usedToken, err := service.AuthenticateToken(ctx, secret)
+		require.NoError(t, err)
+		require.Equal(t, u.ID, authenticated.ID)
+		require.Equal(t, accounts.ScopeReadOnly, usedToken.Scope)
+		require.NotZero(t, usedToken.LastUsedAt, "expected when it was used to be recorded")
+
+		_, err = service.RevokeToken(userCtx, token.ID)
+		require.NoError(t, err)
+
+		_, _, err = service.AuthenticateToken(ctx, secret)
+		require.ErrorIs(t, err, accounts.ErrInvalidToken)
+	})
+
+	t.Run("an unknown secret is an invalid token", func(t *testing.T) {
+		_, _, err := newService().AuthenticateToken(ctx, accounts.TokenPrefix+"made-up")
+
+		require.ErrorIs(t, err, accounts.ErrInvalidToken)
+	})
+
+	t.Run("a token needs a name and a known scope", func(t *testing.T) {
+		userCtx := actor.With(ctx, a.Actor().Build())
+
+		_, _, err := newService().CreateToken(userCtx, "", accounts.TokenScope("everything"))
+
+		require.ErrorContains(t, err, "failed to validate token:")
+	})
+
+	t.Run("only users can create tokens", func(t *testing.T) {
+		_, _, err := newService().CreateToken(actor.With(ctx, actor.System), "system", accounts.ScopeReadWrite)
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
+	t.Run("only lists the tokens of whoever is signed in", func(t *testing.T) {
+		service := newService()
+		mine := actor.With(ctx, a.Actor().Build())
+		theirs := actor.With(ctx, a.Actor().WithID(a.UUID()).Build())
+		token, _, err := service.CreateToken(mine, "mine", accounts.ScopeReadWrite)
+		require.NoError(t, err)
+		_, _, err = service.CreateToken(theirs, "theirs", accounts.ScopeReadWrite)
+		require.NoError(t, err)
+
+		tokens, err := service.Tokens(mine)
+
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		require.Equal(t, token.ID, tokens[0].ID)
+	})
+
+	t.Run("only the owner or an admin can revoke a token", func(t *testing.T) {
+		service := newService()
+		token, _, err := service.CreateToken(actor.With(ctx, a.Actor().Build()), "mine", accounts.ScopeReadWrite)
+		require.NoError(t, err)
+
+		_, err = service.RevokeToken(actor.With(ctx, a.Actor().WithID(a.UUID()).Build()), token.ID)
+		require.ErrorIs(t, err, actor.ErrForbidden)
+
+		revoked, err := service.RevokeToken(actor.With(ctx, a.Actor().WithID(a.UUID()).WithRole(actor.RoleAdmin).Build()), token.ID)
+		require.NoError(t, err)
+		require.True(t, revoked.IsRevoked())
+	})
+}
+
+func TestService_SignInWithIdentity_groupRoles(t *testing.T) {
+	ctx := context.Background()
+	service := newService(accounts.WithGroupRoles(accounts.GroupRoles{"sre": actor.RoleFacilitator, "engineering": actor.RoleContributor}))
+	identity := accounts.Identity{Issuer: "https://idp.example.com", Subject: "1234", Email: "fran@example.com"}
+
+	for _, tc := range []struct {
+		groups   []string
+		expected actor.Role
+	}{
+		{nil, actor.RoleViewer},
+		{[]string{"engineering"}, actor.RoleContributor},
+		{[]string{"engineering", "sre"}, actor.RoleFacilitator},
+	} {
+		identity.Groups = tc.groups
+		token, err := service.SignInWithIdentity(ctx, identity)
+		require.NoError(t, err)
+
+		u, err := service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, u.Role, "for groups %v", tc.groups)
+	}
+}
+
+func TestParseGroupRoles(t *testing.T) {
+	actual, err := accounts.ParseGroupRoles("sre-leads=admin, sre = facilitator,")
+	require.NoError(t, err)
+	require.Equal(t, accounts.GroupRoles{"sre-leads": actor.RoleAdmin, "sre": actor.RoleFacilitator}, actual)
+
+	_, err = accounts.ParseGroupRoles("sre=superuser")
+	require.ErrorContains(t, err, "unknown role")
+
+	_, err = accounts.ParseGroupRoles("sre")
+	require.ErrorContains(t, err, "expected group=role")
+}
@@ -0,0 +1,53 @@
+package accounts
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultSessionTTL is how long someone stays signed in without signing in again.
+const DefaultSessionTTL = 7 * 24 * time.Hour
+
+// ErrInvalidSession is returned when a session token is unknown or has expired.
+var ErrInvalidSession = errors.New("invalid or expired session")
+
+// Session is a signed in user.
+// Only a hash of the token is kept so a leaked store can't be used to sign in as someone.
+type Session struct {
+	TokenHash string
+	UserID    uuid.UUID
+
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// IsExpired checks whether the session is no longer valid at now.
+func (s Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// newSession creates a session for the user and returns the token to give to the client.
+func newSession(userID uuid.UUID, ttl time.Duration) (Session, string) {
+	token := rand.Text()
+	now := time.Now()
+
+	return Session{
+		TokenHash: HashToken(token),
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, token
+}
+
+// HashToken is how tokens are stored and looked up.
+// A fast hash is fine here since the tokens are long and random, unlike passwords.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,46 @@
+package accounts
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UserStorage interface {
+	// Save stores the user, the email has to be unique across users.
+	Save(ctx context.Context, user User) (User, error)
+
+	// Get finds the user or returns NoUserError.
+	Get(ctx context.Context, id uuid.UUID) (User, error)
+
+	// GetByEmail finds the user by their normalized email or returns NoUserError.
+	GetByEmail(ctx context.Context, email string) (User, error)
+
+	// GetBySubject finds the user linked to the identity from the issuer or returns NoUserError.
+	GetBySubject(ctx context.Context, issuer, subject string) (User, error)
+
+	// All returns all the users with the most recently created first.
+	All(ctx context.Context) ([]User, error)
+}
+
+type SessionStorage interface {
+	Save(ctx context.Context, session Session) (Session, error)
+
+	// Get finds the session by the hash of its token or returns NoSessionError.
+	Get(ctx context.Context, tokenHash string) (Session, error)
+
+	Delete(ctx context.Context, tokenHash string) error
+}
+
+type TokenStorage interface {
+	Save(ctx context.Context, token Token) (Token, error)
+
+	// Get finds the token or returns NoTokenError.
+	Get(ctx context.Context, id uuid.UUID) (Token, error)
+
+	// GetBySecretHash finds the token by the hash of its secret or returns NoTokenError.
+	GetBySecretHash(ctx context.Context, secretHash string) (Token, error)
+
+	// ForUser returns the tokens of the user with the most recently created first.
+	ForUser(ctx context.Context, userID uuid.UUID) ([]Token, error)
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+type NoUserError struct {
+	ID    uuid.UUID
+	Email string
+	// Issuer and Subject are set when looking up a user by their external identity.
+	Issuer  string
+	Subject string
+}
+
+func (e *NoUserError) Error() string {
+	if e.Subject != "" {
+		return fmt.Sprintf("user not found by subject: %s from %s", e.Subject, e.Issuer)
+	}
+	if e.Email != "" {
+		return fmt.Sprintf("user not found by email: %s", e.Email)
+	}
+
+	return fmt.Sprintf("user not found by id: %s", e.ID)
+}
+
+// Is makes it a failure.NotFound.
+func (e *NoUserError) Is(target error) bool {
+	return target == failure.NotFound
+}
+
+// ErrNoID indicates that the passed in ID is blank/uninitialized.
+var ErrNoID = errors.New("can't store user because ID is not set")
+
+// ErrEmailTaken indicates another user already has the email.
+var ErrEmailTaken = failure.New(failure.Conflict, "email is already used by another user")
+
+// ErrNoSession indicates that there's no session for the token.
+var ErrNoSession = failure.New(failure.NotFound, "session not found")
+
+type NoTokenError struct {
+	ID uuid.UUID
+}
+
+func (e *NoTokenError) Error() string {
+	if e.ID == uuid.Nil {
+		return "token not found by secret"
+	}
+
+	return fmt.Sprintf("token not found by id: %s", e.ID)
+}
+
+// Is makes it a failure.NotFound.
+func (e *NoTokenError) Is(target error) bool {
+	return target == failure.NotFound
+}
@@ -0,0 +1,206 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+)
+
+type UserMemoryStore struct {
+	mu   sync.RWMutex
+	data map[uuid.UUID]accounts.User
+}
+
+func NewUserMemoryStore() *UserMemoryStore {
+	return &UserMemoryStore{
+		data: make(map[uuid.UUID]accounts.User),
+	}
+}
+
+func (s *UserMemoryStore) Save(_ context.Context, u accounts.User) (accounts.User, error) {
+	if u.ID == uuid.Nil {
+		return accounts.User{}, ErrNoID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, other := range s.data {
+		if other.ID != u.ID && other.Email == u.Email {
+			return accounts.User{}, ErrEmailTaken
+		}
+	}
+	s.data[u.ID] = u
+
+	return u, nil
+}
+
+func (s *UserMemoryStore) Get(_ context.Context, id uuid.UUID) (accounts.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.data[id]
+	if !ok {
+		return accounts.User{}, &NoUserError{ID: id}
+	}
+
+	return u, nil
+}
+
+func (s *UserMemoryStore) GetByEmail(_ context.Context, email string) (accounts.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.data {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+
+	return accounts.User{}, &NoUserError{Email: email}
+}
+
+func (s *UserMemoryStore) GetBySubject(_ context.Context, issuer, subject string) (accounts.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.data {
+		if u.Issuer == issuer && u.Subject == subject {
+			return u, nil
+		}
+	}
+
+	return accounts.User{}, &NoUserError{Issuer: issuer, Subject: subject}
+}
+
+func (s *UserMemoryStore) All(_ context.Context) ([]accounts.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ret := make([]accounts.User, 0, len(s.data))
+
+	// The IDs are UUIDv7 and sort by when they were created, also within the same millisecond,
+	// reverse for the most recent first
+	keys := slices.SortedFunc(maps.Keys(s.data), func(u uuid.UUID, u2 uuid.UUID) int {
+		return bytes.Compare(u[:], u2[:])
+	})
+	slices.Reverse(keys)
+
+	for _, k := range keys {
+		ret = append(ret, s.data[k])
+	}
+
+	return ret, nil
+}
+
+type SessionMemoryStore struct {
+	mu   sync.RWMutex
+	data map[string]accounts.Session
+}
+
+func NewSessionMemoryStore() *SessionMemoryStore {
+	return &SessionMemoryStore{
+		data: make(map[string]accounts.Session),
+	}
+}
+
+func (s *SessionMemoryStore) Save(_ context.Context, session accounts.Session) (accounts.Session, error) {
+	if session.TokenHash == "" {
+		return accounts.Session{}, ErrNoSession
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[session.TokenHash] = session
+
+	return session, nil
+}
+
+func (s *SessionMemoryStore) Get(_ context.Context, tokenHash string) (accounts.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.data[tokenHash]
+	if !ok {
+		return accounts.Session{}, ErrNoSession
+	}
+
+	return session, nil
+}
+
+func (s *SessionMemoryStore) Delete(_ context.Context, tokenHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, tokenHash)
+
+	return nil
+}
+
+type TokenMemoryStore struct {
+	mu   sync.RWMutex
+	data map[uuid.UUID]accounts.Token
+}
+
+func NewTokenMemoryStore() *TokenMemoryStore {
+	return &TokenMemoryStore{
+		data: make(map[uuid.UUID]accounts.Token),
+	}
+}
+
+func (s *TokenMemoryStore) Save(_ context.Context, token accounts.Token) (accounts.Token, error) {
+	if token.ID == uuid.Nil {
+		return accounts.Token{}, ErrNoID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[token.ID] = token
+
+	return token, nil
+}
+
+func (s *TokenMemoryStore) Get(_ context.Context, id uuid.UUID) (accounts.Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	token, ok := s.data[id]
+	if !ok {
+		return accounts.Token{}, &NoTokenError{ID: id}
+	}
+
+	return token, nil
+}
+
+func (s *TokenMemoryStore) GetBySecretHash(_ context.Context, secretHash string) (accounts.Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, token := range s.data {
+		if token.SecretHash == secretHash {
+			return token, nil
+		}
+	}
+
+	return accounts.Token{}, &NoTokenError{}
+}
+
+func (s *TokenMemoryStore) ForUser(_ context.Context, userID uuid.UUID) ([]accounts.Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ret []accounts.Token
+	for _, token := range s.data {
+		if token.UserID == userID {
+			ret = append(ret, token)
+		}
+	}
+	slices.SortFunc(ret, func(a, b accounts.Token) int { return b.CreatedAt.Compare(a.CreatedAt) })
+
+	return ret, nil
+}
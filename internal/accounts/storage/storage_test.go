@@ -0,0 +1,199 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/internal/accounts/storage"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestUserMemoryStore(t *testing.T) {
+	UserStorageTest(t, context.Background(), func() accounts.UserStorage { return storage.NewUserMemoryStore() })
+}
+
+func TestSessionMemoryStore(t *testing.T) {
+	SessionStorageTest(t, context.Background(), func() accounts.SessionStorage { return storage.NewSessionMemoryStore() })
+}
+
+func TestTokenMemoryStore(t *testing.T) {
+	TokenStorageTest(t, context.Background(), func() accounts.TokenStorage { return storage.NewTokenMemoryStore() })
+}
+
+// UserStorageTest is a base suite used to test across the implementations of accounts.UserStorage.
+func UserStorageTest(t *testing.T, ctx context.Context, storeFactory func() accounts.UserStorage) {
+	t.Run("Save", func(t *testing.T) {
+		t.Run("returns an error when the ID isn't set", func(t *testing.T) {
+			_, err := storeFactory().Save(ctx, accounts.User{})
+
+			require.ErrorIs(t, err, storage.ErrNoID)
+		})
+
+		t.Run("returns an error when another user has the email", func(t *testing.T) {
+			store := storeFactory()
+			_, err := store.Save(ctx, a.User().Build())
+			require.NoError(t, err)
+
+			_, err = store.Save(ctx, a.User().WithID(a.UUID()).Build())
+
+			require.ErrorIs(t, err, storage.ErrEmailTaken)
+		})
+
+		t.Run("the same user can be saved again", func(t *testing.T) {
+			store := storeFactory()
+			_, err := store.Save(ctx, a.User().Build())
+			require.NoError(t, err)
+
+			_, err = store.Save(ctx, a.User().Build())
+
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("returns NoUserError when it doesn't exist", func(t *testing.T) {
+			_, err := storeFactory().Get(ctx, uuid.Must(uuid.NewV7()))
+
+			var actualErr *storage.NoUserError
+			require.ErrorAs(t, err, &actualErr)
+			require.ErrorIs(t, err, failure.NotFound, "expected it to be a not found failure")
+		})
+
+		t.Run("returns the saved user by ID and email", func(t *testing.T) {
+			store := storeFactory()
+			expected, err := store.Save(ctx, a.User().Build())
+			require.NoError(t, err)
+
+			actual, err := store.Get(ctx, expected.ID)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+
+			actual, err = store.GetByEmail(ctx, expected.Email)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		})
+	})
+
+	t.Run("GetBySubject", func(t *testing.T) {
+		t.Run("returns NoUserError when nobody is linked to the identity", func(t *testing.T) {
+			_, err := storeFactory().GetBySubject(ctx, "https://idp.example.com", "1234")
+
+			var actualErr *storage.NoUserError
+			require.ErrorAs(t, err, &actualErr)
+		})
+
+		t.Run("returns the user linked to the identity", func(t *testing.T) {
+			store := storeFactory()
+			expected, err := store.Save(ctx, a.User().WithIdentity("https://idp.example.com", "1234").Build())
+			require.NoError(t, err)
+
+			actual, err := store.GetBySubject(ctx, "https://idp.example.com", "1234")
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+
+			_, err = store.GetBySubject(ctx, "https://other.example.com", "1234")
+			require.Error(t, err, "expected the subject to only match with the same issuer")
+		})
+	})
+
+	t.Run("All returns the most recently created first", func(t *testing.T) {
+		store := storeFactory()
+		first, err := store.Save(ctx, a.User().WithID(uuid.Must(uuid.NewV7())).WithEmail("first@example.com").Build())
+		require.NoError(t, err)
+		second, err := store.Save(ctx, a.User().WithID(uuid.Must(uuid.NewV7())).WithEmail("second@example.com").Build())
+		require.NoError(t, err)
+
+		actual, err := store.All(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, []accounts.User{second, first}, actual)
+	})
+}
+
+// SessionStorageTest is a base suite used to test across the implementations of accounts.SessionStorage.
+func SessionStorageTest(t *testing.T, ctx context.Context, storeFactory func() accounts.SessionStorage) {
+	t.Run("a saved session can be found by its token hash until it's deleted", func(t *testing.T) {
+		store := storeFactory()
+		expected := accounts.Session{TokenHash: accounts.HashToken("token"), UserID: a.User().Build().ID}
+
+		_, err := store.Save(ctx, expected)
+		require.NoError(t, err)
+
+		actual, err := store.Get(ctx, expected.TokenHash)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		require.NoError(t, store.Delete(ctx, expected.TokenHash))
+		_, err = store.Get(ctx, expected.TokenHash)
+		require.ErrorIs(t, err, storage.ErrNoSession)
+	})
+}
+
+// TokenStorageTest is a base suite used to test across the implementations of accounts.TokenStorage.
+func TokenStorageTest(t *testing.T, ctx context.Context, storeFactory func() accounts.TokenStorage) {
+	token := accounts.Token{
+		ID:         a.UUID(),
+		UserID:     a.User().Build().ID,
+		Name:       "deploy script",
+		Scope:      accounts.ScopeReadOnly,
+		SecretHash: accounts.HashToken("secret"),
+		CreatedAt:  time.Date(2025, 5, 6, 10, 20, 30, 0, time.UTC),
+	}
+
+	t.Run("returns an error when the ID isn't set", func(t *testing.T) {
+		_, err := storeFactory().Save(ctx, accounts.Token{})
+
+		require.ErrorIs(t, err, storage.ErrNoID)
+	})
+
+	t.Run("returns NoTokenError when it doesn't exist", func(t *testing.T) {
+		store := storeFactory()
+		var actualErr *storage.NoTokenError
+
+		_, err := store.Get(ctx, token.ID)
+		require.ErrorAs(t, err, &actualErr)
+
+		_, err = store.GetBySecretHash(ctx, token.SecretHash)
+		require.ErrorAs(t, err, &actualErr)
+	})
+
+	t.Run("a saved token can be found by its ID and the hash of its secret", func(t *testing.T) {
+		store := storeFactory()
+		_, err := store.Save(ctx, token)
+		require.NoError(t, err)
+
+		actual, err := store.Get(ctx, token.ID)
+		require.NoError(t, err)
+		require.Equal(t, token, actual)
+
+		actual, err = store.GetBySecretHash(ctx, token.SecretHash)
+		require.NoError(t, err)
+		require.Equal(t, token, actual)
+	})
+
+	t.Run("ForUser returns only the user's tokens with the most recently created first", func(t *testing.T) {
+		store := storeFactory()
+		newer := token
+		newer.ID = a.UUID()
+		newer.SecretHash = accounts.HashToken("newer")
+		newer.CreatedAt = token.CreatedAt.Add(time.Hour)
+		someoneElses := token
+		someoneElses.ID = a.UUID()
+		someoneElses.UserID = a.UUID()
+		for _, tok := range []accounts.Token{token, newer, someoneElses} {
+			_, err := store.Save(ctx, tok)
+			require.NoError(t, err)
+		}
+
+		actual, err := store.ForUser(ctx, token.UserID)
+
+		require.NoError(t, err)
+		require.Equal(t, []accounts.Token{newer, token}, actual)
+	})
+}
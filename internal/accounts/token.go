@@ -0,0 +1,62 @@
+package accounts
+
+import (
+	"crypto/rand"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenPrefix starts every API token, so they're easy to recognize when they end up somewhere they shouldn't.
+const TokenPrefix = "irt_"
+
+// ErrInvalidToken is returned when an API token is unknown or has been revoked.
+var ErrInvalidToken = errors.New("invalid or revoked token")
+
+// TokenScope is what an API token is allowed to do, never more than the user it belongs to.
+type TokenScope string
+
+const (
+	ScopeReadOnly  TokenScope = "read-only"
+	ScopeReadWrite TokenScope = "read-write"
+)
+
+var TokenScopes = []TokenScope{ScopeReadOnly, ScopeReadWrite}
+
+// AllowsChanges is true for tokens that can change things and not only read them.
+func (s TokenScope) AllowsChanges() bool {
+	return s == ScopeReadWrite
+}
+
+// Token is a personal API token for scripts and other machine clients.
+// Only a hash of the secret is kept, like for sessions.
+type Token struct {
+	ID         uuid.UUID  `validate:"required"`
+	UserID     uuid.UUID  `validate:"required"`
+	Name       string     `validate:"required"`
+	Scope      TokenScope `validate:"oneof=read-only read-write"`
+	SecretHash string     `validate:"required"`
+
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+}
+
+// newToken creates a token for the user and returns the secret to give to them, it can't be recovered later.
+func newToken(userID uuid.UUID, name string, scope TokenScope) (Token, string) {
+	secret := TokenPrefix + rand.Text()
+
+	return Token{
+		ID:         uuid.Must(uuid.NewV7()),
+		UserID:     userID,
+		Name:       name,
+		Scope:      scope,
+		SecretHash: HashToken(secret),
+		CreatedAt:  time.Now(),
+	}, secret
+}
+
+func (t Token) IsRevoked() bool {
+	return !t.RevokedAt.IsZero()
+}
@@ -0,0 +1,96 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+// MinPasswordLength is the shortest password we accept when setting one.
+const MinPasswordLength = 12
+
+type User struct {
+	ID           uuid.UUID  `validate:"required"`
+	Email        string     `validate:"required,email"`
+	Name         string     `validate:"required"`
+	Role         actor.Role `validate:"required,oneof=admin facilitator contributor viewer"`
+	PasswordHash []byte
+
+	// Issuer and Subject are set when the user signs in through an external identity provider.
+	Issuer  string
+	Subject string
+	// Groups are the groups the identity provider says the user is part of, they're updated on every sign in.
+	Groups []string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewUser returns an accounts.User with a valid ID set who can only view until given another role.
+func NewUser() User {
+	return User{ID: uuid.Must(uuid.NewV7()), Role: actor.RoleViewer}
+}
+
+// Actor is who the user is when making changes.
+func (u User) Actor() actor.Actor {
+	return actor.Actor{ID: u.ID, Role: u.Role}
+}
+
+// NormalizeEmail is used everywhere we compare emails so people can sign in no matter how they capitalize it.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// SetPassword hashes the password and stores the hash on the user, the password itself is never kept.
+func (u User) SetPassword(password string) (User, error) {
+	if len(password) < MinPasswordLength {
+		return u, failure.Mark(failure.Invalid, fmt.Errorf("password must be at least %d characters", MinPasswordLength))
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return u, fmt.Errorf("failed to hash password: %w", err)
+	}
+	u.PasswordHash = hash
+
+	return u, nil
+}
+
+// HasPassword checks whether the user can sign in with a password, people signing in through an identity provider might not.
+func (u User) HasPassword() bool {
+	return len(u.PasswordHash) > 0
+}
+
+// CheckPassword compares the password against the stored hash.
+// A user without a password set, for example one that only signs in through another provider, never matches.
+func (u User) CheckPassword(password string) bool {
+	if !u.HasPassword() {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword(u.PasswordHash, []byte(password)) == nil
+}
+
+func (u User) updateTimestamps() User {
+	now := time.Now()
+	if u.CreatedAt.IsZero() {
+		u.CreatedAt = now
+	}
+	u.UpdatedAt = now
+
+	return u
+}
+
+// ErrInvalidCredentials is returned when the email and password don't match a user.
+// It's deliberately the same error for unknown emails and wrong passwords to not leak who has an account.
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// ErrWrongPassword is returned when changing the password without knowing the current one.
+var ErrWrongPassword = failure.New(failure.Invalid, "the current password is incorrect")
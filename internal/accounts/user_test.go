@@ -0,0 +1,35 @@
+package accounts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestUser_SetPassword(t *testing.T) {
+	t.Run("stores a hash that matches the password and not the password itself", func(t *testing.T) {
+		u, err := a.User().Build().SetPassword("correct horse battery")
+
+		require.NoError(t, err)
+		require.NotContains(t, string(u.PasswordHash), "correct horse battery")
+		require.True(t, u.CheckPassword("correct horse battery"))
+		require.False(t, u.CheckPassword("Correct horse battery"))
+	})
+
+	t.Run("rejects passwords that are too short", func(t *testing.T) {
+		_, err := a.User().Build().SetPassword("short")
+
+		require.ErrorContains(t, err, "password must be at least 12 characters")
+	})
+
+	t.Run("a user without a password never matches", func(t *testing.T) {
+		require.False(t, a.User().Build().CheckPassword(""))
+	})
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	require.Equal(t, "someone@example.com", accounts.NormalizeEmail("  SomeOne@Example.com "))
+}
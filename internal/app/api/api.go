@@ -0,0 +1,171 @@
+// Package api is the versioned JSON API for tooling, it uses the same services as the web pages.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+)
+
+// V1 is the first version of the API, mount it on /api/v1.
+// Changes that break clients go into a new version instead of changing this one.
+func V1(reviews reviewingService, causes causeService, triggers triggerService) func(chi.Router) {
+	a := handler{
+		reviews:  reviews,
+		causes:   causes,
+		triggers: triggers,
+	}
+
+	return func(r chi.Router) {
+		r.Use(RequireUser)
+		r.Use(requireJSON)
+
+		r.Route("/reviews", func(r chi.Router) {
+			r.Get("/", a.ListReviews)
+			r.Post("/", a.CreateReview)
+
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", a.GetReview)
+				r.Put("/", a.UpdateReview)
+				r.Post("/state", a.TransitionReview)
+
+				r.Post("/contributing-causes", a.BindContributingCause)
+				r.Get("/contributing-causes/{boundCauseID}", a.GetBoundContributingCause)
+				r.Put("/contributing-causes/{boundCauseID}", a.UpdateBoundContributingCause)
+
+				r.Post("/triggers", a.BindTrigger)
+				r.Get("/triggers/{boundTriggerID}", a.GetBoundTrigger)
+				r.Put("/triggers/{boundTriggerID}", a.UpdateBoundTrigger)
+			})
+		})
+
+		r.Route("/contributing-causes", func(r chi.Router) {
+			r.Get("/", a.ListCauses)
+			r.Post("/", a.CreateCause)
+			r.Get("/{id}", a.GetCause)
+			r.Put("/{id}", a.UpdateCause)
+		})
+
+		r.Route("/triggers", func(r chi.Router) {
+			r.Get("/", a.ListTriggers)
+			r.Post("/", a.CreateTrigger)
+			r.Get("/{id}", a.GetTrigger)
+			r.Put("/{id}", a.UpdateTrigger)
+		})
+	}
+}
+
+type handler struct {
+	reviews  reviewingService
+	causes   causeService
+	triggers triggerService
+}
+
+type tokenAuthenticator interface {
+	AuthenticateToken(ctx context.Context, secret string) (accounts.User, accounts.Token, error)
+}
+
+// Authenticate signs in requests with an API token as a bearer token, requests without one continue as they came in.
+// Read-only tokens can only read, and an invalid token is refused instead of continuing as nobody.
+func Authenticate(tokens tokenAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			secret, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok {
+				writeError(w, http.StatusUnauthorized, errors.New("only bearer tokens are supported"))
+				return
+			}
+
+			u, token, err := tokens.AuthenticateToken(r.Context(), secret)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, accounts.ErrInvalidToken)
+				return
+			}
+			if !token.Scope.AllowsChanges() && r.Method != http.MethodGet && r.Method != http.MethodHead {
+				writeError(w, http.StatusForbidden, errors.New("the token is read-only"))
+				return
+			}
+
+			ctx := accounts.WithUser(r.Context(), u)
+			ctx = actor.With(ctx, u.Actor())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireUser answers with 401 for anyone not signed in, since API clients can't follow the web's redirect to sign in.
+func RequireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := accounts.UserFrom(r.Context()); !ok {
+			writeError(w, http.StatusUnauthorized, errors.New("sign in to use the API"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireJSON only accepts JSON bodies for changes, which also stops plain HTML forms on other sites from posting to the API.
+func requireJSON(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+			writeError(w, http.StatusUnsupportedMediaType, errors.New("the body has to be sent as application/json"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to write json response", "error", err)
+	}
+}
+
+// decode reads the JSON body into v, it writes the error response and returns false when it can't.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid json body: %w", err))
+		return false
+	}
+
+	return true
+}
+
+// pathID parses the UUID in the path, it writes the error response and returns false when it can't.
+func pathID(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.PathValue(name))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid %s: %w", name, err))
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+)
+
+type causeService interface {
+	Save(ctx context.Context, cc contributing.Cause) (contributing.Cause, error)
+	All(ctx context.Context) ([]contributing.Cause, error)
+	Get(ctx context.Context, id uuid.UUID) (contributing.Cause, error)
+}
+
+type triggerService interface {
+	Save(ctx context.Context, t normalized.Trigger) (normalized.Trigger, error)
+	All(ctx context.Context) ([]normalized.Trigger, error)
+	Get(ctx context.Context, id uuid.UUID) (normalized.Trigger, error)
+}
+
+// Cause is a contributing cause in the catalog as returned by the API.
+type Cause struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Category    string    `json:"category"`
+	// TeamID is left out when the cause is shared by all teams.
+	TeamID    *uuid.UUID `json:"teamID,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// CauseInput is what can be set when creating or updating a contributing cause.
+type CauseInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+	// Shared creates the cause for all teams instead of the team being worked as, it's ignored on updates.
+	Shared bool `json:"shared,omitempty"`
+}
+
+// Trigger is a trigger in the catalog as returned by the API.
+type Trigger struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	// TeamID is left out when the trigger is shared by all teams.
+	TeamID    *uuid.UUID `json:"teamID,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// TriggerInput is what can be set when creating or updating a trigger.
+type TriggerInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Shared creates the trigger for all teams instead of the team being worked as, it's ignored on updates.
+	Shared bool `json:"shared,omitempty"`
+}
+
+func toCause(cc contributing.Cause) Cause {
+	ret := Cause{
+		ID:          cc.ID,
+		Name:        cc.Name,
+		Description: cc.Description,
+		Category:    cc.Category,
+		CreatedAt:   cc.CreatedAt,
+		UpdatedAt:   cc.UpdatedAt,
+	}
+	if cc.TeamID != uuid.Nil {
+		ret.TeamID = &cc.TeamID
+	}
+
+	return ret
+}
+
+func toTrigger(t normalized.Trigger) Trigger {
+	ret := Trigger{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+	if t.TeamID != uuid.Nil {
+		ret.TeamID = &t.TeamID
+	}
+
+	return ret
+}
+
+func (a *handler) ListCauses(w http.ResponseWriter, r *http.Request) {
+	causes, err := a.causes.All(r.Context())
+	if err != nil {
+		fail(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	ret := make([]Cause, 0, len(causes))
+	for _, cc := range causes {
+		ret = append(ret, toCause(cc))
+	}
+
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (a *handler) CreateCause(w http.ResponseWriter, r *http.Request) {
+	var in CauseInput
+	if !decode(w, r, &in) {
+		return
+	}
+
+	cause := contributing.NewCause()
+	cause.Name = in.Name
+	cause.Description = in.Description
+	cause.Category = in.Category
+	if !in.Shared {
+		cause.TeamID = tenant.ID(r.Context())
+	}
+
+	cause, err := a.causes.Save(r.Context(), cause)
+	if err != nil {
+		fail(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/contributing-causes/"+cause.ID.String())
+	writeJSON(w, http.StatusCreated, toCause(cause))
+}
+
+func (a *handler) GetCause(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	cause, err := a.causes.Get(r.Context(), id)
+	if err != nil {
+		fail(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCause(cause))
+}
+
+func (a *handler) UpdateCause(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var in CauseInput
+	if !decode(w, r, &in) {
+		return
+	}
+
+	cause, err := a.causes.Get(r.Context(), id)
+	if err != nil {
+		fail(w, err, http.StatusInternalServerError)
+		return
+	}
+	cause.Name = in.Name
+	cause.Description = in.Description
+	cause.Category = in.Category
+
+	cause, err = a.causes.Save(r.Context(), cause)
+	if err != nil {
+		fail(w, err, http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCause(cause))
+}
+
+func (a *handler) ListTriggers(w http.ResponseWriter, r *http.Request) {
+	triggers, err := a.triggers.All(r.Context())
+	if err != nil {
+		fail(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	ret := make([]Trigger, 0, len(triggers))
+	for _, t := range triggers {
+		ret = append(ret, toTrigger(t))
+	}
+
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (a *handler) CreateTrigger(w http.ResponseWriter, r *http.Request) {
+	var in TriggerInput
+	if !decode(w, r, &in) {
+		return
+	}
+
+	trigger := normalized.NewTrigger()
+	trigger.Name = in.Name
+	trigger.Description = in.Description
+	if !in.Shared {
+		trigger.TeamID = tenant.ID(r.Context())
+	}
+
+	trigger, err := a.triggers.Save(r.Context(), trigger)
+	if err != nil {
+		fail(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/triggers/"+trigger.ID.String())
+	writeJSON(w, http.StatusCreated, toTrigger(trigger))
+}
+
+func (a *handler) GetTrigger(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	trigger, err := a.triggers.Get(r.Context(), id)
+	if err != nil {
+		fail(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toTrigger(trigger))
+}
+
+func (a *handler) UpdateTrigger(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var in TriggerInput
+	if !decode(w, r, &in) {
+		return
+	}
+
+	trigger, err := a.triggers.Get(r.Context(), id)
+	if err != nil {
+		fail(w, err, http.StatusInternalServerError)
+		return
+	}
+	trigger.Name = in.Name
+	trigger.Description = in.Description
+
+	trigger, err = a.triggers.Save(r.Context(), trigger)
+	if err != nil {
+		fail(w, err, http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toTrigger(trigger))
+}
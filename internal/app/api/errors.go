@@ -0,0 +1,75 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
+)
+
+// Error is the body of every response that isn't a success.
+type Error struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	// Fields has what's wrong with each field when the status is 422.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+type errorBody struct {
+	Error Error `json:"error"`
+}
+
+// fail writes the error from a service, using the status for the kind of error or otherwise when it's not known.
+func fail(w http.ResponseWriter, err error, otherwise int) {
+	writeError(w, statusFor(err, otherwise), err)
+}
+
+func statusFor(err error, otherwise int) int {
+	switch failure.KindOf(err) {
+	case failure.Forbidden:
+		return http.StatusForbidden
+	case failure.NotFound:
+		return http.StatusNotFound
+	case failure.Conflict:
+		return http.StatusConflict
+	case failure.Invalid:
+		return http.StatusUnprocessableEntity
+	default:
+		return otherwise
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	body := Error{Status: status, Message: err.Error()}
+	if status >= http.StatusInternalServerError {
+		// The details are for the logs, not for whoever is calling
+		slog.Error("api request failed", "status", status, "error", err)
+		body.Message = http.StatusText(status)
+	}
+
+	// The same fields and messages as the web, including the custom fields by their ID
+	if errs, ok := validate.Fields(err); ok {
+		body.Message = "validation failed"
+		body.Fields = make(map[string]string, len(errs))
+		for field, msg := range errs {
+			body.Fields[jsonName(field)] = msg
+		}
+	}
+
+	writeJSON(w, status, errorBody{Error: body})
+}
+
+// jsonName turns the Go field name into the name used in the JSON bodies, like URL into url and ReportTrigger into reportTrigger.
+func jsonName(field string) string {
+	if strings.ToUpper(field) == field {
+		return strings.ToLower(field)
+	}
+
+	r := []rune(field)
+	r[0] = unicode.ToLower(r[0])
+
+	return string(r)
+}
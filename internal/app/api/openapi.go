@@ -0,0 +1,262 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+// operation describes one endpoint of V1, the OpenAPI document is generated from these and the types they use.
+type operation struct {
+	Method  string
+	Path    string
+	ID      string
+	Summary string
+	Query   []queryParam
+	// Request is the type of the body it reads, nil when it doesn't read one.
+	Request any
+	// Response is the type it answers with on success.
+	Response any
+	Status   int
+}
+
+// queryParam is a query parameter an endpoint reads, Type is a value of the type it's parsed into.
+type queryParam struct {
+	Name        string
+	Description string
+	Type        any
+}
+
+var operations = []operation{
+	{Method: http.MethodGet, Path: "/reviews", ID: "listReviews", Summary: "List the reviews of the team being worked as",
+		Query: []queryParam{{Name: "state", Description: "Only list reviews in this state", Type: reviewing.State("")}}, Response: []Review{}, Status: http.StatusOK},
+	{Method: http.MethodPost, Path: "/reviews", ID: "createReview", Summary: "Start a new review",
+		Request: ReviewInput{}, Response: Review{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/reviews/{id}", ID: "getReview", Summary: "Get a review",
+		Response: Review{}, Status: http.StatusOK},
+	{Method: http.MethodPut, Path: "/reviews/{id}", ID: "updateReview", Summary: "Update the details of a review",
+		Request: ReviewInput{}, Response: Review{}, Status: http.StatusOK},
+	{Method: http.MethodPost, Path: "/reviews/{id}/state", ID: "transitionReview", Summary: "Move a review to another state",
+		Request: TransitionInput{}, Response: Review{}, Status: http.StatusOK},
+
+	{Method: http.MethodPost, Path: "/reviews/{id}/contributing-causes", ID: "bindContributingCause", Summary: "Bind a contributing cause to a review",
+		Request: BoundCauseInput{}, Response: BoundCause{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/reviews/{id}/contributing-causes/{boundCauseID}", ID: "getBoundContributingCause", Summary: "Get a contributing cause bound to a review",
+		Response: BoundCause{}, Status: http.StatusOK},
+	{Method: http.MethodPut, Path: "/reviews/{id}/contributing-causes/{boundCauseID}", ID: "updateBoundContributingCause", Summary: "Update a contributing cause bound to a review",
+		Request: BoundCauseInput{}, Response: BoundCause{}, Status: http.StatusOK},
+	{Method: http.MethodPost, Path: "/reviews/{id}/triggers", ID: "bindTrigger", Summary: "Bind a trigger to a review",
+		Request: BoundTriggerInput{}, Response: BoundTrigger{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/reviews/{id}/triggers/{boundTriggerID}", ID: "getBoundTrigger", Summary: "Get a trigger bound to a review",
+		Response: BoundTrigger{}, Status: http.StatusOK},
+	{Method: http.MethodPut, Path: "/reviews/{id}/triggers/{boundTriggerID}", ID: "updateBoundTrigger", Summary: "Update a trigger bound to a review",
+		Request: BoundTriggerInput{}, Response: BoundTrigger{}, Status: http.StatusOK},
+
+	{Method: http.MethodGet, Path: "/contributing-causes", ID: "listContributingCauses", Summary: "List the contributing causes in the catalog",
+		Response: []Cause{}, Status: http.StatusOK},
+	{Method: http.MethodPost, Path: "/contributing-causes", ID: "createContributingCause", Summary: "Add a contributing cause to the catalog",
+		Request: CauseInput{}, Response: Cause{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/contributing-causes/{id}", ID: "getContributingCause", Summary: "Get a contributing cause",
+		Response: Cause{}, Status: http.StatusOK},
+	{Method: http.MethodPut, Path: "/contributing-causes/{id}", ID: "updateContributingCause", Summary: "Update a contributing cause",
+		Request: CauseInput{}, Response: Cause{}, Status: http.StatusOK},
+
+	{Method: http.MethodGet, Path: "/triggers", ID: "listTriggers", Summary: "List the triggers in the catalog",
+		Response: []Trigger{}, Status: http.StatusOK},
+	{Method: http.MethodPost, Path: "/triggers", ID: "createTrigger", Summary: "Add a trigger to the catalog",
+		Request: TriggerInput{}, Response: Trigger{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/triggers/{id}", ID: "getTrigger", Summary: "Get a trigger",
+		Response: Trigger{}, Status: http.StatusOK},
+	{Method: http.MethodPut, Path: "/triggers/{id}", ID: "updateTrigger", Summary: "Update a trigger",
+		Request: TriggerInput{}, Response: Trigger{}, Status: http.StatusOK},
+}
+
+// Document is an OpenAPI 3 document, with only the parts this API uses.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Servers    []Server                        `json:"servers"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var pathParam = regexp.MustCompile(`{([^}]+)}`)
+
+// Spec is the OpenAPI document for V1.
+var Spec = sync.OnceValue(func() Document {
+	doc := Document{
+		OpenAPI:    "3.0.3",
+		Info:       Info{Title: "Incident reviewer", Version: "1"},
+		Servers:    []Server{{URL: "/api/v1"}},
+		Paths:      make(map[string]map[string]Operation),
+		Components: Components{Schemas: make(map[string]*Schema)},
+	}
+	errorSchema := schemaFor(reflect.TypeFor[errorBody](), doc.Components.Schemas)
+
+	for _, op := range operations {
+		o := Operation{
+			OperationID: op.ID,
+			Summary:     op.Summary,
+			Responses: map[string]Response{
+				strconv.Itoa(op.Status): {
+					Description: http.StatusText(op.Status),
+					Content:     map[string]MediaType{"application/json": {Schema: schemaFor(reflect.TypeOf(op.Response), doc.Components.Schemas)}},
+				},
+				"default": {
+					Description: "Something went wrong, the status and message say what",
+					Content:     map[string]MediaType{"application/json": {Schema: errorSchema}},
+				},
+			},
+		}
+		for _, m := range pathParam.FindAllStringSubmatch(op.Path, -1) {
+			o.Parameters = append(o.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string", Format: "uuid"}})
+		}
+		for _, q := range op.Query {
+			o.Parameters = append(o.Parameters, Parameter{Name: q.Name, In: "query", Description: q.Description, Schema: schemaFor(reflect.TypeOf(q.Type), nil)})
+		}
+		if op.Request != nil {
+			o.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{"application/json": {Schema: schemaFor(reflect.TypeOf(op.Request), doc.Components.Schemas)}},
+			}
+		}
+
+		if doc.Paths[op.Path] == nil {
+			doc.Paths[op.Path] = make(map[string]Operation)
+		}
+		doc.Paths[op.Path][strings.ToLower(op.Method)] = o
+	}
+
+	return doc
+})
+
+// OpenAPI serves the OpenAPI document, it's public so clients can be generated without signing in.
+func OpenAPI(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, Spec())
+}
+
+// schemaFor describes t the way encoding/json writes it, structs are added to schemas and referenced.
+func schemaFor(t reflect.Type, schemas map[string]*Schema) *Schema {
+	switch t {
+	case reflect.TypeFor[time.Time]():
+		return &Schema{Type: "string", Format: "date-time"}
+	case reflect.TypeFor[uuid.UUID]():
+		return &Schema{Type: "string", Format: "uuid"}
+	case reflect.TypeFor[reviewing.State]():
+		s := &Schema{Type: "string"}
+		for _, state := range reviewing.States {
+			s.Enum = append(s.Enum, string(state))
+		}
+
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), schemas)
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Slice:
+		return &Schema{Type: "array", Items: schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaFor(t.Elem(), schemas)}
+	case reflect.Struct:
+		name := strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+		if _, ok := schemas[name]; !ok {
+			s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+			schemas[name] = s // before the fields, in case a type refers to itself
+			addFields(s, t, schemas)
+		}
+
+		return &Schema{Ref: "#/components/schemas/" + name}
+	default:
+		panic("no OpenAPI schema for " + t.String())
+	}
+}
+
+func addFields(s *Schema, t reflect.Type, schemas map[string]*Schema) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous {
+			addFields(s, f.Type, schemas)
+			continue
+		}
+
+		tag, ok := f.Tag.Lookup("json")
+		if !ok || !f.IsExported() || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		s.Properties[name] = schemaFor(f.Type, schemas)
+		if !strings.Contains(opts, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/app/api"
+)
+
+func TestSpec(t *testing.T) {
+	t.Run("describes every route of V1 and nothing else", func(t *testing.T) {
+		r := chi.NewRouter()
+		api.V1(nil, nil, nil)(r)
+		var routes []string
+		require.NoError(t, chi.Walk(r, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			if route != "/" {
+				route = strings.TrimSuffix(route, "/")
+			}
+			routes = append(routes, method+" "+route)
+			return nil
+		}))
+
+		var documented []string
+		for path, ops := range api.Spec().Paths {
+			for method := range ops {
+				documented = append(documented, strings.ToUpper(method)+" "+path)
+			}
+		}
+
+		require.ElementsMatch(t, routes, documented, "expected the OpenAPI document to be updated with the routes")
+	})
+
+	t.Run("every schema referenced is defined", func(t *testing.T) {
+		doc := api.Spec()
+		b, err := json.Marshal(doc)
+		require.NoError(t, err)
+
+		var refs []string
+		var collect func(v any)
+		collect = func(v any) {
+			switch v := v.(type) {
+			case map[string]any:
+				if ref, ok := v["$ref"].(string); ok {
+					refs = append(refs, ref)
+				}
+				for _, vv := range v {
+					collect(vv)
+				}
+			case []any:
+				for _, vv := range v {
+					collect(vv)
+				}
+			}
+		}
+		var raw any
+		require.NoError(t, json.Unmarshal(b, &raw))
+		collect(raw)
+
+		require.NotEmpty(t, refs)
+		for _, ref := range refs {
+			name := strings.TrimPrefix(ref, "#/components/schemas/")
+			require.Contains(t, doc.Components.Schemas, name, "expected %s to be defined", ref)
+		}
+	})
+
+	t.Run("operation ids are unique since clients name their methods after them", func(t *testing.T) {
+		seen := make(map[string]bool)
+		for _, ops := range api.Spec().Paths {
+			for _, op := range ops {
+				require.False(t, seen[op.OperationID], "duplicate operation id: %s", op.OperationID)
+				seen[op.OperationID] = true
+			}
+		}
+	})
+
+	t.Run("fields left out when empty aren't required", func(t *testing.T) {
+		review := api.Spec().Components.Schemas["Review"]
+
+		require.Contains(t, review.Properties, "teamID")
+		require.NotContains(t, review.Required, "teamID")
+		require.Contains(t, review.Required, "url", "expected the fields of the embedded input")
+		require.Equal(t, "#/components/schemas/BoundCause", review.Properties["boundCauses"].Items.Ref)
+		require.Equal(t, []string{"draft", "in_review", "awaiting_approval", "published", "archived"}, review.Properties["state"].Enum)
+	})
+
+	t.Run("is served as JSON", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		api.OpenAPI(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var doc api.Document
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+		require.Equal(t, "3.0.3", doc.OpenAPI)
+	})
+}
@@ -0,0 +1,441 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
+	"github.com/gaqzi/incident-reviewer/internal/platform/markdown"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+type reviewingService interface {
+	Get(ctx context.Context, id uuid.UUID) (reviewing.Review, error)
+	Save(ctx context.Context, review reviewing.Review) (reviewing.Review, error)
+	Find(ctx context.Context, f reviewing.Filter) ([]reviewing.Review, error)
+	Update(ctx context.Context, reviewID uuid.UUID, update reviewing.Review) (reviewing.Review, error)
+	Transition(ctx context.Context, reviewID uuid.UUID, to reviewing.State) (reviewing.Review, error)
+
+	BindContributingCause(ctx context.Context, reviewID uuid.UUID, causeID uuid.UUID, boundCause reviewing.BoundCause) error
+	GetBoundContributingCause(ctx context.Context, reviewID uuid.UUID, boundCauseID uuid.UUID) (reviewing.BoundCause, error)
+	UpdateBoundContributingCause(ctx context.Context, reviewID uuid.UUID, boundCause reviewing.BoundCause) (reviewing.BoundCause, error)
+	BindTrigger(ctx context.Context, reviewID uuid.UUID, triggerID uuid.UUID, trigger reviewing.UnboundTrigger) error
+	GetBoundTrigger(ctx context.Context, reviewID uuid.UUID, boundTriggerID uuid.UUID) (reviewing.BoundTrigger, error)
+	UpdateBoundTrigger(ctx context.Context, reviewID uuid.UUID, boundTrigger reviewing.BoundTrigger) (reviewing.BoundTrigger, error)
+}
+
+// Review is a review as returned by the API.
+type Review struct {
+	ID uuid.UUID `json:"id"`
+	ReviewInput
+	State         reviewing.State   `json:"state"`
+	NextStates    []reviewing.State `json:"nextStates"`
+	TeamID        *uuid.UUID        `json:"teamID,omitempty"`
+	Facilitators  []uuid.UUID       `json:"facilitators"`
+	Participants  []uuid.UUID       `json:"participants"`
+	BoundCauses   []BoundCause      `json:"boundCauses"`
+	BoundTriggers []BoundTrigger    `json:"boundTriggers"`
+
+	// DescriptionText and ImpactText are the description and impact without the Markdown they're written in.
+	DescriptionText string `json:"descriptionText"`
+	ImpactText      string `json:"impactText"`
+
+	// IncidentStartedAt and IncidentResolvedAt are left out when not known.
+	IncidentStartedAt  *time.Time `json:"incidentStartedAt,omitempty"`
+	IncidentResolvedAt *time.Time `json:"incidentResolvedAt,omitempty"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+}
+
+// ReviewInput is what can be set when creating or updating a review.
+type ReviewInput struct {
+	URL                 string `json:"url"`
+	Title               string `json:"title"`
+	Description         string `json:"description"`
+	Impact              string `json:"impact"`
+	Where               string `json:"where"`
+	ReportProximalCause string `json:"reportProximalCause"`
+	ReportTrigger       string `json:"reportTrigger"`
+}
+
+// TransitionInput moves a review to another state.
+type TransitionInput struct {
+	State reviewing.State `json:"state"`
+}
+
+// Votes is how many have agreed and disagreed with something bound to a review.
+type Votes struct {
+	For     int `json:"for"`
+	Against int `json:"against"`
+}
+
+type BoundCause struct {
+	ID                uuid.UUID `json:"id"`
+	ContributingCause Cause     `json:"contributingCause"`
+	Why               string    `json:"why"`
+	IsProximalCause   bool      `json:"isProximalCause"`
+	Votes             Votes     `json:"votes"`
+
+	// WhyText is why without the Markdown it's written in.
+	WhyText string `json:"whyText"`
+}
+
+// BoundCauseInput binds a contributing cause to a review, or changes one that's bound.
+type BoundCauseInput struct {
+	ContributingCauseID uuid.UUID `json:"contributingCauseID"`
+	Why                 string    `json:"why"`
+	IsProximalCause     bool      `json:"isProximalCause,omitempty"`
+}
+
+type BoundTrigger struct {
+	ID      uuid.UUID `json:"id"`
+	Trigger Trigger   `json:"trigger"`
+	Why     string    `json:"why"`
+	Votes   Votes     `json:"votes"`
+
+	// WhyText is why without the Markdown it's written in.
+	WhyText string `json:"whyText"`
+}
+
+// BoundTriggerInput binds a trigger to a review, or changes one that's bound.
+type BoundTriggerInput struct {
+	TriggerID uuid.UUID `json:"triggerID"`
+	Why       string    `json:"why"`
+}
+
+func (in ReviewInput) toReview(r reviewing.Review) reviewing.Review {
+	r.URL = in.URL
+	r.Title = in.Title
+	r.Description = in.Description
+	r.Impact = in.Impact
+	r.Where = in.Where
+	r.ReportProximalCause = in.ReportProximalCause
+	r.ReportTrigger = in.ReportTrigger
+
+	return r
+}
+
+func toReview(r reviewing.Review) Review {
+	ret := Review{
+		ID: r.ID,
+		ReviewInput: ReviewInput{
+			URL:                 r.URL,
+			Title:               r.Title,
+			Description:         r.Description,
+			Impact:              r.Impact,
+			Where:               r.Where,
+			ReportProximalCause: r.ReportProximalCause,
+			ReportTrigger:       r.ReportTrigger,
+		},
+		State:         r.State,
+		NextStates:    append([]reviewing.State{}, r.NextStates()...),
+		Facilitators:  append([]uuid.UUID{}, r.Facilitators...),
+		Participants:  append([]uuid.UUID{}, r.Participants...),
+		BoundCauses:   make([]BoundCause, 0, len(r.BoundCauses)),
+		BoundTriggers: make([]BoundTrigger, 0, len(r.BoundTriggers)),
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+
+		DescriptionText: markdown.Text(r.Description),
+		ImpactText:      markdown.Text(r.Impact),
+	}
+	if r.TeamID != uuid.Nil {
+		ret.TeamID = &r.TeamID
+	}
+	if !r.IncidentStartedAt.IsZero() {
+		ret.IncidentStartedAt = &r.IncidentStartedAt
+	}
+	if !r.IncidentResolvedAt.IsZero() {
+		ret.IncidentResolvedAt = &r.IncidentResolvedAt
+	}
+	for _, bc := range r.BoundCauses {
+		ret.BoundCauses = append(ret.BoundCauses, toBoundCause(bc))
+	}
+	for _, bt := range r.BoundTriggers {
+		ret.BoundTriggers = append(ret.BoundTriggers, toBoundTrigger(bt))
+	}
+
+	return ret
+}
+
+func toBoundCause(bc reviewing.BoundCause) BoundCause {
+	tally := bc.Votes.Tally()
+
+	return BoundCause{
+		ID:                bc.ID,
+		ContributingCause: toCause(bc.Cause),
+		Why:               bc.Why,
+		IsProximalCause:   bc.IsProximalCause,
+		Votes:             Votes{For: tally.For, Against: tally.Against},
+
+		WhyText: markdown.Text(bc.Why),
+	}
+}
+
+func toBoundTrigger(bt reviewing.BoundTrigger) BoundTrigger {
+	tally := bt.Votes.Tally()
+
+	return BoundTrigger{
+		ID:      bt.ID,
+		Trigger: toTrigger(bt.Trigger),
+		Why:     bt.Why,
+		Votes:   Votes{For: tally.For, Against: tally.Against},
+
+		WhyText: markdown.Text(bt.Why),
+	}
+}
+
+func (a *handler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	reviews, err := a.reviews.Find(r.Context(), reviewing.Filter{State: reviewing.State(r.URL.Query().Get("state"))})
+	if err != nil {
+		fail(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	ret := make([]Review, 0, len(reviews))
+	for _, review := range reviews {
+		ret = append(ret, toReview(review))
+	}
+
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (a *handler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	var in ReviewInput
+	if !decode(w, r, &in) {
+		return
+	}
+
+	review, err := a.reviews.Save(r.Context(), in.toReview(reviewing.NewReview()))
+	if err != nil {
+		fail(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/reviews/"+review.ID.String())
+	writeJSON(w, http.StatusCreated, toReview(review))
+}
+
+func (a *handler) GetReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	review, err := a.reviews.Get(r.Context(), reviewID)
+	if err != nil {
+		fail(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toReview(review))
+}
+
+func (a *handler) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var in ReviewInput
+	if !decode(w, r, &in) {
+		return
+	}
+
+	review, err := a.reviews.Update(r.Context(), reviewID, in.toReview(reviewing.Review{}))
+	if err != nil {
+		fail(w, err, http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toReview(review))
+}
+
+func (a *handler) TransitionReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var in TransitionInput
+	if !decode(w, r, &in) {
+		return
+	}
+
+	review, err := a.reviews.Transition(r.Context(), reviewID, in.State)
+	if err != nil {
+		fail(w, err, http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toReview(review))
+}
+
+func (a *handler) BindContributingCause(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var in BoundCauseInput
+	if !decode(w, r, &in) {
+		return
+	}
+
+	// Setting the ID up front is how the bound cause is found again after binding
+	boundCause := reviewing.NewBoundCause()
+	boundCause.Why = in.Why
+	boundCause.IsProximalCause = in.IsProximalCause
+	if err := a.reviews.BindContributingCause(r.Context(), reviewID, in.ContributingCauseID, boundCause); err != nil {
+		fail(w, err, http.StatusBadRequest)
+		return
+	}
+
+	boundCause, err := a.reviews.GetBoundContributingCause(r.Context(), reviewID, boundCause.ID)
+	if err != nil {
+		fail(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/reviews/"+reviewID.String()+"/contributing-causes/"+boundCause.ID.String())
+	writeJSON(w, http.StatusCreated, toBoundCause(boundCause))
+}
+
+func (a *handler) GetBoundContributingCause(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	boundCauseID, ok := pathID(w, r, "boundCauseID")
+	if !ok {
+		return
+	}
+
+	boundCause, err := a.reviews.GetBoundContributingCause(r.Context(), reviewID, boundCauseID)
+	if err != nil {
+		fail(w, err, http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toBoundCause(boundCause))
+}
+
+func (a *handler) UpdateBoundContributingCause(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	boundCauseID, ok := pathID(w, r, "boundCauseID")
+	if !ok {
+		return
+	}
+
+	var in BoundCauseInput
+	if !decode(w, r, &in) {
+		return
+	}
+
+	boundCause, err := a.reviews.UpdateBoundContributingCause(r.Context(), reviewID, reviewing.BoundCause{
+		ID:              boundCauseID,
+		Cause:           contributing.Cause{ID: in.ContributingCauseID},
+		Why:             in.Why,
+		IsProximalCause: in.IsProximalCause,
+	})
+	if err != nil {
+		fail(w, err, http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toBoundCause(boundCause))
+}
+
+func (a *handler) BindTrigger(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var in BoundTriggerInput
+	if !decode(w, r, &in) {
+		return
+	}
+
+	if err := a.reviews.BindTrigger(r.Context(), reviewID, in.TriggerID, reviewing.UnboundTrigger{Why: in.Why}); err != nil {
+		fail(w, err, http.StatusBadRequest)
+		return
+	}
+
+	// The bound trigger gets its ID when bound, and new ones are added last
+	review, err := a.reviews.Get(r.Context(), reviewID)
+	if err != nil {
+		fail(w, err, http.StatusInternalServerError)
+		return
+	}
+	var boundTrigger reviewing.BoundTrigger
+	for _, bt := range slices.Backward(review.BoundTriggers) {
+		if bt.Trigger.ID == in.TriggerID && bt.Why == in.Why {
+			boundTrigger = bt
+			break
+		}
+	}
+	if boundTrigger.ID == uuid.Nil {
+		fail(w, errors.New("the bound trigger wasn't found after binding it"), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/reviews/"+reviewID.String()+"/triggers/"+boundTrigger.ID.String())
+	writeJSON(w, http.StatusCreated, toBoundTrigger(boundTrigger))
+}
+
+func (a *handler) GetBoundTrigger(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	boundTriggerID, ok := pathID(w, r, "boundTriggerID")
+	if !ok {
+		return
+	}
+
+	boundTrigger, err := a.reviews.GetBoundTrigger(r.Context(), reviewID, boundTriggerID)
+	if err != nil {
+		fail(w, err, http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toBoundTrigger(boundTrigger))
+}
+
+func (a *handler) UpdateBoundTrigger(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	boundTriggerID, ok := pathID(w, r, "boundTriggerID")
+	if !ok {
+		return
+	}
+
+	var in BoundTriggerInput
+	if !decode(w, r, &in) {
+		return
+	}
+
+	boundTrigger, err := a.reviews.UpdateBoundTrigger(r.Context(), reviewID, reviewing.BoundTrigger{
+		ID:             boundTriggerID,
+		Trigger:        normalized.Trigger{ID: in.TriggerID},
+		UnboundTrigger: reviewing.UnboundTrigger{Why: in.Why},
+	})
+	if err != nil {
+		fail(w, err, http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toBoundTrigger(boundTrigger))
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/intake"
+)
+
+// SignatureHeader has the signature of an incident webhook's payload, see intake.Source.Sign.
+const SignatureHeader = "X-Signature-256"
+
+// maxWebhookPayload is more than any incident tool sends, it stops anyone from making us read forever.
+const maxWebhookPayload = 1 << 20
+
+type intakeService interface {
+	Receive(ctx context.Context, sourceName string, payload []byte, signature string) (intake.Result, error)
+}
+
+// WebhookResult is the response to an incident tool delivering an incident.
+type WebhookResult struct {
+	Outcome  intake.Outcome `json:"outcome"`
+	ReviewID *uuid.UUID     `json:"reviewID,omitempty"`
+}
+
+// IncidentWebhooks receives incidents from incident tools, each posting to their source's name.
+// They're signed with the source's secret instead of signing in, so mount it outside of what requires a user.
+func IncidentWebhooks(service intakeService) func(chi.Router) {
+	return func(r chi.Router) {
+		r.Post("/{source}", func(w http.ResponseWriter, r *http.Request) {
+			payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayload))
+			if err != nil {
+				writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("failed to read payload: %w", err))
+				return
+			}
+
+			result, err := service.Receive(r.Context(), r.PathValue("source"), payload, r.Header.Get(SignatureHeader))
+			switch {
+			case errors.Is(err, intake.ErrUnknownSource):
+				writeError(w, http.StatusNotFound, err)
+				return
+			case errors.Is(err, intake.ErrInvalidSignature):
+				writeError(w, http.StatusUnauthorized, err)
+				return
+			case errors.Is(err, intake.ErrInvalidPayload):
+				writeError(w, http.StatusBadRequest, err)
+				return
+			case err != nil:
+				fail(w, err, http.StatusInternalServerError)
+				return
+			}
+
+			body := WebhookResult{Outcome: result.Outcome}
+			if result.ReviewID != uuid.Nil {
+				body.ReviewID = &result.ReviewID
+			}
+			status := http.StatusOK
+			if result.Outcome == intake.OutcomeCreated {
+				status = http.StatusCreated
+			}
+			writeJSON(w, status, body)
+		})
+	}
+}
@@ -2,10 +2,14 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -13,22 +17,71 @@ import (
 	"github.com/go-chi/httplog/v2"
 	"github.com/google/uuid"
 
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/internal/accounts/oidc"
+	accountstorage "github.com/gaqzi/incident-reviewer/internal/accounts/storage"
+	"github.com/gaqzi/incident-reviewer/internal/app/api"
 	"github.com/gaqzi/incident-reviewer/internal/app/web"
+	"github.com/gaqzi/incident-reviewer/internal/intake"
+	intakestorage "github.com/gaqzi/incident-reviewer/internal/intake/storage"
 	"github.com/gaqzi/incident-reviewer/internal/normalized"
 	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
 	contribstorage "github.com/gaqzi/incident-reviewer/internal/normalized/contributing/storage"
 	"github.com/gaqzi/incident-reviewer/internal/normalized/storage"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/blob"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	eventstorage "github.com/gaqzi/incident-reviewer/internal/platform/event/storage"
 	"github.com/gaqzi/incident-reviewer/internal/reviewing"
 	reviewstorage "github.com/gaqzi/incident-reviewer/internal/reviewing/storage"
+	"github.com/gaqzi/incident-reviewer/internal/teams"
+	teamstorage "github.com/gaqzi/incident-reviewer/internal/teams/storage"
+	"github.com/gaqzi/incident-reviewer/internal/webhooks"
+	webhookstorage "github.com/gaqzi/incident-reviewer/internal/webhooks/storage"
 )
 
 type Config struct {
 	Addr string
+	// PublicationRulesPath is a JSON file with the rules a review has to pass before it's published,
+	// when empty reviewing.DefaultPublicationRules are used.
+	PublicationRulesPath string
+	// IncidentSourcesPath is a JSON file with the incident tools that create draft reviews when incidents are resolved,
+	// when empty no incident tool can.
+	IncidentSourcesPath string
+	// AttachmentsDir is where the files attached to reviews are kept, it's created when missing.
+	AttachmentsDir string
+	// OutboxDir is where the events that haven't been handled yet are kept, so they're handled after a restart.
+	OutboxDir string
+
+	// SecureCookies should only be turned off when running locally without TLS.
+	SecureCookies bool
+	// AdminEmail and AdminPassword are used to create the first user when there are none,
+	// when no password is set one is generated and written to AdminPasswordFile, readable only by the owner.
+	AdminEmail        string
+	AdminPassword     string
+	AdminPasswordFile string
+
+	// OIDC lets people sign in through an OpenID Connect provider when its IssuerURL is set.
+	// When its RedirectURL is empty it's set to /login/oidc/callback on Addr, which only works when running locally.
+	OIDC oidc.Config
+	// LocalLogin allows signing in with an email and password, it can only be turned off when OIDC is used.
+	LocalLogin bool
+	// GroupRoles gives people signing in through OIDC the role of their groups, everyone else starts as a viewer.
+	GroupRoles accounts.GroupRoles
+	// LinkByEmail lets people signing in through OIDC the first time take over the local user with the same email,
+	// when the provider has verified it. Only turn it on when the provider can be trusted with every email.
+	LinkByEmail bool
 }
 
 func NewConfig() Config {
 	return Config{
-		Addr: "127.0.0.1:3000",
+		Addr:              "127.0.0.1:3000",
+		SecureCookies:     true,
+		AdminEmail:        "admin@example.com",
+		AdminPasswordFile: filepath.Join("data", "admin-password"),
+		LocalLogin:        true,
+		AttachmentsDir:    filepath.Join("data", "attachments"),
+		OutboxDir:         filepath.Join("data", "outbox"),
 	}
 }
 
@@ -72,34 +125,137 @@ func Start(ctx context.Context, cfg Config) (*Server, error) {
 	r.Use(httplog.RequestLogger(logger))
 	r.Use(middleware.Recoverer)
 
+	// The app sets itself up before anyone has signed in, so it has to do it as the system
+	systemCtx := actor.With(ctx, actor.System)
+
+	accountOptions := []accounts.Option{accounts.WithGroupRoles(cfg.GroupRoles)}
+	if cfg.LinkByEmail {
+		accountOptions = append(accountOptions, accounts.WithEmailLinking())
+	}
+	accountService := accounts.NewService(
+		accountstorage.NewUserMemoryStore(),
+		accountstorage.NewSessionMemoryStore(),
+		accountstorage.NewTokenMemoryStore(),
+		accountOptions...,
+	)
+	sessionsConfig := web.SessionsConfig{SecureCookies: cfg.SecureCookies, LocalLogin: cfg.LocalLogin}
+	if cfg.OIDC.Enabled() {
+		if cfg.OIDC.RedirectURL == "" {
+			cfg.OIDC.RedirectURL = "http://" + cfg.Addr + "/login/oidc/callback"
+		}
+
+		provider, err := oidc.NewProvider(ctx, cfg.OIDC)
+		if err != nil {
+			return nil, err
+		}
+		sessionsConfig.SSO = provider
+	} else if !cfg.LocalLogin {
+		return nil, errors.New("local login can only be turned off when OIDC is configured")
+	}
+	if cfg.LocalLogin {
+		if err := seedAdmin(systemCtx, accountService, cfg); err != nil {
+			return nil, err
+		}
+	}
+	r.Use(web.Authenticate(accountService))
+
+	// Everything is seen as the team being worked as, see tenant.Owns
+	teamService := teams.NewService(teamstorage.NewMemoryStore())
+	r.Use(web.CurrentTeam(teamService))
+	r.Use(web.Flashes(cfg.SecureCookies))
+
 	web.PublicAssets(r)
+	r.Group(web.SessionsHandler(accountService, sessionsConfig))
+
+	// Everything else requires someone to be signed in
+	protected := r.With(web.RequireUser)
+
+	// Changes to reviews and the catalogs are published as events, which are handled once the change has been saved
+	outbox, err := eventstorage.NewOutboxFileStore(cfg.OutboxDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox: %w", err)
+	}
+	bus := event.NewBus(outbox)
+	webhookService := webhooks.NewService(webhookstorage.NewSubscriptionMemoryStore(), webhookstorage.NewDeliveryMemoryStore(), bus)
+	bus.Subscribe(webhookService)
+	go bus.Run(ctx)
 
-	causeService := contributing.NewCauseService(contribstorage.NewCauseMemoryStore())
+	causeService := contributing.NewCauseService(contribstorage.NewCauseMemoryStore(), contributing.WithCauseEvents(bus))
 	cause := contributing.NewCause()
 	cause.Name = "Third party outage"
 	cause.Description = "In case a third party experienced issues/outage and it leads to an incident on our side.\nThings like third party changing configuration and it leading to issues on our side also qualifies"
 	cause.Category = "Design"
-	_, err = causeService.Save(ctx, cause)
+	_, err = causeService.Save(systemCtx, cause)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add default contributing causes: %w", err)
 	}
-	r.Route("/contributing-causes", web.ContributingCausesHandler(causeService))
 
 	reviewStore := reviewstorage.NewMemoryStore()
 
-	triggerService := normalized.NewTriggerService(storage.NewTriggerMemoryStore())
+	triggerService := normalized.NewTriggerService(storage.NewTriggerMemoryStore(), normalized.WithTriggerEvents(bus))
 	trigger := normalized.Trigger{}
 	trigger.ID = uuid.MustParse("6A195282-04CA-4405-A6F1-678C525A001B")
 	trigger.Name = "Traffic increase"
 	trigger.Description = "More users than normal"
-	_, err = triggerService.Save(ctx, trigger)
+	_, err = triggerService.Save(systemCtx, trigger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add default trigger: %w", err)
 	}
-	r.Route("/triggers", web.TriggersHandler(triggerService))
 
-	reviewService := reviewing.NewService(reviewStore, causeService, triggerService)
-	r.Route("/reviews", web.ReviewsHandler(reviewService, causeService, triggerService))
+	publicationRules := reviewing.DefaultPublicationRules()
+	if cfg.PublicationRulesPath != "" {
+		publicationRules, err = loadPublicationRules(cfg.PublicationRulesPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	customFieldService := reviewing.NewCustomFieldService(reviewstorage.NewCustomFieldMemoryStore())
+	reviewOptions := []reviewing.Option{
+		reviewing.WithPublicationRules(publicationRules),
+		reviewing.WithEvents(bus),
+		reviewing.WithCustomFields(customFieldService),
+	}
+	blobs, err := blob.NewFileSystemStore(cfg.AttachmentsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attachments directory: %w", err)
+	}
+	reviewOptions = append(reviewOptions, reviewing.WithAttachments(blobs, reviewing.DefaultAttachmentLimits()))
+	reviewService, err := reviewing.NewService(reviewStore, causeService, triggerService, reviewOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up reviews: %w", err)
+	}
+	protected.Route("/contributing-causes", web.ContributingCausesHandler(causeService, reviewService))
+	protected.Route("/triggers", web.TriggersHandler(triggerService, reviewService))
+	templateService := reviewing.NewTemplateService(reviewstorage.NewTemplateMemoryStore())
+	protected.Route("/reviews", web.ReviewsHandler(reviewService, causeService, triggerService, accountService, templateService, customFieldService))
+	protected.Route("/review-templates", web.ReviewTemplatesHandler(templateService, causeService, triggerService))
+	protected.Route("/custom-fields", web.CustomFieldsHandler(customFieldService))
+	protected.Route("/markdown", web.MarkdownHandler())
+	protected.Route("/users", web.UsersHandler(accountService))
+	protected.Route("/teams", web.TeamsHandler(teamService, accountService, cfg.SecureCookies))
+	protected.Route("/tokens", web.TokensHandler(accountService))
+	protected.Route("/password", web.PasswordHandler(accountService))
+	protected.Route("/webhooks/subscriptions", web.WebhooksHandler(webhookService))
+
+	var incidentSources []intake.Source
+	if cfg.IncidentSourcesPath != "" {
+		incidentSources, err = loadIncidentSources(cfg.IncidentSourcesPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	intakeService := intake.NewService(reviewService, intakestorage.NewDeliveryMemoryStore(), incidentSources)
+	r.Route("/webhooks/incidents", api.IncidentWebhooks(intakeService))
+
+	// The API answers unauthenticated requests itself, so it's not behind the web's redirect to sign in
+	r.Route("/api", func(r chi.Router) {
+		r.Get("/openapi.json", api.OpenAPI)
+
+		// Tokens sign in after the team was chosen for the session, so it's chosen again for whoever the token belongs to
+		r.With(api.Authenticate(accountService), web.CurrentTeam(teamService)).
+			Route("/v1", api.V1(reviewService, causeService, triggerService))
+	})
 
 	go (func() {
 		_ = server.Serve(ln)
@@ -110,3 +266,86 @@ func Start(ctx context.Context, cfg Config) (*Server, error) {
 		HTTP:   &server,
 	}, nil
 }
+
+// seedAdmin makes sure there's always someone who can sign in.
+func seedAdmin(ctx context.Context, service *accounts.Service, cfg Config) error {
+	users, err := service.All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check for existing users: %w", err)
+	}
+	if len(users) > 0 {
+		return nil
+	}
+
+	password := cfg.AdminPassword
+	if password == "" {
+		password = rand.Text()
+		// Not logged, so it doesn't end up wherever the logs are kept
+		if err := writeSecret(cfg.AdminPasswordFile, password); err != nil {
+			return fmt.Errorf("failed to write the generated admin password: %w", err)
+		}
+		slog.Warn("no admin password set, sign in with the generated password and change it", "email", cfg.AdminEmail, "passwordFile", cfg.AdminPasswordFile)
+	}
+
+	u, err := service.Register(ctx, cfg.AdminEmail, "Admin", password)
+	if err != nil {
+		return fmt.Errorf("failed to create admin user: %w", err)
+	}
+	if _, err := service.SetRole(ctx, u.ID, actor.RoleAdmin); err != nil {
+		return fmt.Errorf("failed to make the first user an admin: %w", err)
+	}
+
+	return nil
+}
+
+// writeSecret replaces the file with only the owner allowed to read it, creating its directory when missing.
+func writeSecret(path string, secret string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	// The mode is only used when creating it, so make sure a file that was already there isn't readable by others
+	if err := f.Chmod(0o600); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if _, err := f.WriteString(secret + "\n"); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func loadPublicationRules(path string) (reviewing.PublicationRules, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open publication rules: %w", err)
+	}
+	defer f.Close()
+
+	rules, err := reviewing.LoadPublicationRules(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load publication rules from %q: %w", path, err)
+	}
+
+	return rules, nil
+}
+
+func loadIncidentSources(path string) ([]intake.Source, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open incident sources: %w", err)
+	}
+	defer f.Close()
+
+	sources, err := intake.LoadSources(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load incident sources from %q: %w", path, err)
+	}
+
+	return sources, nil
+}
@@ -2,6 +2,7 @@ package web
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -11,23 +12,35 @@ import (
 	"github.com/gaqzi/passepartout"
 	"github.com/gaqzi/passepartout/ppdefaults"
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 
 	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
 )
 
 type causeService interface {
 	Save(ctx context.Context, cause contributing.Cause) (contributing.Cause, error)
 	All(ctx context.Context) ([]contributing.Cause, error)
+	Get(ctx context.Context, id uuid.UUID) (contributing.Cause, error)
+}
+
+type causeUsage interface {
+	// CauseUsage is how it's been used on the reviews of the current team.
+	CauseUsage(ctx context.Context, id uuid.UUID) (reviewing.Usage, error)
 }
 
 type causesHandler struct {
 	htmx    *htmx.HTMX
 	service causeService
+	usage   causeUsage
 	partial *partial.Service
 	pp      *passepartout.Passepartout
 }
 
-func ContributingCausesHandler(service causeService) func(chi.Router) {
+func ContributingCausesHandler(service causeService, usage causeUsage) func(chi.Router) {
 	fsys, err := passepartout.FSWithoutPrefix(templates, "templates")
 	if err != nil {
 		panic(err)
@@ -37,11 +50,13 @@ func ContributingCausesHandler(service causeService) func(chi.Router) {
 	a := causesHandler{
 		htmx:    htmx.New(),
 		service: service,
+		usage:   usage,
 		pp: passepartout.New(
 			ppdefaults.NewLoaderBuilder().
 				WithDefaults(fsys).
 				TemplateLoader(ppdefaults.NewCachedLoader(&ppdefaults.TemplateByNameLoader{FS: fsys})).
 				PartialsFor(partials.Load).
+				TemplateConfig(baseTemplate()).
 				Build(),
 		),
 	}
@@ -57,18 +72,24 @@ func ContributingCausesHandler(service causeService) func(chi.Router) {
 	return func(r chi.Router) {
 		r.Post("/", a.Create)
 		r.Get("/new", a.New)
+		r.Get("/{id}", a.Show)
 	}
 }
 
 func (a *causesHandler) New(w http.ResponseWriter, r *http.Request) {
 	h := a.htmx.NewHandler(w, r)
 
-	if !h.IsHxRequest() {
-		h.WriteHeader(http.StatusNotFound)
-		h.JustWriteString("not yet supported")
+	if err := actor.Require(r.Context(), actor.Curator); err != nil {
+		writeError(w, r, a.pp, fmt.Errorf("only curators can change the catalog: %w", err), http.StatusForbidden)
+		return
 	}
 
-	if err := a.pp.Render(w, "contributing-causes/new.html", nil); err != nil {
+	data := map[string]any{"InTeam": tenant.ID(r.Context()) != uuid.Nil, "Cause": ContributingCauseBasic{}}
+	if !h.IsHxRequest() {
+		// Without htmx it's a page of its own, so send them back to where they came from afterward
+		data["Next"] = safeRedirect(r.URL.Query().Get("next"))
+	}
+	if err := renderPartOrPage(w, r, a.pp, "contributing-causes/new.html", "contributing-causes/new.html", data); err != nil {
 		slog.Error("failed to render new form", "error", err)
 		http.Error(w, "failed to render", http.StatusInternalServerError)
 		return
@@ -78,11 +99,6 @@ func (a *causesHandler) New(w http.ResponseWriter, r *http.Request) {
 func (a *causesHandler) Create(w http.ResponseWriter, r *http.Request) {
 	h := a.htmx.NewHandler(w, r)
 
-	if !h.IsHxRequest() {
-		h.WriteHeader(http.StatusNotFound)
-		h.JustWriteString("not yet supported")
-	}
-
 	if err := r.ParseForm(); err != nil {
 		slog.Error("failed to parse form", "error", err)
 		h.WriteHeader(http.StatusInternalServerError)
@@ -93,18 +109,43 @@ func (a *causesHandler) Create(w http.ResponseWriter, r *http.Request) {
 	cause.Name = r.PostForm.Get("name")
 	cause.Description = r.PostForm.Get("description")
 	cause.Category = r.PostForm.Get("category")
+	if r.PostForm.Get("shared") == "" {
+		cause.TeamID = tenant.ID(r.Context())
+	}
 
 	cause, err := a.service.Save(r.Context(), cause)
+	if errs, ok := validate.Fields(err); ok {
+		// Show the form again with what they wrote, so they only have to fix what's wrong
+		data := map[string]any{
+			"InTeam": tenant.ID(r.Context()) != uuid.Nil,
+			"Shared": r.PostForm.Get("shared") != "",
+			"Cause":  ContributingCauseBasic{Name: cause.Name, Description: cause.Description, Category: cause.Category},
+			"Next":   r.PostForm.Get("next"),
+			"Errors": errs,
+		}
+		h.WriteHeader(http.StatusUnprocessableEntity)
+		if err := renderPartOrPage(w, r, a.pp, "contributing-causes/new.html", "contributing-causes/new.html", data); err != nil {
+			slog.Error("failed to render new form with errors", "error", err)
+		}
+		return
+	}
 	if err != nil {
 		slog.Error("failed to save new contributing cause", "error", err)
-		h.WriteHeader(http.StatusInternalServerError)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	if !h.IsHxRequest() {
+		setFlash(w, r, Flash{Message: "Proposed the contributing cause " + cause.Name + "."})
+		h.Header().Add("Location", safeRedirect(r.PostForm.Get("next")))
+		h.WriteHeader(http.StatusSeeOther)
 		return
 	}
 
 	causes, err := a.service.All(r.Context())
 	if err != nil {
 		slog.Error("failed to fetch all contributing causes after proposing new cause", "error", err)
-		h.WriteHeader(http.StatusInternalServerError)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -119,3 +160,39 @@ func (a *causesHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (a *causesHandler) Show(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for showing contributing cause", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	cause, err := a.service.Get(r.Context(), id)
+	if err != nil {
+		slog.Error("failed to get contributing cause", "id", id, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	usage, err := a.usage.CauseUsage(r.Context(), id)
+	if err != nil {
+		slog.Error("failed to get usage of contributing cause", "id", id, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]any{
+		"Cause": convertContributingCauseToHttpObject(cause),
+		"Usage": toUsageBasic(usage),
+	}
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "contributing-causes/show.html", layoutData(r, data)); err != nil {
+		slog.Error("failed to render contributing cause", "id", id, "error", err)
+		http.Error(w, "failed to render", http.StatusInternalServerError)
+		return
+	}
+}
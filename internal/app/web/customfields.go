@@ -0,0 +1,275 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gaqzi/passepartout"
+	"github.com/gaqzi/passepartout/ppdefaults"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+type customFieldService interface {
+	Save(ctx context.Context, f reviewing.CustomField) (reviewing.CustomField, error)
+	Get(ctx context.Context, id uuid.UUID) (reviewing.CustomField, error)
+	All(ctx context.Context) ([]reviewing.CustomField, error)
+}
+
+type customFieldsHandler struct {
+	htmx    *htmx.HTMX
+	service customFieldService
+	pp      *passepartout.Passepartout
+}
+
+// CustomFieldsHandler lets admins manage the extra fields the reviews of the team they're working as have.
+func CustomFieldsHandler(service customFieldService) func(chi.Router) {
+	fsys, err := passepartout.FSWithoutPrefix(templates, "templates")
+	if err != nil {
+		panic(err)
+	}
+
+	partials := &ppdefaults.PartialsWithCommon{FS: fsys, CommonDir: "partials"}
+	a := customFieldsHandler{
+		htmx:    htmx.New(),
+		service: service,
+		pp: passepartout.New(
+			ppdefaults.NewLoaderBuilder().
+				WithDefaults(fsys).
+				TemplateLoader(ppdefaults.NewCachedLoader(&ppdefaults.TemplateByNameLoader{FS: fsys})).
+				PartialsFor(partials.Load).
+				TemplateConfig(baseTemplate()).
+				Build(),
+		),
+	}
+
+	return func(r chi.Router) {
+		r.Get("/", a.Index)
+		r.Post("/", a.Create)
+		r.Get("/{id}", a.Edit)
+		r.Post("/{id}", a.Update)
+	}
+}
+
+// CustomFieldForm is a custom field as it's edited, the options are one per line.
+type CustomFieldForm struct {
+	ID       uuid.UUID
+	Name     string
+	Kind     string
+	Kinds    []reviewing.FieldKind
+	Options  string
+	Required bool
+	Errors   validate.FieldErrors
+}
+
+// CustomFieldBasic is a custom field with its value on a review, or what's filtered by on the listing.
+type CustomFieldBasic struct {
+	ID       uuid.UUID
+	Name     string
+	Kind     string
+	Options  []string
+	Required bool
+	Value    string
+	Error    string
+}
+
+func toCustomFieldForm(f reviewing.CustomField, errs validate.FieldErrors) CustomFieldForm {
+	return CustomFieldForm{
+		ID:       f.ID,
+		Name:     f.Name,
+		Kind:     string(f.Kind),
+		Kinds:    reviewing.FieldKinds,
+		Options:  strings.Join(f.Options, "\n"),
+		Required: f.Required,
+		Errors:   errs,
+	}
+}
+
+// toCustomFieldBasics has every field with its value, and why the value is wrong when it failed to validate.
+func toCustomFieldBasics(fields []reviewing.CustomField, values map[uuid.UUID]string, errs validate.FieldErrors) []CustomFieldBasic {
+	ret := make([]CustomFieldBasic, 0, len(fields))
+	for _, f := range fields {
+		ret = append(ret, CustomFieldBasic{
+			ID:       f.ID,
+			Name:     f.Name,
+			Kind:     string(f.Kind),
+			Options:  f.Options,
+			Required: f.Required,
+			Value:    values[f.ID],
+			Error:    errs[f.ID.String()],
+		})
+	}
+
+	return ret
+}
+
+// parseCustomValues takes the values posted as custom[<field id>], what isn't a field id is skipped.
+func parseCustomValues(form map[string][]string) map[uuid.UUID]string {
+	ret := make(map[uuid.UUID]string)
+	for k, vs := range form {
+		name, ok := strings.CutPrefix(k, "custom[")
+		if !ok || len(vs) == 0 {
+			continue
+		}
+		if id, err := uuid.Parse(strings.TrimSuffix(name, "]")); err == nil {
+			ret[id] = strings.TrimSpace(vs[len(vs)-1])
+		}
+	}
+
+	return ret
+}
+
+func (a *customFieldsHandler) Index(w http.ResponseWriter, r *http.Request) {
+	a.renderIndex(w, r, http.StatusOK, reviewing.NewCustomField(), nil)
+}
+
+func (a *customFieldsHandler) renderIndex(w http.ResponseWriter, r *http.Request, status int, f reviewing.CustomField, errs validate.FieldErrors) {
+	if err := actor.Require(r.Context(), actor.Admin); err != nil {
+		writeError(w, r, a.pp, fmt.Errorf("only admins can manage custom fields: %w", err), http.StatusForbidden)
+		return
+	}
+
+	all, err := a.service.All(r.Context())
+	if err != nil {
+		slog.Error("failed to fetch custom fields", "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+	data := map[string]any{
+		"Fields": toCustomFieldBasics(all, nil, nil),
+		"Field":  toCustomFieldForm(f, errs),
+	}
+
+	w.WriteHeader(status)
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "custom-fields/index.html", layoutData(r, data)); err != nil {
+		slog.Error("failed to render page", "page", "custom-fields/index", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *customFieldsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	f := fromCustomFieldForm(reviewing.NewCustomField(), r)
+	saved, err := a.service.Save(r.Context(), f)
+	if errs, ok := validate.Fields(err); ok {
+		a.renderIndex(w, r, http.StatusUnprocessableEntity, f, errs)
+		return
+	}
+	if err != nil {
+		slog.Error("failed to save new custom field", "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	setFlash(w, r, Flash{Message: "Created the custom field.", Link: "/custom-fields/" + saved.ID.String(), LinkText: saved.Name})
+	h.Header().Add("Location", "/custom-fields")
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+func (a *customFieldsHandler) Edit(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for edit custom field", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	f, err := a.service.Get(r.Context(), id)
+	if err != nil {
+		slog.Error("failed to get custom field", "id", id, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	a.renderEdit(w, r, http.StatusOK, f, nil)
+}
+
+func (a *customFieldsHandler) renderEdit(w http.ResponseWriter, r *http.Request, status int, f reviewing.CustomField, errs validate.FieldErrors) {
+	if err := actor.Require(r.Context(), actor.Admin); err != nil {
+		writeError(w, r, a.pp, fmt.Errorf("only admins can manage custom fields: %w", err), http.StatusForbidden)
+		return
+	}
+
+	w.WriteHeader(status)
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "custom-fields/edit.html", layoutData(r, map[string]any{"Field": toCustomFieldForm(f, errs)})); err != nil {
+		slog.Error("failed to render page", "page", "custom-fields/edit", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *customFieldsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for update custom field", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	stored, err := a.service.Get(r.Context(), id)
+	if err != nil {
+		slog.Error("failed to get custom field", "id", id, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	f := fromCustomFieldForm(stored, r)
+	_, err = a.service.Save(r.Context(), f)
+	if errs, ok := validate.Fields(err); ok {
+		a.renderEdit(w, r, http.StatusUnprocessableEntity, f, errs)
+		return
+	}
+	if err != nil {
+		slog.Error("failed to save custom field", "id", id, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	setFlash(w, r, Flash{Message: "Saved the custom field.", Link: "/custom-fields/" + id.String(), LinkText: f.Name})
+	h.Header().Add("Location", "/custom-fields")
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+// fromCustomFieldForm sets what was posted on the field, the options are one per line.
+func fromCustomFieldForm(f reviewing.CustomField, r *http.Request) reviewing.CustomField {
+	f.Name = strings.TrimSpace(r.PostForm.Get("name"))
+	f.Kind = reviewing.FieldKind(r.PostForm.Get("kind"))
+	f.Required = r.PostForm.Get("required") != ""
+
+	f.Options = nil
+	for _, line := range strings.Split(r.PostForm.Get("options"), "\n") {
+		if o := strings.TrimSpace(line); o != "" {
+			f.Options = append(f.Options, o)
+		}
+	}
+
+	return f
+}
@@ -0,0 +1,53 @@
+package web
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gaqzi/passepartout"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+// statusFor picks the status for the kind of error from a service,
+// otherwise is used for the errors that aren't of a kind.
+func statusFor(err error, otherwise int) int {
+	switch failure.KindOf(err) {
+	case failure.Forbidden:
+		return http.StatusForbidden
+	case failure.NotFound:
+		return http.StatusNotFound
+	case failure.Conflict:
+		return http.StatusConflict
+	case failure.Invalid:
+		return http.StatusUnprocessableEntity
+	default:
+		return otherwise
+	}
+}
+
+// writeError answers with the status for err, see statusFor, and what went wrong.
+// Full page loads get an error page, htmx requests only get the message so it's shown where the request was made.
+// The details of server errors are left for the logs since there's nothing they can do about them.
+func writeError(w http.ResponseWriter, r *http.Request, pp *passepartout.Passepartout, err error, otherwise int) {
+	status := statusFor(err, otherwise)
+	message := err.Error()
+	if status >= http.StatusInternalServerError {
+		message = "Something went wrong on our side, try again in a little while."
+	}
+	data := map[string]any{
+		"Status":  status,
+		"Title":   http.StatusText(status),
+		"Message": message,
+	}
+
+	w.WriteHeader(status)
+	if r.Header.Get("HX-Request") == "true" {
+		err = pp.Render(w, "partials/errors/_message.html", map[string]any{"Data": data})
+	} else {
+		err = pp.RenderInLayout(w, "layouts/standard.html", "errors/show.html", layoutData(r, data))
+	}
+	if err != nil {
+		slog.Error("failed to render error", "status", status, "error", err)
+	}
+}
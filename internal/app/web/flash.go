@@ -0,0 +1,104 @@
+package web
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gaqzi/passepartout"
+)
+
+// FlashCookieName is the cookie holding the message for the next page.
+const FlashCookieName = "flash"
+
+// Flash is a message shown once on the next page, so a change can say how it went after redirecting there.
+type Flash struct {
+	Message string
+	// Link is shown after the message when it's set, with LinkText as what it says.
+	Link     string
+	LinkText string
+}
+
+type flashState struct {
+	secureCookies bool
+	// shown is the flash left by the previous request, if there was one.
+	shown *Flash
+}
+
+type flashKey struct{}
+
+func flashStateFrom(ctx context.Context) flashState {
+	s, _ := ctx.Value(flashKey{}).(flashState)
+
+	return s
+}
+
+// Flashes takes the flash left by the previous request so it's shown on the page being loaded, and only on that one.
+// Requests from htmx for part of a page leave it for the next page, since they don't show the layout it's in.
+func Flashes(secureCookies bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			state := flashState{secureCookies: secureCookies}
+
+			isPartial := r.Header.Get("HX-Request") == "true" && r.Header.Get("HX-Boosted") != "true"
+			if cookie, err := r.Cookie(FlashCookieName); err == nil && r.Method == http.MethodGet && !isPartial {
+				http.SetCookie(w, flashCookie("", -1, secureCookies))
+
+				var f Flash
+				if raw, err := base64.RawURLEncoding.DecodeString(cookie.Value); err != nil {
+					slog.Warn("failed to decode flash cookie", "error", err)
+				} else if err := json.Unmarshal(raw, &f); err != nil {
+					slog.Warn("failed to unmarshal flash cookie", "error", err)
+				} else {
+					state.shown = &f
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), flashKey{}, state)))
+		})
+	}
+}
+
+// setFlash has the next page show f, it's for changes that redirect afterward so reloading doesn't make them again.
+func setFlash(w http.ResponseWriter, r *http.Request, f Flash) {
+	raw, err := json.Marshal(f)
+	if err != nil {
+		slog.Error("failed to marshal flash", "error", err)
+		return
+	}
+
+	http.SetCookie(w, flashCookie(base64.RawURLEncoding.EncodeToString(raw), 0, flashStateFrom(r.Context()).secureCookies))
+}
+
+func flashCookie(value string, maxAge int, secure bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     FlashCookieName,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// layoutData is what the layout is rendered with, data is for the page itself.
+func layoutData(r *http.Request, data any) map[string]any {
+	return map[string]any{
+		"Data":        data,
+		"CurrentUser": currentUser(r),
+		"Flash":       flashStateFrom(r.Context()).shown,
+	}
+}
+
+// renderPartOrPage renders the partial for htmx requests, and the page with it in the layout for everything else,
+// so a form that's swapped into a page with htmx also works on its own.
+func renderPartOrPage(w http.ResponseWriter, r *http.Request, pp *passepartout.Passepartout, partial, page string, data map[string]any) error {
+	if r.Header.Get("HX-Request") == "true" {
+		return pp.Render(w, partial, map[string]any{"Data": data})
+	}
+
+	return pp.RenderInLayout(w, "layouts/standard.html", page, layoutData(r, data))
+}
@@ -0,0 +1,39 @@
+package web
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/donseba/go-htmx"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/markdown"
+)
+
+type markdownHandler struct {
+	htmx *htmx.HTMX
+}
+
+// MarkdownHandler shows how what's written in the long text fields will look once it's saved.
+func MarkdownHandler() func(chi.Router) {
+	a := markdownHandler{htmx: htmx.New()}
+
+	return func(r chi.Router) {
+		r.Post("/preview", a.Preview)
+	}
+}
+
+// Preview renders the form value named by the field query parameter,
+// since htmx posts the whole form the textarea being previewed is in.
+func (a *markdownHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.Header().Set("Content-Type", "text/html; charset=utf-8")
+	h.JustWriteHTML(markdown.HTML(r.PostForm.Get(r.URL.Query().Get("field"))))
+}
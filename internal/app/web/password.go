@@ -0,0 +1,79 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gaqzi/passepartout"
+	"github.com/gaqzi/passepartout/ppdefaults"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+)
+
+type passwordService interface {
+	ChangePassword(ctx context.Context, current, password string) (accounts.User, error)
+}
+
+type passwordHandler struct {
+	htmx    *htmx.HTMX
+	service passwordService
+	pp      *passepartout.Passepartout
+}
+
+// PasswordHandler lets people change their own password.
+func PasswordHandler(service passwordService) func(chi.Router) {
+	fsys, err := passepartout.FSWithoutPrefix(templates, "templates")
+	if err != nil {
+		panic(err)
+	}
+
+	partials := &ppdefaults.PartialsWithCommon{FS: fsys, CommonDir: "partials"}
+	a := passwordHandler{
+		htmx:    htmx.New(),
+		service: service,
+		pp: passepartout.New(
+			ppdefaults.NewLoaderBuilder().
+				WithDefaults(fsys).
+				TemplateLoader(ppdefaults.NewCachedLoader(&ppdefaults.TemplateByNameLoader{FS: fsys})).
+				PartialsFor(partials.Load).
+				TemplateConfig(baseTemplate()).
+				Build(),
+		),
+	}
+
+	return func(r chi.Router) {
+		r.Get("/", a.Edit)
+		r.Post("/", a.Update)
+	}
+}
+
+func (a *passwordHandler) Edit(w http.ResponseWriter, r *http.Request) {
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "password/edit.html", layoutData(r, nil)); err != nil {
+		slog.Error("failed to render page", "page", "password/edit", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *passwordHandler) Update(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := a.service.ChangePassword(r.Context(), r.PostForm.Get("current"), r.PostForm.Get("password")); err != nil {
+		slog.Error("failed to change password", "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	setFlash(w, r, Flash{Message: "Changed your password."})
+	h.Header().Add("Location", "/password")
+	h.WriteHeader(http.StatusSeeOther)
+}
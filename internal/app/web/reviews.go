@@ -3,10 +3,15 @@ package web
 import (
 	"context"
 	"errors"
-	"fmt"
-	"html/template"
+	"io"
 	"log/slog"
+	"maps"
+	"mime"
 	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/donseba/go-htmx"
@@ -16,10 +21,12 @@ import (
 	"github.com/go-playground/form/v4"
 	"github.com/google/uuid"
 
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
 	"github.com/gaqzi/incident-reviewer/internal/normalized"
 	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
 	"github.com/gaqzi/incident-reviewer/internal/reviewing"
-	"github.com/gaqzi/incident-reviewer/internal/reviewing/storage"
 )
 
 type reviewingService interface {
@@ -29,72 +36,106 @@ type reviewingService interface {
 	// Save validates and stores a review.
 	Save(ctx context.Context, review reviewing.Review) (reviewing.Review, error)
 
-	// All returns all the stored reviews with the most recent first.
-	All(ctx context.Context) ([]reviewing.Review, error)
+	// Find returns the reviews matching the filter with the most recent first.
+	Find(ctx context.Context, f reviewing.Filter) ([]reviewing.Review, error)
+
+	// Update changes the fields allowed for mass changes unless the review is read-only.
+	Update(ctx context.Context, reviewID uuid.UUID, update reviewing.Review) (reviewing.Review, error)
+
+	// PublicationChecklist checks the review against the rules for publishing it.
+	PublicationChecklist(review reviewing.Review) reviewing.Checklist
+
+	// Transition moves the review to a new state in its lifecycle.
+	Transition(ctx context.Context, reviewID uuid.UUID, to reviewing.State) (reviewing.Review, error)
 
 	// BindContributingCause validates that the cause can be added to the review.
 	BindContributingCause(ctx context.Context, reviewID uuid.UUID, causeID uuid.UUID, boundCause reviewing.BoundCause) error
 	GetBoundContributingCause(ctx context.Context, reviewID uuid.UUID, boundCauseID uuid.UUID) (reviewing.BoundCause, error)
 	UpdateBoundContributingCause(ctx context.Context, reviewID uuid.UUID, boundCause reviewing.BoundCause) (reviewing.BoundCause, error)
+	// UnbindContributingCause takes the cause off the review while keeping its discussion.
+	UnbindContributingCause(ctx context.Context, reviewID uuid.UUID, boundCauseID uuid.UUID) error
 	BindTrigger(ctx context.Context, reviewID uuid.UUID, triggerID uuid.UUID, trigger reviewing.UnboundTrigger) error
 	GetBoundTrigger(ctx context.Context, reviewID uuid.UUID, boundTriggerID uuid.UUID) (reviewing.BoundTrigger, error)
 	UpdateBoundTrigger(ctx context.Context, reviewID uuid.UUID, boundTrigger reviewing.BoundTrigger) (reviewing.BoundTrigger, error)
+	// UnbindTrigger takes the trigger off the review while keeping its discussion.
+	UnbindTrigger(ctx context.Context, reviewID uuid.UUID, boundTriggerID uuid.UUID) error
+
+	// VoteOnBoundContributingCause records a participant's vote, and why, on a bound cause.
+	VoteOnBoundContributingCause(ctx context.Context, reviewID uuid.UUID, boundCauseID uuid.UUID, vote reviewing.Vote) (reviewing.BoundCause, error)
+	// VoteOnBoundTrigger records a participant's vote, and why, on a bound trigger.
+	VoteOnBoundTrigger(ctx context.Context, reviewID uuid.UUID, boundTriggerID uuid.UUID, vote reviewing.Vote) (reviewing.BoundTrigger, error)
+
+	AddComment(ctx context.Context, reviewID uuid.UUID, comment reviewing.Comment) (reviewing.Comment, error)
+	EditComment(ctx context.Context, reviewID uuid.UUID, commentID uuid.UUID, body string) (reviewing.Comment, error)
+	DeleteComment(ctx context.Context, reviewID uuid.UUID, commentID uuid.UUID) (reviewing.Comment, error)
+
+	// Attach stores the file on the review, or on one of its bound causes, when it fits the limits.
+	Attach(ctx context.Context, reviewID uuid.UUID, attachment reviewing.Attachment, contents io.Reader) (reviewing.Attachment, error)
+	// OpenAttachment returns the contents of the file, or its thumbnail, to anyone who can see the review.
+	OpenAttachment(ctx context.Context, reviewID uuid.UUID, attachmentID uuid.UUID, thumbnail bool) (reviewing.Attachment, io.ReadCloser, error)
+	RemoveAttachment(ctx context.Context, reviewID uuid.UUID, attachmentID uuid.UUID) (reviewing.Attachment, error)
+
+	// AddMember makes a user part of the review, or changes how they're part of it.
+	AddMember(ctx context.Context, reviewID uuid.UUID, userID uuid.UUID, kind reviewing.MemberKind) (reviewing.Review, error)
+	// RemoveMember takes a user off the review.
+	RemoveMember(ctx context.Context, reviewID uuid.UUID, userID uuid.UUID) (reviewing.Review, error)
+
+	// MoveToTeam changes which team the review belongs to.
+	MoveToTeam(ctx context.Context, reviewID uuid.UUID, teamID uuid.UUID) (reviewing.Review, error)
 }
 
 type causeAller interface {
 	All(ctx context.Context) ([]contributing.Cause, error)
 }
 
+type userAller interface {
+	All(ctx context.Context) ([]accounts.User, error)
+}
+
+type customFieldAller interface {
+	All(ctx context.Context) ([]reviewing.CustomField, error)
+}
+
+type reviewTemplateFinder interface {
+	Get(ctx context.Context, id uuid.UUID) (reviewing.Template, error)
+	All(ctx context.Context) ([]reviewing.Template, error)
+}
+
 type reviewsHandler struct {
 	htmx         *htmx.HTMX
 	decoder      *form.Decoder
 	causeStore   causeAller
 	triggerStore triggerService
 	service      reviewingService
-	pp           *passepartout.Passepartout
+	users        userAller
+	// reviewTemplates are what new reviews can be created from, see reviewing.Template.
+	reviewTemplates reviewTemplateFinder
+	customFields    customFieldAller
+	pp              *passepartout.Passepartout
 }
 
-func ReviewsHandler(service reviewingService, causeStore causeAller, triggerStore triggerService) func(chi.Router) {
+func ReviewsHandler(service reviewingService, causeStore causeAller, triggerStore triggerService, users userAller, reviewTemplates reviewTemplateFinder, customFields customFieldAller) func(chi.Router) {
 	fsys, err := passepartout.FSWithoutPrefix(templates, "templates")
 	if err != nil {
 		panic(err)
 	}
 
 	partials := &ppdefaults.PartialsWithCommon{FS: fsys, CommonDir: "partials"}
-	defaultTemplate := template.New("").Funcs(map[string]any{
-		"map": func(d map[string]any, args ...any) (map[string]any, error) {
-			if d == nil {
-				d = make(map[string]any, len(args)/2)
-			}
-
-			if oddArgs := len(args)%2 != 0; oddArgs {
-				return nil, fmt.Errorf("did not receive an even key/value pair of arguments for map: %s", args)
-			}
-
-			for i := 0; i < len(args); i += 2 {
-				key, ok := args[i].(string)
-				if !ok {
-					return nil, fmt.Errorf("argument %d is not a string: %q", i, args[i])
-				}
-
-				d[key] = args[i+1]
-			}
-
-			return d, nil
-		},
-	})
 	app := reviewsHandler{
-		htmx:         htmx.New(),
-		decoder:      form.NewDecoder(),
-		causeStore:   causeStore,
-		triggerStore: triggerStore,
-		service:      service,
+		htmx:            htmx.New(),
+		decoder:         form.NewDecoder(),
+		causeStore:      causeStore,
+		triggerStore:    triggerStore,
+		service:         service,
+		users:           users,
+		reviewTemplates: reviewTemplates,
+		customFields:    customFields,
 		pp: passepartout.New(
 			ppdefaults.NewLoaderBuilder().
 				WithDefaults(fsys).
 				TemplateLoader(ppdefaults.NewCachedLoader(&ppdefaults.TemplateByNameLoader{FS: fsys})).
 				PartialsFor(partials.Load).
-				TemplateConfig(defaultTemplate).
+				TemplateConfig(baseTemplate()).
 				Build(),
 		),
 	}
@@ -116,14 +157,32 @@ func ReviewsHandler(service reviewingService, causeStore causeAller, triggerStor
 			r.Get("/", app.Show)
 			r.Get("/edit", app.Edit)
 			r.Post("/edit", app.Update)
+			r.Post("/state", app.Transition)
+
+			r.Post("/members", app.AddMember)
+			r.Post("/members/{userID}/delete", app.RemoveMember)
+			r.Post("/team", app.MoveToTeam)
 
 			r.Post("/contributing-causes", app.BindContributingCause)
 			r.Get("/contributing-causes/{boundCauseID}/edit", app.EditBoundContributingCause)
 			r.Post("/contributing-causes/{boundCauseID}/edit", app.UpdateBoundContributingCause)
+			r.Post("/contributing-causes/{boundCauseID}/votes", app.VoteOnBoundContributingCause)
+			r.Post("/contributing-causes/{boundCauseID}/unbind", app.UnbindContributingCause)
 
 			r.Post("/triggers", app.BindTrigger)
 			r.Get("/triggers/{boundTriggerID}/edit", app.EditBoundTrigger)
 			r.Post("/triggers/{boundTriggerID}/edit", app.UpdateBoundTrigger)
+			r.Post("/triggers/{boundTriggerID}/votes", app.VoteOnBoundTrigger)
+			r.Post("/triggers/{boundTriggerID}/unbind", app.UnbindTrigger)
+
+			r.Post("/comments", app.AddComment)
+			r.Post("/comments/{commentID}/edit", app.EditComment)
+			r.Post("/comments/{commentID}/delete", app.DeleteComment)
+
+			r.Post("/attachments", app.Attach)
+			r.Get("/attachments/{attachmentID}", app.OpenAttachment)
+			r.Get("/attachments/{attachmentID}/thumbnail", app.OpenAttachment)
+			r.Post("/attachments/{attachmentID}/delete", app.RemoveAttachment)
 		})
 	}
 }
@@ -142,12 +201,74 @@ type ReviewBasic struct {
 	ReportProximalCause string    `form:"reportProximalCause"`
 	ReportTrigger       string    `form:"reportTrigger"`
 
+	// TemplateID is the template the review is created from, Guidance and the prompts of Answers come from it.
+	TemplateID uuid.UUID     `form:"templateID"`
+	Guidance   string        `form:"-"`
+	Answers    []AnswerBasic `form:"answers"`
+
+	// Custom are the values of the custom fields posted as custom[<field id>], see parseCustomValues,
+	// and CustomFields are the fields of the team with their values.
+	Custom       map[uuid.UUID]string `form:"-"`
+	CustomFields []CustomFieldBasic   `form:"-"`
+
+	// Errors is why the fields failed to validate when the form is shown again.
+	Errors validate.FieldErrors `form:"-"`
+
+	TeamID        uuid.UUID
+	State         StateBasic
+	ReadOnly      bool
+	CanEdit       bool
+	CanFacilitate bool
+	NextStates    []StateBasic
+	Transitions   []TransitionBasic
+	Checklist     []ChecklistItemBasic
+
 	// Related items that are not changed from the forms but by other calls
 	BoundCauses   []BoundCauseBasic
 	BoundTriggers []BoundTriggerBasic
+	Comments      []CommentBasic
+	// CommentsOnRemovedItems are the threads about causes and triggers that have since been unbound.
+	CommentsOnRemovedItems []CommentBasic
+	Attachments            []AttachmentBasic
+	Facilitators           []MemberBasic
+	Participants           []MemberBasic
+
+	IncidentStartedAt  time.Time
+	IncidentResolvedAt time.Time
+	UpdatedAt          time.Time
+	CreatedAt          time.Time
+}
 
-	UpdatedAt time.Time
-	CreatedAt time.Time
+type AnswerBasic struct {
+	Field  string `form:"field"`
+	Prompt string `form:"-"`
+	Text   string `form:"text"`
+}
+
+type MemberBasic struct {
+	ID   uuid.UUID
+	Name string
+}
+
+type MemberForm struct {
+	UserID uuid.UUID `form:"userID"`
+	Kind   string    `form:"kind"`
+}
+
+type StateBasic struct {
+	Value string
+	Label string
+}
+
+type TransitionBasic struct {
+	From StateBasic
+	To   StateBasic
+	At   time.Time
+}
+
+type ChecklistItemBasic struct {
+	Description string
+	Passed      bool
 }
 
 type BoundCauseForm struct {
@@ -163,16 +284,73 @@ type BoundCauseForm struct {
 
 type BoundCauseBasic struct {
 	ID              uuid.UUID
+	CauseID         uuid.UUID
 	Name            string
 	Why             string
 	Category        string
 	IsProximalCause bool
+	Tally           TallyBasic
+	Comments        []CommentBasic
+	Attachments     []AttachmentBasic
+	Errors          validate.FieldErrors
 }
 
 type BoundTriggerBasic struct {
-	ID   uuid.UUID
-	Name string
-	Why  string
+	ID        uuid.UUID
+	TriggerID uuid.UUID
+	Name      string
+	Why       string
+	Tally     TallyBasic
+	Comments  []CommentBasic
+	Errors    validate.FieldErrors
+}
+
+type CommentForm struct {
+	SubjectKind string    `form:"subjectKind"`
+	SubjectID   uuid.UUID `form:"subjectID"`
+	ParentID    uuid.UUID `form:"parentID"`
+	Body        string    `form:"body"`
+}
+
+type CommentBasic struct {
+	ID          uuid.UUID
+	SubjectKind string
+	SubjectID   uuid.UUID
+	Author      MemberBasic
+	Body        string
+	IsDeleted   bool
+	IsEdited    bool
+	CanChange   bool
+	Replies     []CommentBasic
+
+	CreatedAt time.Time
+}
+
+type AttachmentBasic struct {
+	ID           uuid.UUID
+	Name         string
+	ContentType  string
+	Size         string
+	IsImage      bool
+	HasThumbnail bool
+}
+
+type VoteForm struct {
+	Direction string `form:"direction"`
+	Reason    string `form:"reason"`
+}
+
+type ReasonCountBasic struct {
+	Reason string
+	Count  int
+}
+
+type TallyBasic struct {
+	For            int
+	Against        int
+	ReasonsFor     []ReasonCountBasic
+	ReasonsAgainst []ReasonCountBasic
+	Consensus      string
 }
 
 type TriggerForm struct {
@@ -205,13 +383,40 @@ func (a *reviewsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		h.JustWriteString(err.Error())
 		return
 	}
+	inc.Custom = parseCustomValues(r.PostForm)
 
 	rev := fromHttpObject(inc)
+	if inc.TemplateID != uuid.Nil {
+		tpl, err := a.reviewTemplates.Get(r.Context(), inc.TemplateID)
+		if err != nil {
+			slog.Error("failed to get review template for new review", "templateID", inc.TemplateID, "error", err)
+			writeError(w, r, a.pp, err, http.StatusInternalServerError)
+			return
+		}
+		rev = tpl.Apply(rev)
+	}
+
 	rev, err := a.service.Save(r.Context(), rev)
+	if errs, ok := validate.Fields(err); ok {
+		// Show the form again with what they wrote, so they only have to fix what's wrong
+		inc.Guidance = rev.Guidance
+		inc.Answers = toAnswerBasics(rev.Answers)
+		inc.CustomFields = toCustomFieldBasics(a.loadCustomFields(r.Context()), inc.Custom, errs)
+		inc.Errors = errs
+		h.WriteHeader(http.StatusUnprocessableEntity)
+		a.renderIndex(w, r, map[string]any{"Review": inc})
+		return
+	}
 	if err != nil {
 		slog.Error("failed to save incident", "error", err)
-		h.WriteHeader(http.StatusInternalServerError)
-		h.JustWriteString(err.Error())
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	if !h.IsHxRequest() {
+		setFlash(w, r, Flash{Message: "Successfully created the review.", Link: "/reviews/" + rev.ID.String(), LinkText: rev.Title})
+		h.Header().Add("Location", "/reviews")
+		h.WriteHeader(http.StatusSeeOther)
 		return
 	}
 
@@ -229,9 +434,26 @@ func (a *reviewsHandler) renderIndex(w http.ResponseWriter, r *http.Request, dat
 	if _, ok := data["Report"]; !ok {
 		data["Report"] = map[string]any{}
 	}
+	templates, err := a.reviewTemplates.All(r.Context())
+	if err != nil {
+		// Reviews can still be created without a template, so only log it
+		slog.Error("failed to fetch review templates", "error", err)
+	}
+	data["Templates"] = templates
+	data["TemplateID"] = r.URL.Query().Get("template")
+	customFields := a.loadCustomFields(r.Context())
+	if _, ok := data["Review"]; !ok {
+		review := a.newReviewForm(r, templates)
+		review.CustomFields = toCustomFieldBasics(customFields, nil, nil)
+		data["Review"] = review
+	}
+	// Only the custom fields with a value are filtered by, so leaving it empty is any value
+	filterBy := parseCustomValues(r.URL.Query())
+	maps.DeleteFunc(filterBy, func(_ uuid.UUID, v string) bool { return v == "" })
+	data["Filters"] = toCustomFieldBasics(customFields, filterBy, nil)
 	if _, ok := data["Reviews"]; !ok {
 		ctx, cancel := context.WithTimeout(r.Context(), time.Second)
-		reviews, err := a.service.All(ctx)
+		reviews, err := a.service.Find(ctx, reviewing.Filter{State: reviewing.State(r.URL.Query().Get("state")), Custom: filterBy})
 		if err != nil {
 			// Only log the error and set the empty listing as it's an okay fallback instead of returning an error
 			slog.Error("failed to fetch all reviews", "error", err)
@@ -239,14 +461,77 @@ func (a *reviewsHandler) renderIndex(w http.ResponseWriter, r *http.Request, dat
 		cancel()
 		data["Reviews"] = convertToHttpObjects(reviews)
 	}
+	data["States"] = toStateBasics(reviewing.States)
+	data["CanStartReview"] = actor.Require(r.Context(), reviewing.CanStartReview) == nil
+	data["State"] = r.URL.Query().Get("state")
 
-	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "reviews/index.html", map[string]any{"Data": data}); err != nil {
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "reviews/index.html", layoutData(r, data)); err != nil {
 		slog.Error("failed to render page", "page", "reviews/index", "error", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 }
 
+// loadCustomFields are the custom fields of the team being worked as. The reviews work the same without them,
+// so when they can't be fetched it's only logged.
+func (a *reviewsHandler) loadCustomFields(ctx context.Context) []reviewing.CustomField {
+	fields, err := a.customFields.All(ctx)
+	if err != nil {
+		slog.Error("failed to fetch custom fields", "error", err)
+	}
+
+	return fields
+}
+
+// newReviewForm is the empty form for a new review, asking what the template chosen with ?template= asks.
+func (a *reviewsHandler) newReviewForm(r *http.Request, templates []reviewing.Template) ReviewBasic {
+	id, err := uuid.Parse(r.URL.Query().Get("template"))
+	if err != nil {
+		return ReviewBasic{}
+	}
+	i := slices.IndexFunc(templates, func(t reviewing.Template) bool { return t.ID == id })
+	if i < 0 {
+		return ReviewBasic{}
+	}
+
+	rev := templates[i].Apply(reviewing.Review{})
+	return ReviewBasic{TemplateID: rev.TemplateID, Guidance: rev.Guidance, Answers: toAnswerBasics(rev.Answers)}
+}
+
+// suggestions are the contributing causes and triggers the template of the review suggests binding,
+// they're offered first when binding. There are none when it wasn't created from a template.
+func (a *reviewsHandler) suggestions(ctx context.Context, review reviewing.Review, causes []contributing.Cause, triggers []normalized.Trigger) map[string]any {
+	ret := map[string]any{}
+	if review.TemplateID == uuid.Nil {
+		return ret
+	}
+
+	tpl, err := a.reviewTemplates.Get(ctx, review.TemplateID)
+	if err != nil {
+		// The suggestions are only a shortcut, binding works the same without them
+		slog.Error("failed to get review template for suggestions", "reviewID", review.ID, "templateID", review.TemplateID, "error", err)
+		return ret
+	}
+
+	var suggestedCauses []ContributingCauseBasic
+	for _, c := range causes {
+		if slices.Contains(tpl.SuggestedCauseIDs, c.ID) {
+			suggestedCauses = append(suggestedCauses, convertContributingCauseToHttpObject(c))
+		}
+	}
+	var suggestedTriggers []normalized.Trigger
+	for _, t := range triggers {
+		if slices.Contains(tpl.SuggestedTriggerIDs, t.ID) {
+			suggestedTriggers = append(suggestedTriggers, t)
+		}
+	}
+	ret["SuggestedCauses"] = suggestedCauses
+	ret["SuggestedTriggers"] = convertTriggersToHttpObjects(suggestedTriggers)
+	ret["TemplateName"] = tpl.Name
+
+	return ret
+}
+
 func (a *reviewsHandler) Show(w http.ResponseWriter, r *http.Request) {
 	h := a.htmx.NewHandler(w, r)
 
@@ -258,23 +543,33 @@ func (a *reviewsHandler) Show(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	review, err := a.loadReview(r.Context(), h, reviewID)
+	a.renderShow(w, r, reviewID, http.StatusOK, nil)
+}
+
+// renderShow renders the whole page for the review, with what's in overrides replacing the defaults,
+// like the forms showing what was typed when it failed to validate.
+func (a *reviewsHandler) renderShow(w http.ResponseWriter, r *http.Request, reviewID uuid.UUID, status int, overrides map[string]any) {
+	review, err := a.loadReview(w, r, reviewID)
 	if err != nil {
 		return
 	}
 
-	contributingCauses, err := a.loadContributingCauses(r.Context(), h)
+	contributingCauses, err := a.loadContributingCauses(w, r)
 	if err != nil {
 		return
 	}
 
-	triggers, err := a.loadTriggers(r.Context(), h)
+	triggers, err := a.loadTriggers(w, r)
 	if err != nil {
 		return
 	}
 
-	httpReview := convertToHttpObject(review)
+	httpReview, users := a.toReviewBasic(r.Context(), review)
+	httpReview.Checklist = toChecklistBasic(a.service.PublicationChecklist(review))
+	httpReview.CustomFields = toCustomFieldBasics(a.loadCustomFields(r.Context()), review.CustomValues, nil)
 	data := map[string]any{
+		"Users":              users,
+		"CanMove":            actor.Require(r.Context(), actor.Admin) == nil,
 		"Review":             httpReview,
 		"BoundCauses":        httpReview.BoundCauses,
 		"BoundTriggers":      httpReview.BoundTriggers,
@@ -284,8 +579,15 @@ func (a *reviewsHandler) Show(w http.ResponseWriter, r *http.Request) {
 		"ContributingCause":  BoundCauseBasic{},
 		"BoundTrigger":       BoundTriggerBasic{},
 	}
+	maps.Copy(data, a.suggestions(r.Context(), review, contributingCauses, triggers))
+	maps.Copy(data, overrides)
 
-	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "reviews/show.html", map[string]any{"Data": data}); err != nil {
+	if choice, ok := teamChoiceFrom(r.Context()); ok {
+		data["Teams"] = choice.Options
+	}
+
+	w.WriteHeader(status)
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "reviews/show.html", layoutData(r, data)); err != nil {
 		slog.Error("failed to render a review", "reviewID", reviewID, "error", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -303,24 +605,18 @@ func (a *reviewsHandler) Edit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	review, err := a.service.Get(r.Context(), reviewID)
+	review, err := a.loadReview(w, r, reviewID)
 	if err != nil {
-		slog.Info("get review error", "error", err)
-
-		var notFoundError *storage.NoReviewError
-		if errors.As(err, &notFoundError) {
-			h.WriteHeader(http.StatusNotFound)
-			h.JustWriteString(fmt.Sprintf("404: review by id '%d' not found.", reviewID))
-			return
-		}
-		slog.Error("error finding review", "error", err)
+		return
 	}
 
+	httpReview := convertToHttpObject(review)
+	httpReview.CustomFields = toCustomFieldBasics(a.loadCustomFields(r.Context()), review.CustomValues, nil)
 	data := map[string]any{
-		"Review": convertToHttpObject(review),
+		"Review": httpReview,
 	}
 
-	err = a.pp.RenderInLayout(w, "layouts/standard.html", "reviews/edit.html", map[string]any{"Data": data})
+	err = a.pp.RenderInLayout(w, "layouts/standard.html", "reviews/edit.html", layoutData(r, data))
 	if err != nil {
 		slog.Error("failed to render", "error", err)
 		h.WriteHeader(http.StatusInternalServerError)
@@ -339,19 +635,6 @@ func (a *reviewsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	review, err := a.service.Get(r.Context(), reviewID)
-	if err != nil {
-		slog.Info("get review error", "error", err)
-
-		var notFoundError *storage.NoReviewError
-		if errors.As(err, &notFoundError) {
-			h.WriteHeader(http.StatusNotFound)
-			h.JustWriteString(fmt.Sprintf("404: review by id '%d' not found.", reviewID))
-			return
-		}
-		slog.Error("error finding review", "error", err)
-	}
-
 	if err := r.ParseForm(); err != nil {
 		slog.Error("failed to parse form", "error", err)
 		h.WriteHeader(http.StatusInternalServerError)
@@ -365,33 +648,37 @@ func (a *reviewsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		h.JustWriteString(err.Error())
 		return
 	}
+	inc.Custom = parseCustomValues(r.PostForm)
 
-	// Now update the fetched review and save it
-	review = review.Update(fromHttpObject(inc))
-	_, err = a.service.Save(r.Context(), review)
+	_, err = a.service.Update(r.Context(), reviewID, fromHttpObject(inc))
+	if errs, ok := validate.Fields(err); ok {
+		inc.ID = reviewID
+		inc.CustomFields = toCustomFieldBasics(a.loadCustomFields(r.Context()), inc.Custom, errs)
+		inc.Errors = errs
+		h.WriteHeader(http.StatusUnprocessableEntity)
+		if err := a.pp.RenderInLayout(w, "layouts/standard.html", "reviews/edit.html", layoutData(r, map[string]any{"Review": inc})); err != nil {
+			slog.Error("failed to render edit form with errors", "reviewID", reviewID, "error", err)
+		}
+		return
+	}
 	if err != nil {
 		slog.Error("failed to save review", "id", reviewID, "error", err)
-		h.WriteHeader(http.StatusInternalServerError)
-		h.JustWriteString(err.Error())
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
 		return
 	}
 
 	// TODO: HTMX redirect so it doesn't reload the whole page and instead just loads the new content.
+	setFlash(w, r, Flash{Message: "Saved the review."})
 	h.Header().Add("Location", "/reviews/"+reviewID.String())
 	h.WriteHeader(http.StatusSeeOther)
 }
 
-func (a *reviewsHandler) BindContributingCause(w http.ResponseWriter, r *http.Request) {
+func (a *reviewsHandler) Transition(w http.ResponseWriter, r *http.Request) {
 	h := a.htmx.NewHandler(w, r)
 
-	if !h.IsHxRequest() {
-		h.WriteHeader(http.StatusNotFound)
-		h.JustWriteString("non-htmx requests not yet supported")
-	}
-
 	reviewID, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
-		slog.Error("failed to parse id for create contributing cause", "id", r.PathValue("id"), "error", err)
+		slog.Error("failed to parse id for transition", "id", r.PathValue("id"), "error", err)
 		h.WriteHeader(http.StatusBadRequest)
 		h.JustWriteString("invalid id")
 		return
@@ -403,111 +690,93 @@ func (a *reviewsHandler) BindContributingCause(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	var boundCauseForm BoundCauseForm
-	if err := a.decoder.Decode(&boundCauseForm, r.PostForm); err != nil {
-		slog.Error("failed to decode basic contributing cause form", "error", err)
-		h.WriteHeader(http.StatusBadRequest)
-		h.JustWriteString(err.Error())
+	to := reviewing.State(r.PostForm.Get("state"))
+	if _, err := a.service.Transition(r.Context(), reviewID, to); err != nil {
+		slog.Error("failed to transition review", "reviewID", reviewID, "to", to, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
 		return
 	}
 
-	if err := a.service.BindContributingCause(
-		r.Context(),
-		reviewID,
-		boundCauseForm.ContributingCauseID,
-		reviewing.BoundCause{Why: boundCauseForm.Why, IsProximalCause: boundCauseForm.IsProximalCause},
-	); err != nil {
-		slog.Error("failed to bind contributing cause", "reviewID", reviewID, "error", err)
-		h.WriteHeader(http.StatusBadRequest)
-		return
-	}
+	// Changing the state changes what can be done on the whole page, so always reload it.
+	setFlash(w, r, Flash{Message: "The review is now " + strings.ReplaceAll(string(to), "_", " ") + "."})
+	h.Header().Add("Location", "/reviews/"+reviewID.String())
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+func (a *reviewsHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
 
-	review, err := a.loadReview(r.Context(), h, reviewID)
+	reviewID, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
+		slog.Error("failed to parse id for add member", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
 		return
 	}
 
-	contributingCauses, err := a.loadContributingCauses(r.Context(), h)
-	if err != nil {
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	httpReview := convertToHttpObject(review)
-	data := map[string]any{
-		"Review":             httpReview,
-		"BoundCauses":        httpReview.BoundCauses,
-		"ContributingCauses": convertContributingCauseToHttpObjects(contributingCauses),
-		"ReviewID":           reviewID,
-		"ContributingCause":  BoundCauseBasic{},
+	var form MemberForm
+	if err := a.decoder.Decode(&form, r.PostForm); err != nil {
+		slog.Error("failed to decode member form", "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString(err.Error())
+		return
 	}
 
-	if err := a.pp.Render(w, "reviews/show/_contributing-causes.html", map[string]any{"Data": data}); err != nil {
-		slog.Error("failed to render bind contributing cause", "reviewID", reviewID, "error", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if _, err := a.service.AddMember(r.Context(), reviewID, form.UserID, reviewing.MemberKind(form.Kind)); err != nil {
+		slog.Error("failed to add member", "reviewID", reviewID, "userID", form.UserID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
 		return
 	}
+
+	// Who is part of the review changes what they can do on the whole page, so always reload it.
+	setFlash(w, r, Flash{Message: "Added them to the review."})
+	h.Header().Add("Location", "/reviews/"+reviewID.String())
+	h.WriteHeader(http.StatusSeeOther)
 }
 
-func (a *reviewsHandler) EditBoundContributingCause(w http.ResponseWriter, r *http.Request) {
+func (a *reviewsHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
 	h := a.htmx.NewHandler(w, r)
 
-	if !h.IsHxRequest() {
-		h.WriteHeader(http.StatusNotFound)
-		h.JustWriteString("non-htmx requests not yet supported")
-	}
-
 	reviewID, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
-		slog.Error("failed to parse id for create contributing cause", "id", r.PathValue("id"), "error", err)
+		slog.Error("failed to parse id for remove member", "id", r.PathValue("id"), "error", err)
 		h.WriteHeader(http.StatusBadRequest)
 		h.JustWriteString("invalid id")
 		return
 	}
 
-	boundCauseID, err := uuid.Parse(r.PathValue("boundCauseID"))
+	userID, err := uuid.Parse(r.PathValue("userID"))
 	if err != nil {
-		slog.Error("failed to parse bound cause id for editing cause", "id", r.PathValue("id"), "boundCauseID", r.PathValue("boundCauseID"), "error", err)
+		slog.Error("failed to parse user id for remove member", "userID", r.PathValue("userID"), "error", err)
 		h.WriteHeader(http.StatusBadRequest)
-		h.JustWriteString("invalid id")
+		h.JustWriteString("invalid user id")
 		return
 	}
 
-	boundCause, err := a.service.GetBoundContributingCause(r.Context(), reviewID, boundCauseID)
-	if err != nil {
-		slog.Error("failed to get bound contributing cause", "id", reviewID, "boundCauseID", boundCauseID, "error", err)
-		h.WriteHeader(http.StatusInternalServerError)
+	if _, err := a.service.RemoveMember(r.Context(), reviewID, userID); err != nil {
+		slog.Error("failed to remove member", "reviewID", reviewID, "userID", userID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
 		return
 	}
 
-	allCauses, _ := a.loadContributingCauses(r.Context(), h)
-
-	data := map[string]any{
-		"ContributingCauses": convertContributingCauseToHttpObjects(allCauses),
-		"ContributingCause":  toBoundCauseBasic(boundCause),
-		"boundCauseID":       boundCauseID,
-		"ReviewID":           reviewID,
-		"SelectedCauseID":    boundCause.Cause.ID.String(),
-	}
-
-	if err := a.pp.Render(w, "partials/contributing-causes/_form.html", map[string]any{"Data": data}); err != nil {
-		slog.Error("failed to render edit bound contributing cause form", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	setFlash(w, r, Flash{Message: "Removed them from the review."})
+	h.Header().Add("Location", "/reviews/"+reviewID.String())
+	h.WriteHeader(http.StatusSeeOther)
 }
 
-func (a *reviewsHandler) UpdateBoundContributingCause(w http.ResponseWriter, r *http.Request) {
+// UnbindContributingCause reloads the whole review since the cause's discussion moves to the removed items.
+func (a *reviewsHandler) UnbindContributingCause(w http.ResponseWriter, r *http.Request) {
 	h := a.htmx.NewHandler(w, r)
 
-	if !h.IsHxRequest() {
-		h.WriteHeader(http.StatusNotFound)
-		h.JustWriteString("non-htmx requests not yet supported")
-		return
-	}
-
 	reviewID, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
-		slog.Error("failed to parse id for update contributing cause", "id", r.PathValue("id"), "error", err)
+		slog.Error("failed to parse id for unbind contributing cause", "id", r.PathValue("id"), "error", err)
 		h.WriteHeader(http.StatusBadRequest)
 		h.JustWriteString("invalid id")
 		return
@@ -515,101 +784,831 @@ func (a *reviewsHandler) UpdateBoundContributingCause(w http.ResponseWriter, r *
 
 	boundCauseID, err := uuid.Parse(r.PathValue("boundCauseID"))
 	if err != nil {
-		slog.Error("failed to parse bound cause id for updating cause", "id", r.PathValue("id"), "boundCauseID", r.PathValue("boundCauseID"), "error", err)
+		slog.Error("failed to parse bound cause id for unbind contributing cause", "boundCauseID", r.PathValue("boundCauseID"), "error", err)
 		h.WriteHeader(http.StatusBadRequest)
-		h.JustWriteString("invalid id")
+		h.JustWriteString("invalid bound cause id")
 		return
 	}
 
-	if err := r.ParseForm(); err != nil {
-		slog.Error("failed to parse form", "error", err)
-		h.WriteHeader(http.StatusInternalServerError)
+	if err := a.service.UnbindContributingCause(r.Context(), reviewID, boundCauseID); err != nil {
+		slog.Error("failed to unbind contributing cause", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
 		return
 	}
 
-	var updatedCause BoundCauseForm
-	if err := a.decoder.Decode(&updatedCause, r.PostForm); err != nil {
-		slog.Error("failed to decode request form for updating contributing cause", "error", err)
+	setFlash(w, r, Flash{Message: "Removed the contributing cause."})
+	h.Header().Add("Location", "/reviews/"+reviewID.String())
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+// UnbindTrigger reloads the whole review since the trigger's discussion moves to the removed items.
+func (a *reviewsHandler) UnbindTrigger(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for unbind trigger", "id", r.PathValue("id"), "error", err)
 		h.WriteHeader(http.StatusBadRequest)
-		h.JustWriteString(err.Error())
+		h.JustWriteString("invalid id")
 		return
 	}
 
-	boundCause, err := a.service.UpdateBoundContributingCause(r.Context(), reviewID, reviewing.BoundCause{
-		ID:              boundCauseID,
-		Why:             updatedCause.Why,
-		IsProximalCause: updatedCause.IsProximalCause,
-		Cause:           contributing.Cause{ID: updatedCause.ContributingCauseID},
-	})
+	boundTriggerID, err := uuid.Parse(r.PathValue("boundTriggerID"))
 	if err != nil {
-		slog.Error("failed to update bound contributing cause", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
-		h.WriteHeader(http.StatusInternalServerError)
+		slog.Error("failed to parse bound trigger id for unbind trigger", "boundTriggerID", r.PathValue("boundTriggerID"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid bound trigger id")
 		return
 	}
 
-	data := map[string]any{
-		"ReviewID":          reviewID,
-		"ContributingCause": toBoundCauseBasic(boundCause),
-	}
-
-	if err := a.pp.Render(w, "reviews/show/_contributing-cause-bound-li.html", map[string]any{"Data": data}); err != nil {
-		slog.Error("failed to render after updating bound contributing cause", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := a.service.UnbindTrigger(r.Context(), reviewID, boundTriggerID); err != nil {
+		slog.Error("failed to unbind trigger", "reviewID", reviewID, "boundTriggerID", boundTriggerID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
 		return
 	}
+
+	setFlash(w, r, Flash{Message: "Removed the trigger."})
+	h.Header().Add("Location", "/reviews/"+reviewID.String())
+	h.WriteHeader(http.StatusSeeOther)
 }
 
-func (a *reviewsHandler) hasErrored(h *htmx.Handler, err error, status int, msg string, args ...any) bool {
-	if err == nil {
-		return false
-	}
+func (a *reviewsHandler) MoveToTeam(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
 
-	slog.Error(msg, args...)
-	h.WriteHeader(status)
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for move to team", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
 
-	return true
-}
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 
-func (a *reviewsHandler) loadReview(ctx context.Context, h *htmx.Handler, reviewID uuid.UUID) (reviewing.Review, error) {
-	review, err := a.service.Get(ctx, reviewID)
-	if err != nil {
-		var notFoundError *storage.NoReviewError
-		if errors.As(err, &notFoundError) {
-			h.WriteHeader(http.StatusNotFound)
-			h.JustWriteString(fmt.Sprintf("404: review by id '%d' not found.", reviewID))
-			return reviewing.Review{}, err
+	teamID := uuid.Nil
+	if v := r.PostForm.Get("team"); v != "" {
+		teamID, err = uuid.Parse(v)
+		if err != nil {
+			slog.Error("failed to parse team id for move to team", "team", v, "error", err)
+			h.WriteHeader(http.StatusBadRequest)
+			h.JustWriteString("invalid team id")
+			return
 		}
-		slog.Error("error finding review", "error", err)
 	}
-	return review, err
-}
 
-func (a *reviewsHandler) loadContributingCauses(ctx context.Context, h *htmx.Handler) ([]contributing.Cause, error) {
-	contributingCauses, err := a.causeStore.All(ctx)
-	if a.hasErrored(h, err, http.StatusInternalServerError, "failed to get all contributing causes", "error", err) {
-		return nil, err
+	if _, err := a.service.MoveToTeam(r.Context(), reviewID, teamID); err != nil {
+		slog.Error("failed to move review to team", "reviewID", reviewID, "teamID", teamID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
 	}
 
-	return contributingCauses, nil
+	// The review might not belong to the team being worked as anymore, so go back to the listing
+	setFlash(w, r, Flash{Message: "Moved the review to the other team."})
+	h.Header().Add("Location", "/reviews")
+	h.WriteHeader(http.StatusSeeOther)
 }
 
-func (a *reviewsHandler) loadTriggers(ctx context.Context, h *htmx.Handler) ([]normalized.Trigger, error) {
-	triggers, err := a.triggerStore.All(ctx)
-	if a.hasErrored(h, err, http.StatusInternalServerError, "failed to get all triggers", "error", err) {
-		return nil, err
+func (a *reviewsHandler) BindContributingCause(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for create contributing cause", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var boundCauseForm BoundCauseForm
+	if err := a.decoder.Decode(&boundCauseForm, r.PostForm); err != nil {
+		slog.Error("failed to decode basic contributing cause form", "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString(err.Error())
+		return
+	}
+
+	err = a.service.BindContributingCause(
+		r.Context(),
+		reviewID,
+		boundCauseForm.ContributingCauseID,
+		reviewing.BoundCause{Why: boundCauseForm.Why, IsProximalCause: boundCauseForm.IsProximalCause},
+	)
+	errs, invalid := validate.Fields(err)
+	if err != nil && !invalid {
+		slog.Error("failed to bind contributing cause", "reviewID", reviewID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	if !h.IsHxRequest() {
+		if invalid {
+			a.renderShow(w, r, reviewID, http.StatusUnprocessableEntity, map[string]any{
+				"ContributingCause": BoundCauseBasic{Why: boundCauseForm.Why, IsProximalCause: boundCauseForm.IsProximalCause, Errors: errs},
+				"SelectedCauseID":   boundCauseForm.ContributingCauseID.String(),
+			})
+			return
+		}
+
+		setFlash(w, r, Flash{Message: "Added the contributing cause."})
+		h.Header().Add("Location", "/reviews/"+reviewID.String()+"#contributing-causes")
+		h.WriteHeader(http.StatusSeeOther)
+		return
+	}
+
+	review, err := a.loadReview(w, r, reviewID)
+	if err != nil {
+		return
+	}
+
+	contributingCauses, err := a.loadContributingCauses(w, r)
+	if err != nil {
+		return
+	}
+
+	httpReview, _ := a.toReviewBasic(r.Context(), review)
+	data := map[string]any{
+		"Review":             httpReview,
+		"BoundCauses":        httpReview.BoundCauses,
+		"ContributingCauses": convertContributingCauseToHttpObjects(contributingCauses),
+		"ReviewID":           reviewID,
+		"ContributingCause":  BoundCauseBasic{},
+	}
+	maps.Copy(data, a.suggestions(r.Context(), review, contributingCauses, nil))
+	if invalid {
+		// Show the form again with what they wrote, so they only have to fix what's wrong
+		data["ContributingCause"] = BoundCauseBasic{Why: boundCauseForm.Why, IsProximalCause: boundCauseForm.IsProximalCause, Errors: errs}
+		data["SelectedCauseID"] = boundCauseForm.ContributingCauseID.String()
+		h.WriteHeader(http.StatusUnprocessableEntity)
+	}
+
+	if err := a.pp.Render(w, "reviews/show/_contributing-causes.html", map[string]any{"Data": data}); err != nil {
+		slog.Error("failed to render bind contributing cause", "reviewID", reviewID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *reviewsHandler) EditBoundContributingCause(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for create contributing cause", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	boundCauseID, err := uuid.Parse(r.PathValue("boundCauseID"))
+	if err != nil {
+		slog.Error("failed to parse bound cause id for editing cause", "id", r.PathValue("id"), "boundCauseID", r.PathValue("boundCauseID"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	boundCause, err := a.service.GetBoundContributingCause(r.Context(), reviewID, boundCauseID)
+	if err != nil {
+		slog.Error("failed to get bound contributing cause", "id", reviewID, "boundCauseID", boundCauseID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	allCauses, _ := a.loadContributingCauses(w, r)
+
+	data := map[string]any{
+		"ContributingCauses": convertContributingCauseToHttpObjects(allCauses),
+		"ContributingCause":  toBoundCauseBasic(boundCause),
+		"Editing":            true,
+		"ReviewID":           reviewID,
+		"SelectedCauseID":    boundCause.Cause.ID.String(),
+	}
+
+	if err := renderPartOrPage(w, r, a.pp, "partials/contributing-causes/_form.html", "reviews/edit-contributing-cause.html", data); err != nil {
+		slog.Error("failed to render edit bound contributing cause form", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *reviewsHandler) UpdateBoundContributingCause(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for update contributing cause", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	boundCauseID, err := uuid.Parse(r.PathValue("boundCauseID"))
+	if err != nil {
+		slog.Error("failed to parse bound cause id for updating cause", "id", r.PathValue("id"), "boundCauseID", r.PathValue("boundCauseID"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var updatedCause BoundCauseForm
+	if err := a.decoder.Decode(&updatedCause, r.PostForm); err != nil {
+		slog.Error("failed to decode request form for updating contributing cause", "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString(err.Error())
+		return
+	}
+
+	boundCause, err := a.service.UpdateBoundContributingCause(r.Context(), reviewID, reviewing.BoundCause{
+		ID:              boundCauseID,
+		Why:             updatedCause.Why,
+		IsProximalCause: updatedCause.IsProximalCause,
+		Cause:           contributing.Cause{ID: updatedCause.ContributingCauseID},
+	})
+	if errs, ok := validate.Fields(err); ok {
+		allCauses, err := a.loadContributingCauses(w, r)
+		if err != nil {
+			return
+		}
+
+		data := map[string]any{
+			"ContributingCauses": convertContributingCauseToHttpObjects(allCauses),
+			"ContributingCause":  BoundCauseBasic{ID: boundCauseID, Why: updatedCause.Why, IsProximalCause: updatedCause.IsProximalCause, Errors: errs},
+			"Editing":            true,
+			"ReviewID":           reviewID,
+			"SelectedCauseID":    updatedCause.ContributingCauseID.String(),
+		}
+		h.WriteHeader(http.StatusUnprocessableEntity)
+		if err := renderPartOrPage(w, r, a.pp, "partials/contributing-causes/_form.html", "reviews/edit-contributing-cause.html", data); err != nil {
+			slog.Error("failed to render edit bound contributing cause form with errors", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
+		}
+		return
+	}
+	if err != nil {
+		slog.Error("failed to update bound contributing cause", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	if !h.IsHxRequest() {
+		setFlash(w, r, Flash{Message: "Saved the contributing cause."})
+		h.Header().Add("Location", "/reviews/"+reviewID.String()+"#contributing-causes")
+		h.WriteHeader(http.StatusSeeOther)
+		return
+	}
+
+	httpCause := toBoundCauseBasic(boundCause)
+	httpCause.Comments = a.loadComments(r.Context(), reviewID, reviewing.CommentSubject{Kind: reviewing.CommentOnBoundCause, ID: boundCauseID})
+	httpCause.Attachments = a.loadAttachments(r.Context(), reviewID, boundCauseID)
+	data := map[string]any{
+		"ReviewID":          reviewID,
+		"ContributingCause": httpCause,
+	}
+
+	if err := a.pp.Render(w, "reviews/show/_contributing-cause-bound-li.html", map[string]any{"Data": data}); err != nil {
+		slog.Error("failed to render after updating bound contributing cause", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *reviewsHandler) VoteOnBoundContributingCause(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for voting on contributing cause", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	boundCauseID, err := uuid.Parse(r.PathValue("boundCauseID"))
+	if err != nil {
+		slog.Error("failed to parse bound cause id for voting on cause", "id", r.PathValue("id"), "boundCauseID", r.PathValue("boundCauseID"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var voteForm VoteForm
+	if err := a.decoder.Decode(&voteForm, r.PostForm); err != nil {
+		slog.Error("failed to decode vote form for contributing cause", "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString(err.Error())
+		return
+	}
+
+	boundCause, err := a.service.VoteOnBoundContributingCause(r.Context(), reviewID, boundCauseID, reviewing.Vote{
+		Direction: reviewing.VoteDirection(voteForm.Direction),
+		Reason:    voteForm.Reason,
+	})
+	if err != nil {
+		slog.Error("failed to vote on bound contributing cause", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	// Voting works as a plain form post as well, so send those back to where they came from.
+	if !h.IsHxRequest() {
+		setFlash(w, r, Flash{Message: "Counted your vote."})
+		h.Header().Add("Location", "/reviews/"+reviewID.String()+"#contributing-causes")
+		h.WriteHeader(http.StatusSeeOther)
+		return
+	}
+
+	httpCause := toBoundCauseBasic(boundCause)
+	httpCause.Comments = a.loadComments(r.Context(), reviewID, reviewing.CommentSubject{Kind: reviewing.CommentOnBoundCause, ID: boundCauseID})
+	httpCause.Attachments = a.loadAttachments(r.Context(), reviewID, boundCauseID)
+	data := map[string]any{
+		"ReviewID":          reviewID,
+		"ContributingCause": httpCause,
+	}
+
+	if err := a.pp.Render(w, "partials/contributing-causes/_bound-li.html", data); err != nil {
+		slog.Error("failed to render after voting on bound contributing cause", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *reviewsHandler) VoteOnBoundTrigger(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for voting on trigger", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	boundTriggerID, err := uuid.Parse(r.PathValue("boundTriggerID"))
+	if err != nil {
+		slog.Error("failed to parse bound trigger id for voting on trigger", "id", r.PathValue("id"), "boundTriggerID", r.PathValue("boundTriggerID"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var voteForm VoteForm
+	if err := a.decoder.Decode(&voteForm, r.PostForm); err != nil {
+		slog.Error("failed to decode vote form for trigger", "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString(err.Error())
+		return
+	}
+
+	boundTrigger, err := a.service.VoteOnBoundTrigger(r.Context(), reviewID, boundTriggerID, reviewing.Vote{
+		Direction: reviewing.VoteDirection(voteForm.Direction),
+		Reason:    voteForm.Reason,
+	})
+	if err != nil {
+		slog.Error("failed to vote on bound trigger", "reviewID", reviewID, "boundTriggerID", boundTriggerID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	if !h.IsHxRequest() {
+		setFlash(w, r, Flash{Message: "Counted your vote."})
+		h.Header().Add("Location", "/reviews/"+reviewID.String()+"#triggers")
+		h.WriteHeader(http.StatusSeeOther)
+		return
+	}
+
+	httpTrigger := toBoundTriggerBasic(boundTrigger)
+	httpTrigger.Comments = a.loadComments(r.Context(), reviewID, reviewing.CommentSubject{Kind: reviewing.CommentOnBoundTrigger, ID: boundTriggerID})
+	data := map[string]any{
+		"ReviewID": reviewID,
+		"Trigger":  httpTrigger,
+	}
+
+	if err := a.pp.Render(w, "partials/triggers/_bound-li.html", data); err != nil {
+		slog.Error("failed to render after voting on bound trigger", "reviewID", reviewID, "boundTriggerID", boundTriggerID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *reviewsHandler) AddComment(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for adding comment", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var commentForm CommentForm
+	if err := a.decoder.Decode(&commentForm, r.PostForm); err != nil {
+		slog.Error("failed to decode comment form", "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString(err.Error())
+		return
+	}
+
+	comment, err := a.service.AddComment(r.Context(), reviewID, reviewing.Comment{
+		ParentID: commentForm.ParentID,
+		Subject:  reviewing.CommentSubject{Kind: reviewing.CommentSubjectKind(commentForm.SubjectKind), ID: commentForm.SubjectID},
+		Body:     commentForm.Body,
+	})
+	if err != nil {
+		slog.Error("failed to add comment", "reviewID", reviewID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	a.renderComments(w, r, h, reviewID, comment.Subject, "Added your comment.")
+}
+
+func (a *reviewsHandler) EditComment(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for editing comment", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	commentID, err := uuid.Parse(r.PathValue("commentID"))
+	if err != nil {
+		slog.Error("failed to parse comment id for editing comment", "id", r.PathValue("id"), "commentID", r.PathValue("commentID"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	comment, err := a.service.EditComment(r.Context(), reviewID, commentID, r.PostForm.Get("body"))
+	if err != nil {
+		slog.Error("failed to edit comment", "reviewID", reviewID, "commentID", commentID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	a.renderComments(w, r, h, reviewID, comment.Subject, "Saved your comment.")
+}
+
+func (a *reviewsHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for deleting comment", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	commentID, err := uuid.Parse(r.PathValue("commentID"))
+	if err != nil {
+		slog.Error("failed to parse comment id for deleting comment", "id", r.PathValue("id"), "commentID", r.PathValue("commentID"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	comment, err := a.service.DeleteComment(r.Context(), reviewID, commentID)
+	if err != nil {
+		slog.Error("failed to delete comment", "reviewID", reviewID, "commentID", commentID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	a.renderComments(w, r, h, reviewID, comment.Subject, "Deleted the comment.")
+}
+
+// renderComments renders the discussion the subject is part of after it's been changed,
+// which is the section with everything on removed items if the subject is no longer on the review.
+// Plain form posts are sent back to the review with done as the flash instead.
+func (a *reviewsHandler) renderComments(w http.ResponseWriter, r *http.Request, h *htmx.Handler, reviewID uuid.UUID, subject reviewing.CommentSubject, done string) {
+	if !h.IsHxRequest() {
+		setFlash(w, r, Flash{Message: done})
+		h.Header().Add("Location", "/reviews/"+reviewID.String())
+		h.WriteHeader(http.StatusSeeOther)
+		return
+	}
+
+	review, err := a.loadReview(w, r, reviewID)
+	if err != nil {
+		return
 	}
 
-	return triggers, nil
+	data := map[string]any{
+		"ReviewID":    reviewID,
+		"SubjectKind": string(subject.Kind),
+		"SubjectID":   subject.ID.String(),
+		"Comments":    a.toCommentBasics(r.Context(), review, review.CommentThreads(subject)),
+	}
+	if !review.HasCommentSubject(subject) {
+		data["SubjectID"] = "removed"
+		data["Comments"] = a.toCommentBasics(r.Context(), review, review.CommentThreadsOnRemovedItems())
+		data["Removed"] = true
+	}
+
+	if err := a.pp.Render(w, "partials/comments/_section.html", data); err != nil {
+		slog.Error("failed to render comments", "reviewID", reviewID, "subject", subject, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 }
 
-func (a *reviewsHandler) BindTrigger(w http.ResponseWriter, r *http.Request) {
+// loadComments gets the discussion about a single item on the review.
+func (a *reviewsHandler) loadComments(ctx context.Context, reviewID uuid.UUID, subject reviewing.CommentSubject) []CommentBasic {
+	review, err := a.service.Get(ctx, reviewID)
+	if err != nil {
+		// Only log the error since showing the item without its discussion is an okay fallback
+		slog.Error("failed to load comments", "reviewID", reviewID, "subject", subject, "error", err)
+		return nil
+	}
+
+	return a.toCommentBasics(ctx, review, review.CommentThreads(subject))
+}
+
+// toCommentBasics is the threads with who wrote each comment and what the current actor can do with it.
+func (a *reviewsHandler) toCommentBasics(ctx context.Context, review reviewing.Review, threads []reviewing.CommentThread) []CommentBasic {
+	users, err := a.users.All(ctx)
+	if err != nil {
+		// The names are only for display, so fall back to showing the ids
+		slog.Error("failed to fetch users for comment authors", "reviewID", review.ID, "error", err)
+	}
+	names := make(map[uuid.UUID]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Name
+	}
+
+	current, _ := actor.From(ctx)
+	ret := toCommentBasics(threads)
+	describeComments(ret, review, current, names)
+
+	return ret
+}
+
+// loadAttachments gets what's attached to a bound cause on the review.
+func (a *reviewsHandler) loadAttachments(ctx context.Context, reviewID uuid.UUID, boundCauseID uuid.UUID) []AttachmentBasic {
+	review, err := a.service.Get(ctx, reviewID)
+	if err != nil {
+		// Only log the error since showing the item without its attachments is an okay fallback
+		slog.Error("failed to load attachments", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
+		return nil
+	}
+
+	return toAttachmentBasics(review.AttachmentsOf(boundCauseID))
+}
+
+// maxUploadSize stops reading uploads that are far too large to be attached, so they don't fill the disk
+// while being parsed. What can be attached is decided by reviewing.AttachmentLimits.
+const maxUploadSize = 64 << 20
+
+func (a *reviewsHandler) Attach(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for attaching", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		slog.Error("failed to read attached file", "reviewID", reviewID, "error", err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.WriteHeader(http.StatusRequestEntityTooLarge)
+			h.JustWriteString("the file is too large")
+			return
+		}
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("no file to attach")
+		return
+	}
+	defer (func() { _ = file.Close() })()
+
+	boundCauseID := uuid.Nil
+	if id := r.PostForm.Get("boundCauseID"); id != "" {
+		if boundCauseID, err = uuid.Parse(id); err != nil {
+			slog.Error("failed to parse bound cause id for attaching", "id", r.PathValue("id"), "boundCauseID", id, "error", err)
+			h.WriteHeader(http.StatusBadRequest)
+			h.JustWriteString("invalid id")
+			return
+		}
+	}
+
+	attachment, err := a.service.Attach(r.Context(), reviewID, reviewing.Attachment{
+		BoundCauseID: boundCauseID,
+		Name:         filepath.Base(header.Filename),
+	}, file)
+	if err != nil {
+		slog.Error("failed to attach file", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	a.renderAttachments(w, r, h, reviewID, boundCauseID, "Attached "+attachment.Name+".")
+}
+
+// OpenAttachment sends the file, or its thumbnail, to be shown by the browser when it's an image and downloaded otherwise.
+// The files are uploaded by people, so they're never run as a page on this site.
+func (a *reviewsHandler) OpenAttachment(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for opening attachment", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	attachmentID, err := uuid.Parse(r.PathValue("attachmentID"))
+	if err != nil {
+		slog.Error("failed to parse attachment id for opening attachment", "id", r.PathValue("id"), "attachmentID", r.PathValue("attachmentID"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	thumbnail := strings.HasSuffix(r.URL.Path, "/thumbnail")
+	attachment, contents, err := a.service.OpenAttachment(r.Context(), reviewID, attachmentID, thumbnail)
+	if err != nil {
+		slog.Error("failed to open attachment", "reviewID", reviewID, "attachmentID", attachmentID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+	defer (func() { _ = contents.Close() })()
+
+	contentType, disposition := attachment.ContentType, "attachment"
+	if thumbnail {
+		contentType = "image/png"
+	}
+	if attachment.IsImage() {
+		disposition = "inline"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	w.Header().Set("Cache-Control", "private")
+	if _, err := io.Copy(w, contents); err != nil {
+		slog.Error("failed to send attachment", "reviewID", reviewID, "attachmentID", attachmentID, "error", err)
+	}
+}
+
+func (a *reviewsHandler) RemoveAttachment(w http.ResponseWriter, r *http.Request) {
 	h := a.htmx.NewHandler(w, r)
 
+	reviewID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for removing attachment", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	attachmentID, err := uuid.Parse(r.PathValue("attachmentID"))
+	if err != nil {
+		slog.Error("failed to parse attachment id for removing attachment", "id", r.PathValue("id"), "attachmentID", r.PathValue("attachmentID"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	attachment, err := a.service.RemoveAttachment(r.Context(), reviewID, attachmentID)
+	if err != nil {
+		slog.Error("failed to remove attachment", "reviewID", reviewID, "attachmentID", attachmentID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	a.renderAttachments(w, r, h, reviewID, attachment.BoundCauseID, "Removed "+attachment.Name+".")
+}
+
+// renderAttachments renders what's attached to the review, or the bound cause, after it's been changed.
+// Plain form posts are sent back to the attachments on the review with done as the flash instead.
+func (a *reviewsHandler) renderAttachments(w http.ResponseWriter, r *http.Request, h *htmx.Handler, reviewID uuid.UUID, boundCauseID uuid.UUID, done string) {
 	if !h.IsHxRequest() {
-		h.WriteHeader(http.StatusNotFound)
-		h.JustWriteString("non-htmx requests not yet supported")
+		setFlash(w, r, Flash{Message: done})
+		h.Header().Add("Location", "/reviews/"+reviewID.String()+attachmentsAnchor(boundCauseID))
+		h.WriteHeader(http.StatusSeeOther)
+		return
+	}
+
+	review, err := a.loadReview(w, r, reviewID)
+	if err != nil {
+		return
+	}
+
+	data := map[string]any{
+		"ReviewID":    reviewID,
+		"Attachments": toAttachmentBasics(review.AttachmentsOf(boundCauseID)),
+	}
+	if boundCauseID != uuid.Nil {
+		data["BoundCauseID"] = boundCauseID
+	}
+
+	if err := a.pp.Render(w, "partials/attachments/_section.html", data); err != nil {
+		slog.Error("failed to render attachments", "reviewID", reviewID, "boundCauseID", boundCauseID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// attachmentsAnchor is where on the review page the attachments of the bound cause are, uuid.Nil for the review's own.
+func attachmentsAnchor(boundCauseID uuid.UUID) string {
+	if boundCauseID == uuid.Nil {
+		return "#attachments"
+	}
+
+	return "#attachments-" + boundCauseID.String()
+}
+
+func (a *reviewsHandler) hasErrored(w http.ResponseWriter, r *http.Request, err error, status int, msg string, args ...any) bool {
+	if err == nil {
+		return false
+	}
+
+	slog.Error(msg, args...)
+	writeError(w, r, a.pp, err, status)
+
+	return true
+}
+
+func (a *reviewsHandler) loadReview(w http.ResponseWriter, r *http.Request, reviewID uuid.UUID) (reviewing.Review, error) {
+	review, err := a.service.Get(r.Context(), reviewID)
+	if a.hasErrored(w, r, err, http.StatusInternalServerError, "failed to get review", "reviewID", reviewID, "error", err) {
+		return reviewing.Review{}, err
+	}
+
+	return review, nil
+}
+
+func (a *reviewsHandler) loadContributingCauses(w http.ResponseWriter, r *http.Request) ([]contributing.Cause, error) {
+	contributingCauses, err := a.causeStore.All(r.Context())
+	if a.hasErrored(w, r, err, http.StatusInternalServerError, "failed to get all contributing causes", "error", err) {
+		return nil, err
+	}
+
+	return contributingCauses, nil
+}
+
+func (a *reviewsHandler) loadTriggers(w http.ResponseWriter, r *http.Request) ([]normalized.Trigger, error) {
+	triggers, err := a.triggerStore.All(r.Context())
+	if a.hasErrored(w, r, err, http.StatusInternalServerError, "failed to get all triggers", "error", err) {
+		return nil, err
 	}
 
+	return triggers, nil
+}
+
+func (a *reviewsHandler) BindTrigger(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
 	reviewID, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
 		slog.Error("failed to parse id for create trigger", "id", r.PathValue("id"), "error", err)
@@ -632,24 +1631,40 @@ func (a *reviewsHandler) BindTrigger(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := a.service.BindTrigger(
+	err = a.service.BindTrigger(
 		r.Context(),
 		reviewID,
 		triggerForm.TriggerID,
 		reviewing.UnboundTrigger{Why: triggerForm.Why},
-	); err != nil {
+	)
+	errs, invalid := validate.Fields(err)
+	if err != nil && !invalid {
 		slog.Error("failed to bind trigger", "reviewID", reviewID, "error", err)
-		h.WriteHeader(http.StatusBadRequest)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	if !h.IsHxRequest() {
+		if invalid {
+			a.renderShow(w, r, reviewID, http.StatusUnprocessableEntity, map[string]any{
+				"BoundTrigger": BoundTriggerBasic{TriggerID: triggerForm.TriggerID, Why: triggerForm.Why, Errors: errs},
+			})
+			return
+		}
+
+		setFlash(w, r, Flash{Message: "Added the trigger."})
+		h.Header().Add("Location", "/reviews/"+reviewID.String()+"#triggers")
+		h.WriteHeader(http.StatusSeeOther)
 		return
 	}
 
-	review, err := a.loadReview(r.Context(), h, reviewID)
+	review, err := a.loadReview(w, r, reviewID)
 	if err != nil {
 		return
 	}
-	httpReview := convertToHttpObject(review)
+	httpReview, _ := a.toReviewBasic(r.Context(), review)
 
-	triggers, err := a.loadTriggers(r.Context(), h)
+	triggers, err := a.loadTriggers(w, r)
 	if err != nil {
 		return
 	}
@@ -661,6 +1676,12 @@ func (a *reviewsHandler) BindTrigger(w http.ResponseWriter, r *http.Request) {
 		"BoundTriggers": httpReview.BoundTriggers,
 		"Triggers":      convertTriggersToHttpObjects(triggers),
 	}
+	maps.Copy(data, a.suggestions(r.Context(), review, nil, triggers))
+	if invalid {
+		// Show the form again with what they wrote, so they only have to fix what's wrong
+		data["BoundTrigger"] = BoundTriggerBasic{TriggerID: triggerForm.TriggerID, Why: triggerForm.Why, Errors: errs}
+		h.WriteHeader(http.StatusUnprocessableEntity)
+	}
 
 	if err := a.pp.Render(w, "reviews/show/_triggers.html", map[string]any{"Data": data}); err != nil {
 		slog.Error("failed to render bind trigger", "reviewID", reviewID, "data", data, "error", err)
@@ -672,11 +1693,6 @@ func (a *reviewsHandler) BindTrigger(w http.ResponseWriter, r *http.Request) {
 func (a *reviewsHandler) EditBoundTrigger(w http.ResponseWriter, r *http.Request) {
 	h := a.htmx.NewHandler(w, r)
 
-	if !h.IsHxRequest() {
-		h.WriteHeader(http.StatusNotFound)
-		h.JustWriteString("non-htmx requests not yet supported")
-	}
-
 	reviewID, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
 		slog.Error("failed to parse id for edit trigger", "id", r.PathValue("id"), "error", err)
@@ -696,23 +1712,23 @@ func (a *reviewsHandler) EditBoundTrigger(w http.ResponseWriter, r *http.Request
 	boundTrigger, err := a.service.GetBoundTrigger(r.Context(), reviewID, boundTriggerID)
 	if err != nil {
 		slog.Error("failed to get bound trigger", "id", reviewID, "boundTriggerID", boundTriggerID, "error", err)
-		h.WriteHeader(http.StatusInternalServerError)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
 		return
 	}
 
-	triggers, err := a.loadTriggers(r.Context(), h)
+	triggers, err := a.loadTriggers(w, r)
 	if err != nil {
 		return
 	}
 
 	data := map[string]any{
-		"BoundTrigger":   toBoundTriggerBasic(boundTrigger),
-		"boundTriggerID": boundTriggerID,
-		"ReviewID":       reviewID,
-		"Triggers":       convertTriggersToHttpObjects(triggers),
+		"BoundTrigger": toBoundTriggerBasic(boundTrigger),
+		"Editing":      true,
+		"ReviewID":     reviewID,
+		"Triggers":     convertTriggersToHttpObjects(triggers),
 	}
 
-	if err := a.pp.Render(w, "partials/triggers/_form.html", map[string]any{"Data": data}); err != nil {
+	if err := renderPartOrPage(w, r, a.pp, "partials/triggers/_form.html", "reviews/edit-trigger.html", data); err != nil {
 		slog.Error("failed to render edit bound trigger form", "reviewID", reviewID, "boundTriggerID", boundTriggerID, "error", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -722,12 +1738,6 @@ func (a *reviewsHandler) EditBoundTrigger(w http.ResponseWriter, r *http.Request
 func (a *reviewsHandler) UpdateBoundTrigger(w http.ResponseWriter, r *http.Request) {
 	h := a.htmx.NewHandler(w, r)
 
-	if !h.IsHxRequest() {
-		h.WriteHeader(http.StatusNotFound)
-		h.JustWriteString("non-htmx requests not yet supported")
-		return
-	}
-
 	reviewID, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
 		slog.Error("failed to parse id for update trigger", "id", r.PathValue("id"), "error", err)
@@ -765,15 +1775,42 @@ func (a *reviewsHandler) UpdateBoundTrigger(w http.ResponseWriter, r *http.Reque
 			Why: updatedTrigger.Why,
 		},
 	})
+	if errs, ok := validate.Fields(err); ok {
+		triggers, err := a.loadTriggers(w, r)
+		if err != nil {
+			return
+		}
+
+		data := map[string]any{
+			"BoundTrigger": BoundTriggerBasic{ID: boundTriggerID, TriggerID: updatedTrigger.TriggerID, Why: updatedTrigger.Why, Errors: errs},
+			"Editing":      true,
+			"ReviewID":     reviewID,
+			"Triggers":     convertTriggersToHttpObjects(triggers),
+		}
+		h.WriteHeader(http.StatusUnprocessableEntity)
+		if err := renderPartOrPage(w, r, a.pp, "partials/triggers/_form.html", "reviews/edit-trigger.html", data); err != nil {
+			slog.Error("failed to render edit bound trigger form with errors", "reviewID", reviewID, "boundTriggerID", boundTriggerID, "error", err)
+		}
+		return
+	}
 	if err != nil {
 		slog.Error("failed to update bound trigger", "reviewID", reviewID, "boundTriggerID", boundTriggerID, "error", err)
-		h.WriteHeader(http.StatusInternalServerError)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
 		return
 	}
 
+	if !h.IsHxRequest() {
+		setFlash(w, r, Flash{Message: "Saved the trigger."})
+		h.Header().Add("Location", "/reviews/"+reviewID.String()+"#triggers")
+		h.WriteHeader(http.StatusSeeOther)
+		return
+	}
+
+	httpTrigger := toBoundTriggerBasic(boundTrigger)
+	httpTrigger.Comments = a.loadComments(r.Context(), reviewID, reviewing.CommentSubject{Kind: reviewing.CommentOnBoundTrigger, ID: boundTriggerID})
 	data := map[string]any{
 		"ReviewID": reviewID,
-		"Trigger":  toBoundTriggerBasic(boundTrigger),
+		"Trigger":  httpTrigger,
 	}
 
 	if err := a.pp.Render(w, "partials/triggers/_bound-li.html", data); err != nil {
@@ -796,12 +1833,32 @@ func convertToHttpObjects(rs []reviewing.Review) []ReviewBasic {
 func convertToHttpObject(r reviewing.Review) ReviewBasic {
 	causes := make([]BoundCauseBasic, 0, len(r.BoundCauses))
 	for _, cause := range r.BoundCauses {
-		causes = append(causes, toBoundCauseBasic(cause))
+		c := toBoundCauseBasic(cause)
+		c.Comments = toCommentBasics(r.CommentThreads(reviewing.CommentSubject{Kind: reviewing.CommentOnBoundCause, ID: cause.ID}))
+		c.Attachments = toAttachmentBasics(r.AttachmentsOf(cause.ID))
+		causes = append(causes, c)
 	}
 
 	triggers := make([]BoundTriggerBasic, 0, len(r.BoundTriggers))
 	for _, trigger := range r.BoundTriggers {
-		triggers = append(triggers, toBoundTriggerBasic(trigger))
+		t := toBoundTriggerBasic(trigger)
+		t.Comments = toCommentBasics(r.CommentThreads(reviewing.CommentSubject{Kind: reviewing.CommentOnBoundTrigger, ID: trigger.ID}))
+		triggers = append(triggers, t)
+	}
+
+	nextStates := make([]StateBasic, 0, len(r.NextStates()))
+	for _, state := range r.NextStates() {
+		next := toStateBasic(state)
+		// Moving back to in review is the only way of changing a published review, so call it what it is
+		if r.State == reviewing.StatePublished && state == reviewing.StateInReview {
+			next.Label = "Reopen"
+		}
+		nextStates = append(nextStates, next)
+	}
+
+	transitions := make([]TransitionBasic, 0, len(r.Transitions))
+	for _, t := range r.Transitions {
+		transitions = append(transitions, TransitionBasic{From: toStateBasic(t.From), To: toStateBasic(t.To), At: t.At})
 	}
 
 	return ReviewBasic{
@@ -814,29 +1871,217 @@ func convertToHttpObject(r reviewing.Review) ReviewBasic {
 		ReportProximalCause: r.ReportProximalCause,
 		ReportTrigger:       r.ReportTrigger,
 
-		BoundCauses:   causes,
-		BoundTriggers: triggers,
+		TemplateID: r.TemplateID,
+		Guidance:   r.Guidance,
+		Answers:    toAnswerBasics(r.Answers),
+
+		TeamID:      r.TeamID,
+		State:       toStateBasic(r.State),
+		ReadOnly:    r.IsReadOnly(),
+		NextStates:  nextStates,
+		Transitions: transitions,
+
+		BoundCauses:            causes,
+		BoundTriggers:          triggers,
+		Comments:               toCommentBasics(r.CommentThreads(reviewing.CommentSubject{Kind: reviewing.CommentOnReview, ID: r.ID})),
+		CommentsOnRemovedItems: toCommentBasics(r.CommentThreadsOnRemovedItems()),
+		Attachments:            toAttachmentBasics(r.AttachmentsOf(uuid.Nil)),
 
 		CreatedAt: r.CreatedAt,
 		UpdatedAt: r.UpdatedAt,
+
+		IncidentStartedAt:  r.IncidentStartedAt,
+		IncidentResolvedAt: r.IncidentResolvedAt,
+	}
+}
+
+// toReviewBasic is convertToHttpObject with what the current actor can do on the review and who is part of it,
+// it also returns everyone who could be made part of the review when the actor is able to.
+func (a *reviewsHandler) toReviewBasic(ctx context.Context, review reviewing.Review) (ReviewBasic, []MemberBasic) {
+	ret := convertToHttpObject(review)
+
+	current, _ := actor.From(ctx)
+	ret.CanEdit = !ret.ReadOnly && review.Allows(current, reviewing.PermissionContribute)
+	ret.CanFacilitate = review.Allows(current, reviewing.PermissionFacilitate)
+
+	users, err := a.users.All(ctx)
+	if err != nil {
+		// The names are only for display, so fall back to showing the ids
+		slog.Error("failed to fetch users for review members", "reviewID", review.ID, "error", err)
+	}
+	names := make(map[uuid.UUID]string, len(users))
+	candidates := make([]MemberBasic, 0, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Name
+		if u.Role.CanContribute() {
+			candidates = append(candidates, MemberBasic{ID: u.ID, Name: u.Name})
+		}
+	}
+
+	ret.Facilitators = toMemberBasics(review.Facilitators, names)
+	ret.Participants = toMemberBasics(review.Participants, names)
+	describeComments(ret.Comments, review, current, names)
+	describeComments(ret.CommentsOnRemovedItems, review, current, names)
+	for _, c := range ret.BoundCauses {
+		describeComments(c.Comments, review, current, names)
+	}
+	for _, t := range ret.BoundTriggers {
+		describeComments(t.Comments, review, current, names)
+	}
+	if !ret.CanFacilitate {
+		candidates = nil
+	}
+
+	return ret, candidates
+}
+
+func toAnswerBasics(answers []reviewing.Answer) []AnswerBasic {
+	ret := make([]AnswerBasic, 0, len(answers))
+	for _, a := range answers {
+		ret = append(ret, AnswerBasic{Field: a.Field, Prompt: a.Prompt, Text: a.Text})
+	}
+
+	return ret
+}
+
+func toMemberBasics(ids []uuid.UUID, names map[uuid.UUID]string) []MemberBasic {
+	ret := make([]MemberBasic, 0, len(ids))
+	for _, id := range ids {
+		name, ok := names[id]
+		if !ok {
+			name = id.String()
+		}
+		ret = append(ret, MemberBasic{ID: id, Name: name})
+	}
+
+	return ret
+}
+
+var stateLabels = map[reviewing.State]string{
+	reviewing.StateDraft:            "Draft",
+	reviewing.StateInReview:         "In review",
+	reviewing.StateAwaitingApproval: "Awaiting approval",
+	reviewing.StatePublished:        "Published",
+	reviewing.StateArchived:         "Archived",
+}
+
+func toStateBasic(s reviewing.State) StateBasic {
+	return StateBasic{Value: string(s), Label: stateLabels[s]}
+}
+
+func toStateBasics(states []reviewing.State) []StateBasic {
+	ret := make([]StateBasic, 0, len(states))
+	for _, s := range states {
+		ret = append(ret, toStateBasic(s))
+	}
+
+	return ret
+}
+
+func toChecklistBasic(c reviewing.Checklist) []ChecklistItemBasic {
+	ret := make([]ChecklistItemBasic, 0, len(c))
+	for _, item := range c {
+		ret = append(ret, ChecklistItemBasic{Description: item.Rule.Describe(), Passed: item.Passed})
 	}
+
+	return ret
 }
 
 func toBoundCauseBasic(cause reviewing.BoundCause) BoundCauseBasic {
 	return BoundCauseBasic{
 		ID:              cause.ID,
+		CauseID:         cause.Cause.ID,
 		Name:            cause.Cause.Name,
 		Why:             cause.Why,
 		Category:        cause.Cause.Category,
 		IsProximalCause: cause.IsProximalCause,
+		Tally:           toTallyBasic(cause.Votes.Tally()),
 	}
 }
 
 func toBoundTriggerBasic(trigger reviewing.BoundTrigger) BoundTriggerBasic {
 	return BoundTriggerBasic{
-		ID:   trigger.ID,
-		Name: trigger.Trigger.Name,
-		Why:  trigger.Why,
+		ID:        trigger.ID,
+		TriggerID: trigger.Trigger.ID,
+		Name:      trigger.Trigger.Name,
+		Why:       trigger.Why,
+		Tally:     toTallyBasic(trigger.Votes.Tally()),
+	}
+}
+
+func toCommentBasics(threads []reviewing.CommentThread) []CommentBasic {
+	ret := make([]CommentBasic, 0, len(threads))
+	for _, t := range threads {
+		ret = append(ret, CommentBasic{
+			ID:          t.ID,
+			SubjectKind: string(t.Subject.Kind),
+			SubjectID:   t.Subject.ID,
+			Author:      MemberBasic{ID: t.AuthorID},
+			Body:        t.Body,
+			IsDeleted:   t.IsDeleted,
+			IsEdited:    !t.UpdatedAt.Equal(t.CreatedAt),
+			Replies:     toCommentBasics(t.Replies),
+			CreatedAt:   t.CreatedAt,
+		})
+	}
+
+	return ret
+}
+
+// describeComments fills in the names of who wrote the comments and whether the current actor can change them.
+func describeComments(comments []CommentBasic, review reviewing.Review, current actor.Actor, names map[uuid.UUID]string) {
+	for i := range comments {
+		c := &comments[i]
+		c.Author = toMemberBasics([]uuid.UUID{c.Author.ID}, names)[0]
+		c.CanChange = !review.IsReadOnly() && review.CanChangeComment(current, reviewing.Comment{AuthorID: c.Author.ID})
+		describeComments(c.Replies, review, current, names)
+	}
+}
+
+func toAttachmentBasics(as []reviewing.Attachment) []AttachmentBasic {
+	ret := make([]AttachmentBasic, 0, len(as))
+	for _, a := range as {
+		ret = append(ret, AttachmentBasic{
+			ID:           a.ID,
+			Name:         a.Name,
+			ContentType:  a.ContentType,
+			Size:         formatSize(a.Size),
+			IsImage:      a.IsImage(),
+			HasThumbnail: a.HasThumbnail,
+		})
+	}
+
+	return ret
+}
+
+// formatSize is the size in the largest unit it's at least one of, like 1.5 MB.
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return strconv.FormatFloat(float64(size)/(1<<20), 'f', 1, 64) + " MB"
+	case size >= 1<<10:
+		return strconv.FormatFloat(float64(size)/(1<<10), 'f', 1, 64) + " KB"
+	default:
+		return strconv.FormatInt(size, 10) + " bytes"
+	}
+}
+
+func toTallyBasic(t reviewing.Tally) TallyBasic {
+	reasons := func(rcs []reviewing.ReasonCount) []ReasonCountBasic {
+		ret := make([]ReasonCountBasic, 0, len(rcs))
+		for _, rc := range rcs {
+			ret = append(ret, ReasonCountBasic{Reason: rc.Reason, Count: rc.Count})
+		}
+
+		return ret
+	}
+
+	return TallyBasic{
+		For:            t.For,
+		Against:        t.Against,
+		ReasonsFor:     reasons(t.ReasonsFor),
+		ReasonsAgainst: reasons(t.ReasonsAgainst),
+		Consensus:      string(t.Consensus()),
 	}
 }
 
@@ -861,7 +2106,7 @@ func convertContributingCauseToHttpObject(cc contributing.Cause) ContributingCau
 
 // fromHttpObject takes all values from rb and assigns them to a new reviewing.Review.
 func fromHttpObject(rb ReviewBasic) reviewing.Review {
-	return reviewing.NewReview().
+	r := reviewing.NewReview().
 		Update(reviewing.Review{
 			URL:                 rb.URL,
 			Title:               rb.Title,
@@ -870,5 +2115,20 @@ func fromHttpObject(rb ReviewBasic) reviewing.Review {
 			Where:               rb.Where,
 			ReportProximalCause: rb.ReportProximalCause,
 			ReportTrigger:       rb.ReportTrigger,
+			CustomValues:        rb.Custom,
 		})
+	// A new review has nothing to answer until a template is applied, so they're set as they are
+	r.Answers = fromAnswerBasics(rb.Answers)
+
+	return r
+}
+
+// fromAnswerBasics only has the texts, since the prompts always come from the template.
+func fromAnswerBasics(abs []AnswerBasic) []reviewing.Answer {
+	ret := make([]reviewing.Answer, 0, len(abs))
+	for _, ab := range abs {
+		ret = append(ret, reviewing.Answer{Field: ab.Field, Text: ab.Text})
+	}
+
+	return ret
 }
@@ -0,0 +1,319 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gaqzi/passepartout"
+	"github.com/gaqzi/passepartout/ppdefaults"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+type reviewTemplateService interface {
+	Save(ctx context.Context, t reviewing.Template) (reviewing.Template, error)
+	Get(ctx context.Context, id uuid.UUID) (reviewing.Template, error)
+	All(ctx context.Context) ([]reviewing.Template, error)
+}
+
+type triggerAller interface {
+	All(ctx context.Context) ([]normalized.Trigger, error)
+}
+
+type reviewTemplatesHandler struct {
+	htmx     *htmx.HTMX
+	service  reviewTemplateService
+	causes   causeAller
+	triggers triggerAller
+	pp       *passepartout.Passepartout
+}
+
+// ReviewTemplatesHandler lets admins manage what the reviews of different kinds of incidents start from.
+func ReviewTemplatesHandler(service reviewTemplateService, causes causeAller, triggers triggerAller) func(chi.Router) {
+	fsys, err := passepartout.FSWithoutPrefix(templates, "templates")
+	if err != nil {
+		panic(err)
+	}
+
+	partials := &ppdefaults.PartialsWithCommon{FS: fsys, CommonDir: "partials"}
+	a := reviewTemplatesHandler{
+		htmx:     htmx.New(),
+		service:  service,
+		causes:   causes,
+		triggers: triggers,
+		pp: passepartout.New(
+			ppdefaults.NewLoaderBuilder().
+				WithDefaults(fsys).
+				TemplateLoader(ppdefaults.NewCachedLoader(&ppdefaults.TemplateByNameLoader{FS: fsys})).
+				PartialsFor(partials.Load).
+				TemplateConfig(baseTemplate()).
+				Build(),
+		),
+	}
+
+	return func(r chi.Router) {
+		r.Get("/", a.Index)
+		r.Post("/", a.Create)
+		r.Get("/{id}", a.Edit)
+		r.Post("/{id}", a.Update)
+	}
+}
+
+// ReviewTemplateForm is a template as it's edited, the fields are one per line as "Name: prompt".
+type ReviewTemplateForm struct {
+	ID       uuid.UUID
+	Name     string
+	Guidance string
+	Fields   string
+	Causes   []OptionBasic
+	Triggers []OptionBasic
+	Errors   validate.FieldErrors
+}
+
+// OptionBasic is an option of a select that can have more than one picked.
+type OptionBasic struct {
+	ID       uuid.UUID
+	Name     string
+	Selected bool
+}
+
+type ReviewTemplateBasic struct {
+	ID     uuid.UUID
+	Name   string
+	Fields []reviewing.TemplateField
+}
+
+func (a *reviewTemplatesHandler) Index(w http.ResponseWriter, r *http.Request) {
+	a.renderIndex(w, r, http.StatusOK, reviewing.Template{}, nil)
+}
+
+func (a *reviewTemplatesHandler) renderIndex(w http.ResponseWriter, r *http.Request, status int, t reviewing.Template, errs validate.FieldErrors) {
+	if err := actor.Require(r.Context(), actor.Admin); err != nil {
+		writeError(w, r, a.pp, fmt.Errorf("only admins can manage review templates: %w", err), http.StatusForbidden)
+		return
+	}
+
+	all, err := a.service.All(r.Context())
+	if err != nil {
+		slog.Error("failed to fetch review templates", "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+	basics := make([]ReviewTemplateBasic, 0, len(all))
+	for _, t := range all {
+		basics = append(basics, ReviewTemplateBasic{ID: t.ID, Name: t.Name, Fields: t.Fields})
+	}
+
+	tpl, err := a.toForm(r.Context(), t, errs)
+	if err != nil {
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+	data := map[string]any{
+		"Templates": basics,
+		"Template":  tpl,
+	}
+
+	w.WriteHeader(status)
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "review-templates/index.html", layoutData(r, data)); err != nil {
+		slog.Error("failed to render page", "page", "review-templates/index", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *reviewTemplatesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	t := fromReviewTemplateForm(reviewing.NewTemplate(), r)
+	saved, err := a.service.Save(r.Context(), t)
+	if errs, ok := validate.Fields(err); ok {
+		a.renderIndex(w, r, http.StatusUnprocessableEntity, t, templateFieldErrors(t, errs))
+		return
+	}
+	if err != nil {
+		slog.Error("failed to save new review template", "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	setFlash(w, r, Flash{Message: "Created the review template.", Link: "/review-templates/" + saved.ID.String(), LinkText: saved.Name})
+	h.Header().Add("Location", "/review-templates")
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+func (a *reviewTemplatesHandler) Edit(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for edit review template", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	t, err := a.service.Get(r.Context(), id)
+	if err != nil {
+		slog.Error("failed to get review template", "id", id, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	a.renderEdit(w, r, http.StatusOK, t, nil)
+}
+
+func (a *reviewTemplatesHandler) renderEdit(w http.ResponseWriter, r *http.Request, status int, t reviewing.Template, errs validate.FieldErrors) {
+	if err := actor.Require(r.Context(), actor.Admin); err != nil {
+		writeError(w, r, a.pp, fmt.Errorf("only admins can manage review templates: %w", err), http.StatusForbidden)
+		return
+	}
+
+	tpl, err := a.toForm(r.Context(), t, errs)
+	if err != nil {
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "review-templates/edit.html", layoutData(r, map[string]any{"Template": tpl})); err != nil {
+		slog.Error("failed to render page", "page", "review-templates/edit", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *reviewTemplatesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for update review template", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	stored, err := a.service.Get(r.Context(), id)
+	if err != nil {
+		slog.Error("failed to get review template", "id", id, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	t := fromReviewTemplateForm(stored, r)
+	_, err = a.service.Save(r.Context(), t)
+	if errs, ok := validate.Fields(err); ok {
+		a.renderEdit(w, r, http.StatusUnprocessableEntity, t, templateFieldErrors(t, errs))
+		return
+	}
+	if err != nil {
+		slog.Error("failed to save review template", "id", id, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	setFlash(w, r, Flash{Message: "Saved the review template.", Link: "/review-templates/" + id.String(), LinkText: t.Name})
+	h.Header().Add("Location", "/review-templates")
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+// toForm has the template as it's edited, with every cause and trigger to pick the suggestions from.
+func (a *reviewTemplatesHandler) toForm(ctx context.Context, t reviewing.Template, errs validate.FieldErrors) (ReviewTemplateForm, error) {
+	causes, err := a.causes.All(ctx)
+	if err != nil {
+		slog.Error("failed to fetch contributing causes", "error", err)
+		return ReviewTemplateForm{}, err
+	}
+	triggers, err := a.triggers.All(ctx)
+	if err != nil {
+		slog.Error("failed to fetch triggers", "error", err)
+		return ReviewTemplateForm{}, err
+	}
+
+	lines := make([]string, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		lines = append(lines, strings.TrimSuffix(f.Name+": "+f.Prompt, ": "))
+	}
+	ret := ReviewTemplateForm{
+		ID:       t.ID,
+		Name:     t.Name,
+		Guidance: t.Guidance,
+		Fields:   strings.Join(lines, "\n"),
+		Causes:   make([]OptionBasic, 0, len(causes)),
+		Triggers: make([]OptionBasic, 0, len(triggers)),
+		Errors:   errs,
+	}
+	for _, c := range causes {
+		ret.Causes = append(ret.Causes, OptionBasic{ID: c.ID, Name: c.Name, Selected: slices.Contains(t.SuggestedCauseIDs, c.ID)})
+	}
+	for _, tr := range triggers {
+		ret.Triggers = append(ret.Triggers, OptionBasic{ID: tr.ID, Name: tr.Name, Selected: slices.Contains(t.SuggestedTriggerIDs, tr.ID)})
+	}
+
+	return ret, nil
+}
+
+// fromReviewTemplateForm sets what was posted on the template, the fields are one per line as "Name: prompt".
+func fromReviewTemplateForm(t reviewing.Template, r *http.Request) reviewing.Template {
+	t.Name = r.PostForm.Get("name")
+	t.Guidance = r.PostForm.Get("guidance")
+
+	t.Fields = nil
+	for _, line := range strings.Split(r.PostForm.Get("fields"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		name, prompt, _ := strings.Cut(line, ":")
+		t.Fields = append(t.Fields, reviewing.TemplateField{Name: strings.TrimSpace(name), Prompt: strings.TrimSpace(prompt)})
+	}
+
+	t.SuggestedCauseIDs = parseIDs(r.PostForm["suggestedCauseIDs"])
+	t.SuggestedTriggerIDs = parseIDs(r.PostForm["suggestedTriggerIDs"])
+
+	return t
+}
+
+// templateFieldErrors shows a field without a name next to the fields, since it's reported as the name of the template.
+func templateFieldErrors(t reviewing.Template, errs validate.FieldErrors) validate.FieldErrors {
+	if _, ok := errs["Name"]; ok && t.Name != "" {
+		delete(errs, "Name")
+		errs["Fields"] = "Every field needs a name before the colon."
+	}
+
+	return errs
+}
+
+// parseIDs skips what isn't an id, it's only from the options of a select.
+func parseIDs(vals []string) []uuid.UUID {
+	ret := make([]uuid.UUID, 0, len(vals))
+	for _, v := range vals {
+		if id, err := uuid.Parse(v); err == nil {
+			ret = append(ret, id)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,319 @@
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gaqzi/passepartout"
+	"github.com/gaqzi/passepartout/ppdefaults"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+)
+
+// SessionCookieName is the cookie holding the session token for a signed-in user.
+const SessionCookieName = "session"
+
+type accountsService interface {
+	Login(ctx context.Context, email, password string) (string, error)
+	Logout(ctx context.Context, token string) error
+	Authenticate(ctx context.Context, token string) (accounts.User, error)
+	SignInWithIdentity(ctx context.Context, i accounts.Identity) (string, error)
+}
+
+// ssoProvider is an identity provider people are sent to for signing in.
+type ssoProvider interface {
+	AuthCodeURL(state, nonce string) string
+	Exchange(ctx context.Context, code, nonce string) (accounts.Identity, error)
+}
+
+// SessionsConfig decides how people can sign in.
+type SessionsConfig struct {
+	// SecureCookies should only be turned off when running locally without TLS.
+	SecureCookies bool
+	// LocalLogin allows signing in with an email and password.
+	LocalLogin bool
+	// SSO is the identity provider to sign in through, leave it nil to not use one.
+	SSO ssoProvider
+}
+
+type sessionsHandler struct {
+	htmx    *htmx.HTMX
+	service accountsService
+	pp      *passepartout.Passepartout
+	config  SessionsConfig
+}
+
+// SessionsHandler lets people sign in and out.
+func SessionsHandler(service accountsService, config SessionsConfig) func(chi.Router) {
+	fsys, err := passepartout.FSWithoutPrefix(templates, "templates")
+	if err != nil {
+		panic(err)
+	}
+
+	partials := &ppdefaults.PartialsWithCommon{FS: fsys, CommonDir: "partials"}
+	a := sessionsHandler{
+		htmx:    htmx.New(),
+		service: service,
+		config:  config,
+		pp: passepartout.New(
+			ppdefaults.NewLoaderBuilder().
+				WithDefaults(fsys).
+				TemplateLoader(ppdefaults.NewCachedLoader(&ppdefaults.TemplateByNameLoader{FS: fsys})).
+				PartialsFor(partials.Load).
+				TemplateConfig(baseTemplate()).
+				Build(),
+		),
+	}
+
+	return func(r chi.Router) {
+		r.Get("/login", a.New)
+		if config.LocalLogin {
+			r.Post("/login", a.Create)
+		}
+		if config.SSO != nil {
+			r.Get("/login/oidc", a.StartSSO)
+			r.Get("/login/oidc/callback", a.FinishSSO)
+		}
+		r.Post("/logout", a.Delete)
+	}
+}
+
+func (a *sessionsHandler) New(w http.ResponseWriter, r *http.Request) {
+	a.renderLogin(w, http.StatusOK, map[string]any{"Next": safeRedirect(r.URL.Query().Get("next"))})
+}
+
+func (a *sessionsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	email := r.PostForm.Get("email")
+	next := safeRedirect(r.PostForm.Get("next"))
+
+	token, err := a.service.Login(r.Context(), email, r.PostForm.Get("password"))
+	if err != nil {
+		if !errors.Is(err, accounts.ErrInvalidCredentials) {
+			slog.Error("failed to log in", "error", err)
+		}
+
+		a.renderLogin(w, http.StatusUnauthorized, map[string]any{
+			"Next":  next,
+			"Email": email,
+			"Error": "The email or password is incorrect.",
+		})
+		return
+	}
+
+	http.SetCookie(w, a.sessionCookie(token, 0))
+	h.Header().Add("Location", next)
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+// ssoStateCookieName holds what's needed to finish signing in when the identity provider sends people back.
+const ssoStateCookieName = "sso_state"
+
+// StartSSO sends people to the identity provider to sign in.
+func (a *sessionsHandler) StartSSO(w http.ResponseWriter, r *http.Request) {
+	state := url.Values{
+		"state": {rand.Text()},
+		"nonce": {rand.Text()},
+		"next":  {safeRedirect(r.URL.Query().Get("next"))},
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookieName,
+		Value:    state.Encode(),
+		Path:     "/login/oidc",
+		MaxAge:   int((10 * time.Minute).Seconds()),
+		HttpOnly: true,
+		Secure:   a.config.SecureCookies,
+		// Lax so the cookie is sent along when the provider redirects back
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, a.config.SSO.AuthCodeURL(state.Get("state"), state.Get("nonce")), http.StatusSeeOther)
+}
+
+// FinishSSO is where the identity provider sends people back to after signing in.
+func (a *sessionsHandler) FinishSSO(w http.ResponseWriter, r *http.Request) {
+	failed := func(status int, msg string, args ...any) {
+		slog.Error(msg, args...)
+		a.renderLogin(w, status, map[string]any{"Error": "Signing in with single sign-on failed, please try again."})
+	}
+
+	cookie, err := r.Cookie(ssoStateCookieName)
+	if err != nil {
+		failed(http.StatusBadRequest, "no sso state cookie when finishing sso", "error", err)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: ssoStateCookieName, Path: "/login/oidc", MaxAge: -1, HttpOnly: true, Secure: a.config.SecureCookies})
+
+	state, err := url.ParseQuery(cookie.Value)
+	if err != nil {
+		failed(http.StatusBadRequest, "failed to parse sso state cookie", "error", err)
+		return
+	}
+
+	q := r.URL.Query()
+	if errCode := q.Get("error"); errCode != "" {
+		failed(http.StatusUnauthorized, "identity provider returned an error", "error", errCode, "description", q.Get("error_description"))
+		return
+	}
+	if q.Get("state") == "" || q.Get("state") != state.Get("state") {
+		failed(http.StatusBadRequest, "sso state doesn't match")
+		return
+	}
+
+	identity, err := a.config.SSO.Exchange(r.Context(), q.Get("code"), state.Get("nonce"))
+	if err != nil {
+		failed(http.StatusUnauthorized, "failed to exchange sso code", "error", err)
+		return
+	}
+
+	token, err := a.service.SignInWithIdentity(r.Context(), identity)
+	if err != nil {
+		failed(http.StatusUnauthorized, "failed to sign in with identity", "error", err, "email", identity.Email)
+		return
+	}
+
+	http.SetCookie(w, a.sessionCookie(token, 0))
+	http.Redirect(w, r, safeRedirect(state.Get("next")), http.StatusSeeOther)
+}
+
+func (a *sessionsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	if cookie, err := r.Cookie(SessionCookieName); err == nil {
+		if err := a.service.Logout(r.Context(), cookie.Value); err != nil {
+			slog.Error("failed to log out", "error", err)
+		}
+	}
+
+	http.SetCookie(w, a.sessionCookie("", -1))
+	h.Header().Add("Location", "/login")
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+func (a *sessionsHandler) renderLogin(w http.ResponseWriter, status int, data map[string]any) {
+	if _, ok := data["Next"]; !ok {
+		data["Next"] = "/reviews"
+	}
+	data["LocalLogin"] = a.config.LocalLogin
+	data["SSO"] = a.config.SSO != nil
+
+	w.WriteHeader(status)
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "sessions/new.html", map[string]any{"Data": data}); err != nil {
+		slog.Error("failed to render page", "page", "sessions/new", "error", err)
+		return
+	}
+}
+
+// sessionCookie with a negative maxAge removes the cookie, and zero makes it last until the browser closes.
+// The session itself expires on the server regardless.
+func (a *sessionsHandler) sessionCookie(token string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   a.config.SecureCookies,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// safeRedirect only allows redirecting within the app, so the login page can't be used to send people elsewhere.
+func safeRedirect(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/reviews"
+	}
+
+	return next
+}
+
+// Authenticate puts the signed-in user in the request context when there's a valid session.
+// It never rejects a request, use RequireUser for the pages that need someone signed in.
+func Authenticate(service accountsService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie(SessionCookieName)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			u, err := service.Authenticate(r.Context(), cookie.Value)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := accounts.WithUser(r.Context(), u)
+			ctx = actor.With(ctx, u.Actor())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireUser sends anyone not signed in to the login page and back to where they were going afterward.
+func RequireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := accounts.UserFrom(r.Context()); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		loginURL := "/login?next=" + url.QueryEscape(r.URL.RequestURI())
+		if r.Header.Get("HX-Request") == "true" {
+			// The request is for part of a page, so come back to the page itself after signing in
+			if current, err := url.Parse(r.Header.Get("HX-Current-URL")); err == nil && current.Path != "" {
+				loginURL = "/login?next=" + url.QueryEscape(current.RequestURI())
+			}
+
+			// A redirect would be followed by htmx and swapped into the page, so have it navigate instead.
+			w.Header().Set("HX-Redirect", loginURL)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		http.Redirect(w, r, loginURL, http.StatusSeeOther)
+	})
+}
+
+// currentUser is what the layout shows for the signed-in user, nil when nobody is.
+func currentUser(r *http.Request) map[string]any {
+	u, ok := accounts.UserFrom(r.Context())
+	if !ok {
+		return nil
+	}
+
+	ret := map[string]any{
+		"ID":        u.ID,
+		"Name":      u.Name,
+		"Email":     u.Email,
+		"Role":      u.Role,
+		"IsAdmin":   u.Role == actor.RoleAdmin,
+		"IsCurator": u.Role.IsCurator(),
+		// HasPassword is whether they can change their password, people only signing in through SSO can't
+		"HasPassword": u.HasPassword(),
+	}
+	if choice, ok := teamChoiceFrom(r.Context()); ok {
+		ret["Team"] = choice.Current
+		ret["Teams"] = choice.Options
+		ret["AllowNoTeam"] = choice.AllowNone
+	}
+
+	return ret
+}
@@ -0,0 +1,292 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gaqzi/passepartout"
+	"github.com/gaqzi/passepartout/ppdefaults"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/teams"
+)
+
+// TeamCookieName is the cookie holding the team someone has chosen to work as.
+const TeamCookieName = "team"
+
+type teamsService interface {
+	Save(ctx context.Context, t teams.Team) (teams.Team, error)
+	All(ctx context.Context) ([]teams.Team, error)
+	ForMember(ctx context.Context, userID uuid.UUID) ([]teams.Team, error)
+	AddMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID) (teams.Team, error)
+	RemoveMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID) (teams.Team, error)
+}
+
+// teamChoice is which team someone is working as and which others they could choose.
+type teamChoice struct {
+	Current teams.Team
+	Options []teams.Team
+	// AllowNone is for admins, who can also work without a team to see what doesn't belong to any.
+	AllowNone bool
+}
+
+type teamChoiceKey struct{}
+
+func teamChoiceFrom(ctx context.Context) (teamChoice, bool) {
+	c, ok := ctx.Value(teamChoiceKey{}).(teamChoice)
+
+	return c, ok
+}
+
+// CurrentTeam decides which team the signed-in user is working as, and with that what they see.
+// It's the team they chose last time if they're still part of it, otherwise their first team.
+// Admins can choose any team, or no team, which is also where everyone not part of a team works.
+func CurrentTeam(service teamsService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u, ok := accounts.UserFrom(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			choice := teamChoice{AllowNone: u.Role == actor.RoleAdmin}
+			var err error
+			if choice.AllowNone {
+				choice.Options, err = service.All(r.Context())
+			} else {
+				choice.Options, err = service.ForMember(r.Context(), u.ID)
+			}
+			if err != nil {
+				// Without knowing the teams it's only safe to continue as no team, which sees the least
+				slog.Error("failed to fetch teams for user", "userID", u.ID, "error", err)
+			}
+
+			chosen := uuid.Nil
+			if cookie, err := r.Cookie(TeamCookieName); err == nil {
+				chosen, _ = uuid.Parse(cookie.Value)
+			}
+			i := slices.IndexFunc(choice.Options, func(t teams.Team) bool { return t.ID == chosen })
+			switch {
+			case i >= 0:
+				choice.Current = choice.Options[i]
+			case !choice.AllowNone && len(choice.Options) > 0:
+				choice.Current = choice.Options[0]
+			}
+
+			ctx := tenant.With(r.Context(), choice.Current.ID)
+			ctx = context.WithValue(ctx, teamChoiceKey{}, choice)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+type teamsHandler struct {
+	htmx          *htmx.HTMX
+	service       teamsService
+	users         userAller
+	pp            *passepartout.Passepartout
+	secureCookies bool
+}
+
+// TeamsHandler lets people choose which team they're working as, and admins manage the teams.
+func TeamsHandler(service teamsService, users userAller, secureCookies bool) func(chi.Router) {
+	fsys, err := passepartout.FSWithoutPrefix(templates, "templates")
+	if err != nil {
+		panic(err)
+	}
+
+	partials := &ppdefaults.PartialsWithCommon{FS: fsys, CommonDir: "partials"}
+	a := teamsHandler{
+		htmx:          htmx.New(),
+		service:       service,
+		users:         users,
+		secureCookies: secureCookies,
+		pp: passepartout.New(
+			ppdefaults.NewLoaderBuilder().
+				WithDefaults(fsys).
+				TemplateLoader(ppdefaults.NewCachedLoader(&ppdefaults.TemplateByNameLoader{FS: fsys})).
+				PartialsFor(partials.Load).
+				TemplateConfig(baseTemplate()).
+				Build(),
+		),
+	}
+
+	return func(r chi.Router) {
+		r.Get("/", a.Index)
+		r.Post("/", a.Create)
+		r.Post("/current", a.Choose)
+		r.Post("/{id}/members", a.AddMember)
+		r.Post("/{id}/members/{userID}/delete", a.RemoveMember)
+	}
+}
+
+type TeamBasic struct {
+	ID      uuid.UUID
+	Name    string
+	Members []MemberBasic
+}
+
+func (a *teamsHandler) Index(w http.ResponseWriter, r *http.Request) {
+	if err := actor.Require(r.Context(), actor.Admin); err != nil {
+		writeError(w, r, a.pp, fmt.Errorf("only admins can manage teams: %w", err), http.StatusForbidden)
+		return
+	}
+
+	all, err := a.service.All(r.Context())
+	if err != nil {
+		slog.Error("failed to fetch teams", "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	users, err := a.users.All(r.Context())
+	if err != nil {
+		slog.Error("failed to fetch users", "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+	names := make(map[uuid.UUID]string, len(users))
+	candidates := make([]MemberBasic, 0, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Name
+		candidates = append(candidates, MemberBasic{ID: u.ID, Name: u.Name})
+	}
+
+	basics := make([]TeamBasic, 0, len(all))
+	for _, t := range all {
+		basics = append(basics, TeamBasic{ID: t.ID, Name: t.Name, Members: toMemberBasics(t.Members, names)})
+	}
+	data := map[string]any{
+		"Teams": basics,
+		"Users": candidates,
+	}
+
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "teams/index.html", layoutData(r, data)); err != nil {
+		slog.Error("failed to render page", "page", "teams/index", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *teamsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	team := teams.NewTeam()
+	team.Name = r.PostForm.Get("name")
+	if _, err := a.service.Save(r.Context(), team); err != nil {
+		slog.Error("failed to save new team", "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	h.Header().Add("Location", "/teams")
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+// Choose remembers which team to work as, CurrentTeam makes sure it's one they're allowed to.
+func (a *teamsHandler) Choose(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     TeamCookieName,
+		Value:    r.PostForm.Get("team"),
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   a.secureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	// What's on a page depends on the team, so go back to the page to see it as the new team
+	next := "/reviews"
+	if ref, err := url.Parse(r.Referer()); err == nil && ref.Path != "" && ref.Host == r.Host {
+		next = ref.RequestURI()
+	}
+	h.Header().Add("Location", next)
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+func (a *teamsHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	teamID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for add team member", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	userID, err := uuid.Parse(r.PostForm.Get("userID"))
+	if err != nil {
+		slog.Error("failed to parse user id for add team member", "userID", r.PostForm.Get("userID"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid user id")
+		return
+	}
+
+	if _, err := a.service.AddMember(r.Context(), teamID, userID); err != nil {
+		slog.Error("failed to add team member", "teamID", teamID, "userID", userID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	h.Header().Add("Location", "/teams")
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+func (a *teamsHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	teamID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for remove team member", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		slog.Error("failed to parse user id for remove team member", "userID", r.PathValue("userID"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid user id")
+		return
+	}
+
+	if _, err := a.service.RemoveMember(r.Context(), teamID, userID); err != nil {
+		slog.Error("failed to remove team member", "teamID", teamID, "userID", userID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	h.Header().Add("Location", "/teams")
+	h.WriteHeader(http.StatusSeeOther)
+}
@@ -1,8 +1,42 @@
 package web
 
-import "embed"
+import (
+	"embed"
+	"fmt"
+	"html/template"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/markdown"
+)
 
 var (
 	//go:embed all:templates/*
 	templates embed.FS
 )
+
+// baseTemplate is what all handlers build their templates from.
+// The partials are loaded for every template, so any function used in a partial has to be available everywhere.
+func baseTemplate() *template.Template {
+	return template.New("").Funcs(map[string]any{
+		"map": func(d map[string]any, args ...any) (map[string]any, error) {
+			if d == nil {
+				d = make(map[string]any, len(args)/2)
+			}
+
+			if oddArgs := len(args)%2 != 0; oddArgs {
+				return nil, fmt.Errorf("did not receive an even key/value pair of arguments for map: %s", args)
+			}
+
+			for i := 0; i < len(args); i += 2 {
+				key, ok := args[i].(string)
+				if !ok {
+					return nil, fmt.Errorf("argument %d is not a string: %q", i, args[i])
+				}
+
+				d[key] = args[i+1]
+			}
+
+			return d, nil
+		},
+		"markdown": markdown.HTML,
+	})
+}
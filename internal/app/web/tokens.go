@@ -0,0 +1,142 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gaqzi/passepartout"
+	"github.com/gaqzi/passepartout/ppdefaults"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+)
+
+type tokensService interface {
+	CreateToken(ctx context.Context, name string, scope accounts.TokenScope) (accounts.Token, string, error)
+	Tokens(ctx context.Context) ([]accounts.Token, error)
+	RevokeToken(ctx context.Context, id uuid.UUID) (accounts.Token, error)
+}
+
+type tokensHandler struct {
+	htmx    *htmx.HTMX
+	service tokensService
+	pp      *passepartout.Passepartout
+}
+
+// TokensHandler lets people create and revoke their own API tokens.
+func TokensHandler(service tokensService) func(chi.Router) {
+	fsys, err := passepartout.FSWithoutPrefix(templates, "templates")
+	if err != nil {
+		panic(err)
+	}
+
+	partials := &ppdefaults.PartialsWithCommon{FS: fsys, CommonDir: "partials"}
+	a := tokensHandler{
+		htmx:    htmx.New(),
+		service: service,
+		pp: passepartout.New(
+			ppdefaults.NewLoaderBuilder().
+				WithDefaults(fsys).
+				TemplateLoader(ppdefaults.NewCachedLoader(&ppdefaults.TemplateByNameLoader{FS: fsys})).
+				PartialsFor(partials.Load).
+				TemplateConfig(baseTemplate()).
+				Build(),
+		),
+	}
+
+	return func(r chi.Router) {
+		r.Get("/", a.Index)
+		r.Post("/", a.Create)
+		r.Post("/{id}/revoke", a.Revoke)
+	}
+}
+
+type TokenBasic struct {
+	ID         uuid.UUID
+	Name       string
+	Scope      string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	Revoked    bool
+}
+
+func (a *tokensHandler) Index(w http.ResponseWriter, r *http.Request) {
+	a.render(w, r, "")
+}
+
+// Create shows the page again with the secret instead of redirecting, since it's the only time it can be shown.
+func (a *tokensHandler) Create(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, secret, err := a.service.CreateToken(r.Context(), r.PostForm.Get("name"), accounts.TokenScope(r.PostForm.Get("scope")))
+	if err != nil {
+		slog.Error("failed to create token", "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	a.render(w, r, secret)
+}
+
+func (a *tokensHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	tokenID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for revoke token", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if _, err := a.service.RevokeToken(r.Context(), tokenID); err != nil {
+		slog.Error("failed to revoke token", "tokenID", tokenID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	h.Header().Add("Location", "/tokens")
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+func (a *tokensHandler) render(w http.ResponseWriter, r *http.Request, secret string) {
+	tokens, err := a.service.Tokens(r.Context())
+	if err != nil {
+		slog.Error("failed to fetch tokens", "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	basics := make([]TokenBasic, 0, len(tokens))
+	for _, t := range tokens {
+		basics = append(basics, TokenBasic{
+			ID:         t.ID,
+			Name:       t.Name,
+			Scope:      string(t.Scope),
+			CreatedAt:  t.CreatedAt,
+			LastUsedAt: t.LastUsedAt,
+			Revoked:    t.IsRevoked(),
+		})
+	}
+	data := map[string]any{
+		"Tokens":    basics,
+		"Scopes":    accounts.TokenScopes,
+		"NewSecret": secret,
+	}
+
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "tokens/index.html", layoutData(r, data)); err != nil {
+		slog.Error("failed to render page", "page", "tokens/index", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
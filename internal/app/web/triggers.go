@@ -2,6 +2,7 @@ package web
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -14,6 +15,10 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
 )
 
 // TriggerBasic is a simplified version of normalized.Trigger for use in templates.
@@ -27,28 +32,39 @@ type TriggerBasic struct {
 func convertTriggersToHttpObjects(triggers []normalized.Trigger) []TriggerBasic {
 	ret := make([]TriggerBasic, 0, len(triggers))
 	for _, t := range triggers {
-		ret = append(ret, TriggerBasic{
-			ID:          t.ID,
-			Name:        t.Name,
-			Description: t.Description,
-		})
+		ret = append(ret, convertTriggerToHttpObject(t))
 	}
 	return ret
 }
 
+func convertTriggerToHttpObject(t normalized.Trigger) TriggerBasic {
+	return TriggerBasic{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+	}
+}
+
 type triggerService interface {
 	Save(ctx context.Context, trigger normalized.Trigger) (normalized.Trigger, error)
 	All(ctx context.Context) ([]normalized.Trigger, error)
+	Get(ctx context.Context, id uuid.UUID) (normalized.Trigger, error)
+}
+
+type triggerUsage interface {
+	// TriggerUsage is how it's been used on the reviews of the current team.
+	TriggerUsage(ctx context.Context, id uuid.UUID) (reviewing.Usage, error)
 }
 
 type triggersHandler struct {
 	htmx    *htmx.HTMX
 	service triggerService
+	usage   triggerUsage
 	partial *partial.Service
 	pp      *passepartout.Passepartout
 }
 
-func TriggersHandler(service triggerService) func(chi.Router) {
+func TriggersHandler(service triggerService, usage triggerUsage) func(chi.Router) {
 	fsys, err := passepartout.FSWithoutPrefix(templates, "templates")
 	if err != nil {
 		panic(err)
@@ -58,11 +74,13 @@ func TriggersHandler(service triggerService) func(chi.Router) {
 	a := triggersHandler{
 		htmx:    htmx.New(),
 		service: service,
+		usage:   usage,
 		pp: passepartout.New(
 			ppdefaults.NewLoaderBuilder().
 				WithDefaults(fsys).
 				TemplateLoader(ppdefaults.NewCachedLoader(&ppdefaults.TemplateByNameLoader{FS: fsys})).
 				PartialsFor(partials.Load).
+				TemplateConfig(baseTemplate()).
 				Build(),
 		),
 	}
@@ -78,19 +96,24 @@ func TriggersHandler(service triggerService) func(chi.Router) {
 	return func(r chi.Router) {
 		r.Post("/", a.Create)
 		r.Get("/new", a.New)
+		r.Get("/{id}", a.Show)
 	}
 }
 
 func (a *triggersHandler) New(w http.ResponseWriter, r *http.Request) {
 	h := a.htmx.NewHandler(w, r)
 
-	if !h.IsHxRequest() {
-		h.WriteHeader(http.StatusNotFound)
-		h.JustWriteString("not yet supported")
+	if err := actor.Require(r.Context(), actor.Curator); err != nil {
+		writeError(w, r, a.pp, fmt.Errorf("only curators can change the catalog: %w", err), http.StatusForbidden)
 		return
 	}
 
-	if err := a.pp.Render(w, "triggers/new.html", nil); err != nil {
+	data := map[string]any{"InTeam": tenant.ID(r.Context()) != uuid.Nil, "Trigger": TriggerBasic{}}
+	if !h.IsHxRequest() {
+		// Without htmx it's a page of its own, so send them back to where they came from afterward
+		data["Next"] = safeRedirect(r.URL.Query().Get("next"))
+	}
+	if err := renderPartOrPage(w, r, a.pp, "triggers/new.html", "triggers/new.html", data); err != nil {
 		slog.Error("failed to render new form", "error", err)
 		http.Error(w, "failed to render", http.StatusInternalServerError)
 		return
@@ -100,12 +123,6 @@ func (a *triggersHandler) New(w http.ResponseWriter, r *http.Request) {
 func (a *triggersHandler) Create(w http.ResponseWriter, r *http.Request) {
 	h := a.htmx.NewHandler(w, r)
 
-	if !h.IsHxRequest() {
-		h.WriteHeader(http.StatusNotFound)
-		h.JustWriteString("not yet supported")
-		return
-	}
-
 	if err := r.ParseForm(); err != nil {
 		slog.Error("failed to parse form", "error", err)
 		h.WriteHeader(http.StatusInternalServerError)
@@ -115,18 +132,43 @@ func (a *triggersHandler) Create(w http.ResponseWriter, r *http.Request) {
 	trigger := normalized.NewTrigger()
 	trigger.Name = r.PostForm.Get("name")
 	trigger.Description = r.PostForm.Get("description")
+	if r.PostForm.Get("shared") == "" {
+		trigger.TeamID = tenant.ID(r.Context())
+	}
 
 	trigger, err := a.service.Save(r.Context(), trigger)
+	if errs, ok := validate.Fields(err); ok {
+		// Show the form again with what they wrote, so they only have to fix what's wrong
+		data := map[string]any{
+			"InTeam":  tenant.ID(r.Context()) != uuid.Nil,
+			"Shared":  r.PostForm.Get("shared") != "",
+			"Trigger": TriggerBasic{Name: trigger.Name, Description: trigger.Description},
+			"Next":    r.PostForm.Get("next"),
+			"Errors":  errs,
+		}
+		h.WriteHeader(http.StatusUnprocessableEntity)
+		if err := renderPartOrPage(w, r, a.pp, "triggers/new.html", "triggers/new.html", data); err != nil {
+			slog.Error("failed to render new form with errors", "error", err)
+		}
+		return
+	}
 	if err != nil {
 		slog.Error("failed to save new trigger", "error", err)
-		h.WriteHeader(http.StatusInternalServerError)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	if !h.IsHxRequest() {
+		setFlash(w, r, Flash{Message: "Proposed the trigger " + trigger.Name + "."})
+		h.Header().Add("Location", safeRedirect(r.PostForm.Get("next")))
+		h.WriteHeader(http.StatusSeeOther)
 		return
 	}
 
 	triggers, err := a.service.All(r.Context())
 	if err != nil {
 		slog.Error("failed to fetch all triggers after proposing new trigger", "error", err)
-		h.WriteHeader(http.StatusInternalServerError)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -141,3 +183,39 @@ func (a *triggersHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (a *triggersHandler) Show(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for showing trigger", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	trigger, err := a.service.Get(r.Context(), id)
+	if err != nil {
+		slog.Error("failed to get trigger", "id", id, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	usage, err := a.usage.TriggerUsage(r.Context(), id)
+	if err != nil {
+		slog.Error("failed to get usage of trigger", "id", id, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]any{
+		"Trigger": convertTriggerToHttpObject(trigger),
+		"Usage":   toUsageBasic(usage),
+	}
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "triggers/show.html", layoutData(r, data)); err != nil {
+		slog.Error("failed to render trigger", "id", id, "error", err)
+		http.Error(w, "failed to render", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,47 @@
+package web
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+// UsageBasic is a simplified version of reviewing.Usage for use in templates.
+type UsageBasic struct {
+	Reviews   int
+	FirstUsed time.Time
+	LastUsed  time.Time
+	Proximal  int
+	Uses      []UseBasic
+}
+
+type UseBasic struct {
+	ReviewID        uuid.UUID
+	ReviewTitle     string
+	Why             string
+	IsProximalCause bool
+	BoundAt         time.Time
+}
+
+func toUsageBasic(u reviewing.Usage) UsageBasic {
+	uses := make([]UseBasic, 0, len(u.Uses))
+	for _, use := range u.Uses {
+		uses = append(uses, UseBasic{
+			ReviewID:        use.ReviewID,
+			ReviewTitle:     use.ReviewTitle,
+			Why:             use.Why,
+			IsProximalCause: use.IsProximalCause,
+			BoundAt:         use.BoundAt,
+		})
+	}
+
+	return UsageBasic{
+		Reviews:   u.Reviews(),
+		FirstUsed: u.FirstUsed(),
+		LastUsed:  u.LastUsed(),
+		Proximal:  u.Proximal(),
+		Uses:      uses,
+	}
+}
@@ -0,0 +1,149 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gaqzi/passepartout"
+	"github.com/gaqzi/passepartout/ppdefaults"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+)
+
+type usersService interface {
+	All(ctx context.Context) ([]accounts.User, error)
+	CreateUser(ctx context.Context, email, name, password string, role actor.Role) (accounts.User, error)
+	SetRole(ctx context.Context, id uuid.UUID, role actor.Role) (accounts.User, error)
+}
+
+type usersHandler struct {
+	htmx    *htmx.HTMX
+	service usersService
+	pp      *passepartout.Passepartout
+}
+
+// UsersHandler lets admins add people and decide what everyone is allowed to do.
+func UsersHandler(service usersService) func(chi.Router) {
+	fsys, err := passepartout.FSWithoutPrefix(templates, "templates")
+	if err != nil {
+		panic(err)
+	}
+
+	partials := &ppdefaults.PartialsWithCommon{FS: fsys, CommonDir: "partials"}
+	a := usersHandler{
+		htmx:    htmx.New(),
+		service: service,
+		pp: passepartout.New(
+			ppdefaults.NewLoaderBuilder().
+				WithDefaults(fsys).
+				TemplateLoader(ppdefaults.NewCachedLoader(&ppdefaults.TemplateByNameLoader{FS: fsys})).
+				PartialsFor(partials.Load).
+				TemplateConfig(baseTemplate()).
+				Build(),
+		),
+	}
+
+	return func(r chi.Router) {
+		r.Get("/", a.Index)
+		r.Post("/", a.Create)
+		r.Post("/{id}/role", a.SetRole)
+	}
+}
+
+type UserBasic struct {
+	ID    uuid.UUID
+	Name  string
+	Email string
+	Role  string
+}
+
+func (a *usersHandler) Index(w http.ResponseWriter, r *http.Request) {
+	if err := actor.Require(r.Context(), actor.Admin); err != nil {
+		writeError(w, r, a.pp, fmt.Errorf("only admins can manage users: %w", err), http.StatusForbidden)
+		return
+	}
+
+	users, err := a.service.All(r.Context())
+	if err != nil {
+		slog.Error("failed to fetch users", "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	basics := make([]UserBasic, 0, len(users))
+	for _, u := range users {
+		basics = append(basics, UserBasic{ID: u.ID, Name: u.Name, Email: u.Email, Role: string(u.Role)})
+	}
+	data := map[string]any{
+		"Users": basics,
+		"Roles": actor.Roles,
+	}
+
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "users/index.html", layoutData(r, data)); err != nil {
+		slog.Error("failed to render page", "page", "users/index", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// Create adds someone who signs in with an email and password, which the admin has to pass on to them.
+func (a *usersHandler) Create(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	u, err := a.service.CreateUser(
+		r.Context(),
+		r.PostForm.Get("email"),
+		r.PostForm.Get("name"),
+		r.PostForm.Get("password"),
+		actor.Role(r.PostForm.Get("role")),
+	)
+	if err != nil {
+		slog.Error("failed to create user", "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	setFlash(w, r, Flash{Message: fmt.Sprintf("Added %s, they can sign in as %s.", u.Name, u.Email)})
+	h.Header().Add("Location", "/users")
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+func (a *usersHandler) SetRole(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for set role", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	role := actor.Role(r.PostForm.Get("role"))
+	if _, err := a.service.SetRole(r.Context(), userID, role); err != nil {
+		slog.Error("failed to set role", "userID", userID, "role", role, "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	h.Header().Add("Location", "/users")
+	h.WriteHeader(http.StatusSeeOther)
+}
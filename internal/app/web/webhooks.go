@@ -0,0 +1,201 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/donseba/go-htmx"
+	"github.com/gaqzi/passepartout"
+	"github.com/gaqzi/passepartout/ppdefaults"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/webhooks"
+)
+
+type webhooksService interface {
+	Subscribe(ctx context.Context, url string, events []webhooks.EventType) (webhooks.Subscription, error)
+	Subscriptions(ctx context.Context) ([]webhooks.Subscription, error)
+	Subscription(ctx context.Context, id uuid.UUID) (webhooks.Subscription, error)
+	Unsubscribe(ctx context.Context, id uuid.UUID) error
+	Deliveries(ctx context.Context, subscriptionID uuid.UUID) ([]webhooks.Delivery, error)
+}
+
+type webhooksHandler struct {
+	htmx    *htmx.HTMX
+	service webhooksService
+	pp      *passepartout.Passepartout
+}
+
+// WebhooksHandler lets admins send events to other systems and see how the deliveries went.
+func WebhooksHandler(service webhooksService) func(chi.Router) {
+	fsys, err := passepartout.FSWithoutPrefix(templates, "templates")
+	if err != nil {
+		panic(err)
+	}
+
+	partials := &ppdefaults.PartialsWithCommon{FS: fsys, CommonDir: "partials"}
+	a := webhooksHandler{
+		htmx:    htmx.New(),
+		service: service,
+		pp: passepartout.New(
+			ppdefaults.NewLoaderBuilder().
+				WithDefaults(fsys).
+				TemplateLoader(ppdefaults.NewCachedLoader(&ppdefaults.TemplateByNameLoader{FS: fsys})).
+				PartialsFor(partials.Load).
+				TemplateConfig(baseTemplate()).
+				Build(),
+		),
+	}
+
+	return func(r chi.Router) {
+		r.Get("/", a.Index)
+		r.Post("/", a.Create)
+		r.Get("/{id}", a.Show)
+		r.Post("/{id}/delete", a.Delete)
+	}
+}
+
+type SubscriptionBasic struct {
+	ID        uuid.UUID
+	URL       string
+	Secret    string
+	Events    []webhooks.EventType
+	CreatedAt time.Time
+}
+
+type DeliveryBasic struct {
+	EventID     uuid.UUID
+	EventType   webhooks.EventType
+	Attempt     int
+	StatusCode  int
+	Error       string
+	Succeeded   bool
+	AttemptedAt time.Time
+	Duration    time.Duration
+}
+
+func toSubscriptionBasic(s webhooks.Subscription) SubscriptionBasic {
+	return SubscriptionBasic{ID: s.ID, URL: s.URL, Secret: s.Secret, Events: s.Events, CreatedAt: s.CreatedAt}
+}
+
+func (a *webhooksHandler) Index(w http.ResponseWriter, r *http.Request) {
+	subs, err := a.service.Subscriptions(r.Context())
+	if err != nil {
+		slog.Error("failed to fetch webhook subscriptions", "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+
+	basics := make([]SubscriptionBasic, 0, len(subs))
+	for _, s := range subs {
+		basics = append(basics, toSubscriptionBasic(s))
+	}
+	data := map[string]any{
+		"Subscriptions": basics,
+		"EventTypes":    webhooks.EventTypes,
+	}
+
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "webhooks/index.html", layoutData(r, data)); err != nil {
+		slog.Error("failed to render page", "page", "webhooks/index", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *webhooksHandler) Create(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	if err := r.ParseForm(); err != nil {
+		slog.Error("failed to parse form", "error", err)
+		h.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	events := make([]webhooks.EventType, 0, len(r.PostForm["events"]))
+	for _, e := range r.PostForm["events"] {
+		events = append(events, webhooks.EventType(e))
+	}
+
+	sub, err := a.service.Subscribe(r.Context(), r.PostForm.Get("url"), events)
+	if err != nil {
+		slog.Error("failed to subscribe webhook", "error", err)
+		writeError(w, r, a.pp, err, http.StatusBadRequest)
+		return
+	}
+
+	h.Header().Add("Location", "/webhooks/subscriptions/"+sub.ID.String())
+	h.WriteHeader(http.StatusSeeOther)
+}
+
+func (a *webhooksHandler) Show(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	subID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for webhook subscription", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	sub, err := a.service.Subscription(r.Context(), subID)
+	if err != nil {
+		slog.Error("failed to fetch webhook subscription", "subscriptionID", subID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusNotFound)
+		return
+	}
+
+	deliveries, err := a.service.Deliveries(r.Context(), subID)
+	if err != nil {
+		slog.Error("failed to fetch webhook deliveries", "subscriptionID", subID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusInternalServerError)
+		return
+	}
+	log := make([]DeliveryBasic, 0, len(deliveries))
+	for _, d := range deliveries {
+		log = append(log, DeliveryBasic{
+			EventID:     d.EventID,
+			EventType:   d.EventType,
+			Attempt:     d.Attempt,
+			StatusCode:  d.StatusCode,
+			Error:       d.Error,
+			Succeeded:   d.Succeeded(),
+			AttemptedAt: d.AttemptedAt,
+			Duration:    d.Duration.Round(time.Millisecond),
+		})
+	}
+	data := map[string]any{
+		"Subscription": toSubscriptionBasic(sub),
+		"Deliveries":   log,
+	}
+
+	if err := a.pp.RenderInLayout(w, "layouts/standard.html", "webhooks/show.html", layoutData(r, data)); err != nil {
+		slog.Error("failed to render page", "page", "webhooks/show", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *webhooksHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	h := a.htmx.NewHandler(w, r)
+
+	subID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to parse id for webhook subscription", "id", r.PathValue("id"), "error", err)
+		h.WriteHeader(http.StatusBadRequest)
+		h.JustWriteString("invalid id")
+		return
+	}
+
+	if err := a.service.Unsubscribe(r.Context(), subID); err != nil {
+		slog.Error("failed to unsubscribe webhook", "subscriptionID", subID, "error", err)
+		writeError(w, r, a.pp, err, http.StatusNotFound)
+		return
+	}
+
+	h.Header().Add("Location", "/webhooks/subscriptions")
+	h.WriteHeader(http.StatusSeeOther)
+}
@@ -0,0 +1,50 @@
+// Package intake creates draft reviews from the incidents in incident management tools as they're resolved.
+package intake
+
+import (
+	"time"
+
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+// NotReported is used for what a review needs but the incident tool doesn't know, for the facilitator to fill in.
+const NotReported = "Not reported yet"
+
+// Incident is what's known about an incident from an incident tool's payload.
+type Incident struct {
+	// ID is the incident tool's ID for the incident, it's only turned into a review once.
+	ID          string
+	Title       string
+	URL         string
+	Impact      string
+	Description string
+	Where       string
+	StartedAt   time.Time
+	ResolvedAt  time.Time
+	// Resolved is whether the payload is for the incident being resolved, only resolved incidents are reviewed.
+	Resolved bool
+}
+
+// Review is a draft review of the incident.
+func (i Incident) Review() reviewing.Review {
+	r := reviewing.NewReview()
+	r.Title = i.Title
+	r.URL = i.URL
+	r.Impact = orNotReported(i.Impact)
+	r.Description = orNotReported(i.Description)
+	r.Where = orNotReported(i.Where)
+	r.ReportProximalCause = NotReported
+	r.ReportTrigger = NotReported
+	r.IncidentStartedAt = i.StartedAt
+	r.IncidentResolvedAt = i.ResolvedAt
+
+	return r
+}
+
+func orNotReported(s string) string {
+	if s == "" {
+		return NotReported
+	}
+
+	return s
+}
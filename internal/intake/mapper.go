@@ -0,0 +1,224 @@
+package intake
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mapper reads the payload an incident tool sends.
+type Mapper interface {
+	Map(payload []byte) (Incident, error)
+}
+
+type MapperFunc func(payload []byte) (Incident, error)
+
+func (f MapperFunc) Map(payload []byte) (Incident, error) {
+	return f(payload)
+}
+
+// Mappers are the payloads that can be read without configuring how, by the name used for them in LoadSources.
+var Mappers = map[string]Mapper{
+	"pagerduty":   MapperFunc(PagerDuty),
+	"incident.io": MapperFunc(IncidentIO),
+}
+
+// PagerDuty reads the incident events of PagerDuty's v3 webhooks.
+func PagerDuty(payload []byte) (Incident, error) {
+	var p struct {
+		Event struct {
+			EventType  string    `json:"event_type"`
+			OccurredAt time.Time `json:"occurred_at"`
+			Data       struct {
+				ID        string    `json:"id"`
+				Title     string    `json:"title"`
+				HTMLURL   string    `json:"html_url"`
+				Urgency   string    `json:"urgency"`
+				CreatedAt time.Time `json:"created_at"`
+				Service   struct {
+					Summary string `json:"summary"`
+				} `json:"service"`
+			} `json:"data"`
+		} `json:"event"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return Incident{}, fmt.Errorf("failed to decode pagerduty payload: %w", err)
+	}
+
+	data := p.Event.Data
+	ret := Incident{
+		ID:        data.ID,
+		Title:     data.Title,
+		URL:       data.HTMLURL,
+		Where:     data.Service.Summary,
+		StartedAt: data.CreatedAt,
+		Resolved:  p.Event.EventType == "incident.resolved",
+	}
+	if data.Urgency != "" {
+		ret.Impact = "Paged with " + data.Urgency + " urgency"
+	}
+	if ret.Resolved {
+		ret.ResolvedAt = p.Event.OccurredAt
+	}
+
+	return ret, nil
+}
+
+// IncidentIO reads the incident updated events of incident.io's webhooks,
+// an incident is resolved once it's in the learning or closed category.
+func IncidentIO(payload []byte) (Incident, error) {
+	type incident struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		Permalink string `json:"permalink"`
+		Summary   string `json:"summary"`
+		Severity  struct {
+			Name string `json:"name"`
+		} `json:"severity"`
+		Status struct {
+			Category string `json:"category"`
+		} `json:"incident_status"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+	var p struct {
+		EventType string `json:"event_type"`
+		Updated   struct {
+			Incident incident `json:"incident"`
+		} `json:"public_incident.incident_updated_v2"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return Incident{}, fmt.Errorf("failed to decode incident.io payload: %w", err)
+	}
+
+	inc := p.Updated.Incident
+	ret := Incident{
+		ID:          inc.ID,
+		Title:       inc.Name,
+		URL:         inc.Permalink,
+		Description: inc.Summary,
+		StartedAt:   inc.CreatedAt,
+		Resolved:    slices.Contains([]string{"learning", "closed"}, inc.Status.Category),
+	}
+	if inc.Severity.Name != "" {
+		ret.Impact = inc.Severity.Name + " severity"
+	}
+	if ret.Resolved {
+		ret.ResolvedAt = inc.UpdatedAt
+	}
+
+	return ret, nil
+}
+
+// FieldMapper reads any JSON payload by where in it each field is,
+// as dot separated paths like "incident.id" or "links.0.href".
+type FieldMapper struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Impact      string `json:"impact,omitempty"`
+	Description string `json:"description,omitempty"`
+	Where       string `json:"where,omitempty"`
+	// StartedAt and ResolvedAt are timestamps in RFC 3339, like 2025-05-06T10:20:30Z.
+	StartedAt  string `json:"startedAt,omitempty"`
+	ResolvedAt string `json:"resolvedAt,omitempty"`
+	// ResolvedWhen is when the incident is resolved, every payload is for a resolved incident when it's not set.
+	ResolvedWhen *Condition `json:"resolvedWhen,omitempty"`
+}
+
+// Condition is true when the value at Path is one of In.
+type Condition struct {
+	Path string   `json:"path"`
+	In   []string `json:"in"`
+}
+
+func (fm FieldMapper) validate() error {
+	var errs []error
+	for name, path := range map[string]string{"id": fm.ID, "title": fm.Title, "url": fm.URL} {
+		if path == "" {
+			errs = append(errs, errors.New("the path to "+name+" is required"))
+		}
+	}
+	if fm.ResolvedWhen != nil && (fm.ResolvedWhen.Path == "" || len(fm.ResolvedWhen.In) == 0) {
+		errs = append(errs, errors.New("resolvedWhen needs a path and the values it's in"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (fm FieldMapper) Map(payload []byte) (Incident, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return Incident{}, fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	ret := Incident{
+		ID:          lookup(doc, fm.ID),
+		Title:       lookup(doc, fm.Title),
+		URL:         lookup(doc, fm.URL),
+		Impact:      lookup(doc, fm.Impact),
+		Description: lookup(doc, fm.Description),
+		Where:       lookup(doc, fm.Where),
+		Resolved:    fm.ResolvedWhen == nil || slices.Contains(fm.ResolvedWhen.In, lookup(doc, fm.ResolvedWhen.Path)),
+	}
+
+	var err error
+	if ret.StartedAt, err = lookupTime(doc, fm.StartedAt); err != nil {
+		return Incident{}, fmt.Errorf("failed to read startedAt: %w", err)
+	}
+	if ret.ResolvedAt, err = lookupTime(doc, fm.ResolvedAt); err != nil {
+		return Incident{}, fmt.Errorf("failed to read resolvedAt: %w", err)
+	}
+
+	return ret, nil
+}
+
+// lookup returns the value at the path as a string, or an empty string when there's nothing there.
+func lookup(doc any, path string) string {
+	if path == "" {
+		return ""
+	}
+
+	current := doc
+	for _, key := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]any:
+			current = v[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return ""
+			}
+			current = v[i]
+		default:
+			return ""
+		}
+	}
+
+	switch v := current.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
+
+func lookupTime(doc any, path string) (time.Time, error) {
+	s := lookup(doc, path)
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
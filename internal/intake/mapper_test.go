@@ -0,0 +1,147 @@
+package intake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/intake"
+)
+
+const pagerDutyResolved = `{
+  "event": {
+    "id": "01BZ3JZHPRQMWGLTEL2LLCPUOC",
+    "event_type": "incident.resolved",
+    "resource_type": "incident",
+    "occurred_at": "2025-05-06T11:30:00Z",
+    "data": {
+      "id": "PGR0VU2",
+      "type": "incident",
+      "html_url": "https://acme.pagerduty.com/incidents/PGR0VU2",
+      "number": 2,
+      "status": "resolved",
+      "title": "Checkout is failing",
+      "urgency": "high",
+      "created_at": "2025-05-06T10:00:00Z",
+      "service": {"id": "PF9KMXH", "summary": "Payments API"}
+    }
+  }
+}`
+
+const incidentIOClosed = `{
+  "event_type": "public_incident.incident_updated_v2",
+  "public_incident.incident_updated_v2": {
+    "incident": {
+      "id": "01FDAG4SAP5TYPT98WGR2N7W91",
+      "name": "Our database is sad",
+      "permalink": "https://app.incident.io/incidents/123",
+      "summary": "Queries timed out for an hour",
+      "severity": {"name": "Major"},
+      "incident_status": {"category": "closed", "name": "Closed"},
+      "created_at": "2025-05-06T10:00:00Z",
+      "updated_at": "2025-05-06T11:00:00Z"
+    }
+  }
+}`
+
+func TestPagerDuty(t *testing.T) {
+	t.Run("a resolved incident", func(t *testing.T) {
+		incident, err := intake.PagerDuty([]byte(pagerDutyResolved))
+
+		require.NoError(t, err)
+		require.Equal(t, intake.Incident{
+			ID:         "PGR0VU2",
+			Title:      "Checkout is failing",
+			URL:        "https://acme.pagerduty.com/incidents/PGR0VU2",
+			Impact:     "Paged with high urgency",
+			Where:      "Payments API",
+			StartedAt:  time.Date(2025, 5, 6, 10, 0, 0, 0, time.UTC),
+			ResolvedAt: time.Date(2025, 5, 6, 11, 30, 0, 0, time.UTC),
+			Resolved:   true,
+		}, incident)
+	})
+
+	t.Run("other events aren't resolved", func(t *testing.T) {
+		incident, err := intake.PagerDuty([]byte(`{"event": {"event_type": "incident.acknowledged", "data": {"id": "PGR0VU2"}}}`))
+
+		require.NoError(t, err)
+		require.False(t, incident.Resolved)
+		require.True(t, incident.ResolvedAt.IsZero())
+	})
+}
+
+func TestIncidentIO(t *testing.T) {
+	t.Run("a closed incident", func(t *testing.T) {
+		incident, err := intake.IncidentIO([]byte(incidentIOClosed))
+
+		require.NoError(t, err)
+		require.Equal(t, intake.Incident{
+			ID:          "01FDAG4SAP5TYPT98WGR2N7W91",
+			Title:       "Our database is sad",
+			URL:         "https://app.incident.io/incidents/123",
+			Impact:      "Major severity",
+			Description: "Queries timed out for an hour",
+			StartedAt:   time.Date(2025, 5, 6, 10, 0, 0, 0, time.UTC),
+			ResolvedAt:  time.Date(2025, 5, 6, 11, 0, 0, 0, time.UTC),
+			Resolved:    true,
+		}, incident)
+	})
+
+	t.Run("a live incident isn't resolved", func(t *testing.T) {
+		incident, err := intake.IncidentIO([]byte(`{"public_incident.incident_updated_v2": {"incident": {"id": "01", "incident_status": {"category": "live"}}}}`))
+
+		require.NoError(t, err)
+		require.False(t, incident.Resolved)
+	})
+}
+
+func TestFieldMapper(t *testing.T) {
+	mapper := intake.FieldMapper{
+		ID:         "incident.number",
+		Title:      "incident.title",
+		URL:        "incident.links.0.href",
+		Impact:     "incident.impact",
+		StartedAt:  "incident.opened",
+		ResolvedAt: "incident.closed",
+		ResolvedWhen: &intake.Condition{
+			Path: "incident.state",
+			In:   []string{"resolved", "closed"},
+		},
+	}
+
+	t.Run("reads the fields from their paths", func(t *testing.T) {
+		incident, err := mapper.Map([]byte(`{"incident": {
+			"number": 42,
+			"title": "Search is slow",
+			"links": [{"href": "https://status.example.com/42"}],
+			"impact": "Searches took seconds",
+			"state": "resolved",
+			"opened": "2025-05-06T10:00:00Z",
+			"closed": "2025-05-06T10:45:00+02:00"
+		}}`))
+
+		require.NoError(t, err)
+		require.Equal(t, "42", incident.ID, "expected numbers to be read as they were written")
+		require.Equal(t, "Search is slow", incident.Title)
+		require.Equal(t, "https://status.example.com/42", incident.URL)
+		require.Equal(t, "Searches took seconds", incident.Impact)
+		require.True(t, incident.Resolved)
+		require.Equal(t, time.Date(2025, 5, 6, 10, 0, 0, 0, time.UTC), incident.StartedAt.UTC())
+		require.Equal(t, time.Date(2025, 5, 6, 8, 45, 0, 0, time.UTC), incident.ResolvedAt.UTC())
+	})
+
+	t.Run("what isn't in the payload is left empty", func(t *testing.T) {
+		incident, err := mapper.Map([]byte(`{"incident": {"number": 42, "state": "investigating", "links": []}}`))
+
+		require.NoError(t, err)
+		require.Empty(t, incident.URL)
+		require.False(t, incident.Resolved)
+	})
+
+	t.Run("returns an error for a timestamp that can't be read", func(t *testing.T) {
+		_, err := mapper.Map([]byte(`{"incident": {"opened": "yesterday"}}`))
+
+		require.ErrorContains(t, err, "startedAt")
+	})
+}
@@ -0,0 +1,116 @@
+package intake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+var (
+	ErrUnknownSource    = failure.New(failure.NotFound, "unknown incident source")
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrInvalidPayload is returned when the payload isn't what the source's mapper reads.
+	ErrInvalidPayload = failure.New(failure.Invalid, "invalid payload")
+)
+
+// Outcome is what came of a delivery.
+type Outcome string
+
+const (
+	// OutcomeCreated is when a draft review was created for the incident.
+	OutcomeCreated Outcome = "created"
+	// OutcomeDuplicate is when the incident was delivered before, the review is the one created then.
+	OutcomeDuplicate Outcome = "duplicate"
+	// OutcomeIgnored is when the incident isn't resolved yet.
+	OutcomeIgnored Outcome = "ignored"
+)
+
+type Result struct {
+	Outcome  Outcome
+	ReviewID uuid.UUID
+}
+
+type reviewSaver interface {
+	Save(ctx context.Context, review reviewing.Review) (reviewing.Review, error)
+}
+
+type Service struct {
+	reviews    reviewSaver
+	deliveries DeliveryStorage
+	sources    map[string]Source
+}
+
+func NewService(reviews reviewSaver, deliveries DeliveryStorage, sources []Source) *Service {
+	s := &Service{
+		reviews:    reviews,
+		deliveries: deliveries,
+		sources:    make(map[string]Source, len(sources)),
+	}
+	for _, src := range sources {
+		s.sources[src.Name] = src
+	}
+
+	return s
+}
+
+// Receive creates a draft review when the payload is for a resolved incident, signed by the source.
+// The review is created by the system in the source's team, and the incident is only reviewed once.
+// The system isn't a user and can't facilitate, so the source's facilitator is made the facilitator of the review,
+// and without one only admins can change it until they've added the facilitators.
+func (s *Service) Receive(ctx context.Context, sourceName string, payload []byte, signature string) (Result, error) {
+	src, ok := s.sources[sourceName]
+	if !ok {
+		return Result{}, fmt.Errorf("%w: %q", ErrUnknownSource, sourceName)
+	}
+	if !src.Verify(payload, signature) {
+		return Result{}, ErrInvalidSignature
+	}
+
+	incident, err := src.Mapper.Map(payload)
+	if err != nil {
+		return Result{}, fmt.Errorf("%w from %s: %w", ErrInvalidPayload, src.Name, err)
+	}
+	if !incident.Resolved {
+		return Result{Outcome: OutcomeIgnored}, nil
+	}
+	if incident.ID == "" {
+		return Result{}, fmt.Errorf("%w from %s: no incident ID", ErrInvalidPayload, src.Name)
+	}
+
+	review := incident.Review()
+	if src.FacilitatorID != uuid.Nil {
+		review.Facilitators = []uuid.UUID{src.FacilitatorID}
+	}
+	delivery, err := s.deliveries.Claim(ctx, Delivery{
+		Source:     src.Name,
+		UpstreamID: incident.ID,
+		ReviewID:   review.ID,
+		ReceivedAt: time.Now(),
+	})
+	switch {
+	case errors.Is(err, ErrDuplicateDelivery):
+		return Result{Outcome: OutcomeDuplicate, ReviewID: delivery.ReviewID}, nil
+	case err != nil:
+		return Result{}, fmt.Errorf("failed to claim delivery: %w", err)
+	}
+
+	systemCtx := tenant.With(actor.With(ctx, actor.System), src.TeamID)
+	if _, err := s.reviews.Save(systemCtx, review); err != nil {
+		// Let the incident tool's retry create it instead
+		if relErr := s.deliveries.Release(ctx, src.Name, incident.ID); relErr != nil {
+			err = errors.Join(err, relErr)
+		}
+
+		return Result{}, fmt.Errorf("failed to create review for incident %s from %s: %w", incident.ID, src.Name, err)
+	}
+
+	return Result{Outcome: OutcomeCreated, ReviewID: review.ID}, nil
+}
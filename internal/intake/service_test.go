@@ -0,0 +1,180 @@
+package intake_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/intake"
+	intakestorage "github.com/gaqzi/incident-reviewer/internal/intake/storage"
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
+	contribstorage "github.com/gaqzi/incident-reviewer/internal/normalized/contributing/storage"
+	"github.com/gaqzi/incident-reviewer/internal/normalized/storage"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	reviewstorage "github.com/gaqzi/incident-reviewer/internal/reviewing/storage"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+type failingSaver struct{}
+
+func (failingSaver) Save(_ context.Context, r reviewing.Review) (reviewing.Review, error) {
+	return r, errors.New("storage is down")
+}
+
+func TestService_Receive(t *testing.T) {
+	ctx := context.Background()
+	teamID := a.UUID()
+	src := intake.Source{Name: "pagerduty", Secret: "s3cret", Mapper: intake.MapperFunc(intake.PagerDuty), TeamID: teamID}
+	payload := []byte(pagerDutyResolved)
+	newReviews := func(t *testing.T) *reviewing.Service {
+		t.Helper()
+		reviews, err := reviewing.NewService(
+			reviewstorage.NewMemoryStore(),
+			contributing.NewCauseService(contribstorage.NewCauseMemoryStore()),
+			normalized.NewTriggerService(storage.NewTriggerMemoryStore()),
+		)
+		require.NoError(t, err)
+
+		return reviews
+	}
+	setup := func(t *testing.T) (*intake.Service, *reviewing.Service) {
+		t.Helper()
+		reviews := newReviews(t)
+
+		return intake.NewService(reviews, intakestorage.NewDeliveryMemoryStore(), []intake.Source{src}), reviews
+	}
+
+	t.Run("creates a draft review in the source's team for a resolved incident", func(t *testing.T) {
+		service, reviews := setup(t)
+
+		result, err := service.Receive(ctx, "pagerduty", payload, src.Sign(payload))
+
+		require.NoError(t, err)
+		require.Equal(t, intake.OutcomeCreated, result.Outcome)
+		review, err := reviews.Get(tenant.With(actor.With(ctx, actor.System), teamID), result.ReviewID)
+		require.NoError(t, err)
+		require.Equal(t, reviewing.StateDraft, review.State)
+		require.Equal(t, teamID, review.TeamID)
+		require.Equal(t, "Checkout is failing", review.Title)
+		require.Equal(t, "https://acme.pagerduty.com/incidents/PGR0VU2", review.URL)
+		require.Equal(t, "Paged with high urgency", review.Impact)
+		require.Equal(t, intake.NotReported, review.ReportProximalCause)
+		require.False(t, review.IncidentStartedAt.IsZero())
+		require.False(t, review.IncidentResolvedAt.IsZero())
+		require.Empty(t, review.Facilitators, "expected the system to not be added as a facilitator")
+		admin := a.Actor().WithRole(actor.RoleAdmin).Build()
+		require.True(t, review.Allows(admin, reviewing.PermissionFacilitate), "expected admins to pick up a review without facilitators")
+		facilitator := a.Actor().WithRole(actor.RoleFacilitator).Build()
+		require.False(t, review.Allows(facilitator, reviewing.PermissionContribute), "expected only admins to be able to change it until they've added the facilitators")
+	})
+
+	t.Run("the source's facilitator facilitates the review", func(t *testing.T) {
+		facilitator := a.Actor().WithRole(actor.RoleFacilitator).Build()
+		withFacilitator := src
+		withFacilitator.FacilitatorID = facilitator.ID
+		reviews := newReviews(t)
+		service := intake.NewService(reviews, intakestorage.NewDeliveryMemoryStore(), []intake.Source{withFacilitator})
+
+		result, err := service.Receive(ctx, "pagerduty", payload, src.Sign(payload))
+
+		require.NoError(t, err)
+		review, err := reviews.Get(tenant.With(actor.With(ctx, facilitator), teamID), result.ReviewID)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{facilitator.ID}, review.Facilitators)
+		require.Equal(t, uuid.Nil, review.CreatedBy, "expected the system to still be who created it")
+		require.True(t, review.Allows(facilitator, reviewing.PermissionFacilitate))
+	})
+
+	t.Run("a redelivered incident returns the review created the first time", func(t *testing.T) {
+		service, _ := setup(t)
+		first, err := service.Receive(ctx, "pagerduty", payload, src.Sign(payload))
+		require.NoError(t, err)
+
+		again, err := service.Receive(ctx, "pagerduty", payload, src.Sign(payload))
+
+		require.NoError(t, err)
+		require.Equal(t, intake.OutcomeDuplicate, again.Outcome)
+		require.Equal(t, first.ReviewID, again.ReviewID)
+	})
+
+	t.Run("an incident that isn't resolved is ignored", func(t *testing.T) {
+		service, _ := setup(t)
+		acknowledged := []byte(`{"event": {"event_type": "incident.acknowledged", "data": {"id": "PGR0VU2"}}}`)
+
+		result, err := service.Receive(ctx, "pagerduty", acknowledged, src.Sign(acknowledged))
+
+		require.NoError(t, err)
+		require.Equal(t, intake.OutcomeIgnored, result.Outcome)
+	})
+
+	t.Run("returns an error for a source that isn't configured", func(t *testing.T) {
+		service, _ := setup(t)
+
+		_, err := service.Receive(ctx, "opsgenie", payload, src.Sign(payload))
+
+		require.ErrorIs(t, err, intake.ErrUnknownSource)
+	})
+
+	t.Run("returns an error when the signature doesn't match", func(t *testing.T) {
+		service, _ := setup(t)
+
+		_, err := service.Receive(ctx, "pagerduty", payload, intake.Source{Secret: "guessed"}.Sign(payload))
+
+		require.ErrorIs(t, err, intake.ErrInvalidSignature)
+	})
+
+	t.Run("returns an error when a review can't be made from the incident", func(t *testing.T) {
+		service, _ := setup(t)
+		noURL := []byte(`{"event": {"event_type": "incident.resolved", "data": {"id": "P1", "title": "No link"}}}`)
+
+		_, err := service.Receive(ctx, "pagerduty", noURL, src.Sign(noURL))
+
+		require.Error(t, err)
+	})
+
+	t.Run("a team's required custom fields are filled in before the draft is moved on, not when it's created", func(t *testing.T) {
+		teamCtx := tenant.With(actor.With(ctx, a.Actor().WithRole(actor.RoleAdmin).Build()), teamID)
+		customFields := reviewing.NewCustomFieldService(reviewstorage.NewCustomFieldMemoryStore())
+		region := reviewing.NewCustomField()
+		region.Name = "Region"
+		region.Required = true
+		region, err := customFields.Save(teamCtx, region)
+		require.NoError(t, err)
+		reviews, err := reviewing.NewService(reviewstorage.NewMemoryStore(), nil, nil, reviewing.WithCustomFields(customFields))
+		require.NoError(t, err)
+		service := intake.NewService(reviews, intakestorage.NewDeliveryMemoryStore(), []intake.Source{src})
+
+		result, err := service.Receive(ctx, "pagerduty", payload, src.Sign(payload))
+
+		require.NoError(t, err)
+		require.Equal(t, intake.OutcomeCreated, result.Outcome)
+		_, err = reviews.Transition(teamCtx, result.ReviewID, reviewing.StateInReview)
+		require.ErrorContains(t, err, region.ID.String()+": Can't be empty.")
+		draft, err := reviews.Get(teamCtx, result.ReviewID)
+		require.NoError(t, err)
+		draft.CustomValues = map[uuid.UUID]string{region.ID: "EU"}
+		_, err = reviews.Save(teamCtx, draft)
+		require.NoError(t, err)
+		_, err = reviews.Transition(teamCtx, result.ReviewID, reviewing.StateInReview)
+		require.NoError(t, err)
+	})
+
+	t.Run("an incident that failed to be created can be delivered again", func(t *testing.T) {
+		deliveries := intakestorage.NewDeliveryMemoryStore()
+		_, err := intake.NewService(failingSaver{}, deliveries, []intake.Source{src}).
+			Receive(ctx, "pagerduty", payload, src.Sign(payload))
+		require.Error(t, err)
+
+		result, err := intake.NewService(newReviews(t), deliveries, []intake.Source{src}).
+			Receive(ctx, "pagerduty", payload, src.Sign(payload))
+
+		require.NoError(t, err)
+		require.Equal(t, intake.OutcomeCreated, result.Outcome)
+	})
+}
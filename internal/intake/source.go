@@ -0,0 +1,121 @@
+package intake
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SignaturePrefix starts the signature of a payload, which is followed by the hex encoded HMAC-SHA256 of it.
+const SignaturePrefix = "sha256="
+
+// Source is an incident tool that sends its incidents.
+type Source struct {
+	// Name is what the source is called in the URL it sends to.
+	Name string
+	// Secret is shared with the incident tool to sign its payloads.
+	Secret string
+	Mapper Mapper
+	// TeamID is the team the reviews are created for, uuid.Nil for no team.
+	TeamID uuid.UUID
+	// FacilitatorID is the user who facilitates the reviews created from the source's incidents,
+	// uuid.Nil leaves them for an admin to pick up and add the facilitators to.
+	FacilitatorID uuid.UUID
+}
+
+// Sign returns the signature of the payload the way the incident tool has to send it.
+func (s Source) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write(payload)
+
+	return SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks that the payload was signed with the source's secret.
+func (s Source) Verify(payload []byte, signature string) bool {
+	hexSum, ok := strings.CutPrefix(signature, SignaturePrefix)
+	if !ok {
+		return false
+	}
+	sum, err := hex.DecodeString(hexSum)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write(payload)
+
+	return hmac.Equal(sum, mac.Sum(nil))
+}
+
+type sourceConfig struct {
+	Name        string       `json:"name"`
+	Secret      string       `json:"secret"`
+	Mapper      string       `json:"mapper"`
+	Fields      *FieldMapper `json:"fields,omitempty"`
+	Team        uuid.UUID    `json:"team,omitempty"`
+	Facilitator uuid.UUID    `json:"facilitator,omitempty"`
+}
+
+// LoadSources reads a JSON list of sources, where the mapper is one of Mappers or "fields" with the fields configured,
+// so a mistake in the configuration is found at startup and not when an incident is resolved.
+func LoadSources(r io.Reader) ([]Source, error) {
+	var configs []sourceConfig
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&configs); err != nil {
+		return nil, fmt.Errorf("failed to decode incident sources: %w", err)
+	}
+
+	ret := make([]Source, 0, len(configs))
+	seen := make(map[string]bool, len(configs))
+	for i, c := range configs {
+		source, err := c.source()
+		if err == nil && seen[c.Name] {
+			err = errors.New("the name is used by another source: " + c.Name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid incident source at position %d: %w", i, err)
+		}
+
+		seen[c.Name] = true
+		ret = append(ret, source)
+	}
+
+	return ret, nil
+}
+
+func (c sourceConfig) source() (Source, error) {
+	if c.Name == "" {
+		return Source{}, errors.New("a name is required")
+	}
+	if c.Secret == "" {
+		return Source{}, errors.New("a secret is required for " + c.Name)
+	}
+
+	ret := Source{Name: c.Name, Secret: c.Secret, TeamID: c.Team, FacilitatorID: c.Facilitator}
+	switch {
+	case c.Mapper == "fields" && c.Fields != nil:
+		if err := c.Fields.validate(); err != nil {
+			return Source{}, fmt.Errorf("invalid fields for %s: %w", c.Name, err)
+		}
+		ret.Mapper = *c.Fields
+	case c.Mapper == "fields":
+		return Source{}, errors.New("the fields mapper needs fields for " + c.Name)
+	default:
+		mapper, ok := Mappers[c.Mapper]
+		if !ok {
+			return Source{}, fmt.Errorf("unknown mapper for %s: %q", c.Name, c.Mapper)
+		}
+		ret.Mapper = mapper
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,64 @@
+package intake_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/intake"
+)
+
+func TestSource_Verify(t *testing.T) {
+	src := intake.Source{Name: "pagerduty", Secret: "s3cret"}
+	payload := []byte(`{"event": {}}`)
+
+	t.Run("a payload signed with the secret", func(t *testing.T) {
+		require.True(t, src.Verify(payload, src.Sign(payload)))
+	})
+
+	t.Run("a payload signed with another secret", func(t *testing.T) {
+		other := intake.Source{Secret: "not-it"}
+
+		require.False(t, src.Verify(payload, other.Sign(payload)))
+	})
+
+	t.Run("a changed payload", func(t *testing.T) {
+		require.False(t, src.Verify([]byte(`{"event": {"changed": true}}`), src.Sign(payload)))
+	})
+
+	t.Run("a signature that isn't prefixed or hex", func(t *testing.T) {
+		require.False(t, src.Verify(payload, strings.TrimPrefix(src.Sign(payload), intake.SignaturePrefix)))
+		require.False(t, src.Verify(payload, intake.SignaturePrefix+"not hex"))
+	})
+}
+
+func TestLoadSources(t *testing.T) {
+	t.Run("loads the built in and configured mappers", func(t *testing.T) {
+		sources, err := intake.LoadSources(strings.NewReader(`[
+			{"name": "pagerduty", "secret": "one", "mapper": "pagerduty", "team": "0196a4c0-0000-7000-8000-000000000001", "facilitator": "0196a4c0-0000-7000-8000-000000000002"},
+			{"name": "status", "secret": "two", "mapper": "fields", "fields": {"id": "id", "title": "title", "url": "url"}}
+		]`))
+
+		require.NoError(t, err)
+		require.Len(t, sources, 2)
+		require.Equal(t, "0196a4c0-0000-7000-8000-000000000001", sources[0].TeamID.String())
+		require.Equal(t, "0196a4c0-0000-7000-8000-000000000002", sources[0].FacilitatorID.String())
+		require.Equal(t, intake.FieldMapper{ID: "id", Title: "title", URL: "url"}, sources[1].Mapper)
+	})
+
+	for name, config := range map[string]string{
+		"unknown mapper":         `[{"name": "x", "secret": "s", "mapper": "nope"}]`,
+		"missing secret":         `[{"name": "x", "mapper": "pagerduty"}]`,
+		"fields without fields":  `[{"name": "x", "secret": "s", "mapper": "fields"}]`,
+		"fields without an id":   `[{"name": "x", "secret": "s", "mapper": "fields", "fields": {"title": "t", "url": "u"}}]`,
+		"same name twice":        `[{"name": "x", "secret": "s", "mapper": "pagerduty"}, {"name": "x", "secret": "s", "mapper": "incident.io"}]`,
+		"misspelled config keys": `[{"name": "x", "secret": "s", "maper": "pagerduty"}]`,
+	} {
+		t.Run("returns an error for "+name, func(t *testing.T) {
+			_, err := intake.LoadSources(strings.NewReader(config))
+
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package intake
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+// ErrDuplicateDelivery is returned when the incident has already been delivered from the source.
+var ErrDuplicateDelivery = failure.New(failure.Conflict, "the incident has already been delivered")
+
+// Delivery is an incident that has been turned into a review.
+type Delivery struct {
+	Source     string
+	UpstreamID string
+	ReviewID   uuid.UUID
+	ReceivedAt time.Time
+}
+
+type DeliveryStorage interface {
+	// Claim stores the delivery unless the incident was delivered from the source before,
+	// then it returns the earlier delivery and ErrDuplicateDelivery.
+	Claim(ctx context.Context, d Delivery) (Delivery, error)
+
+	// Release forgets the delivery, so the incident can be delivered again.
+	Release(ctx context.Context, source, upstreamID string) error
+}
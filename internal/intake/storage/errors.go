@@ -0,0 +1,6 @@
+package storage
+
+import "github.com/gaqzi/incident-reviewer/internal/platform/failure"
+
+// ErrNoUpstreamID indicates the delivery doesn't say which source and incident it's for.
+var ErrNoUpstreamID = failure.New(failure.Invalid, "can't store delivery without a source and upstream ID")
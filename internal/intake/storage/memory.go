@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gaqzi/incident-reviewer/internal/intake"
+)
+
+type deliveryKey struct {
+	source     string
+	upstreamID string
+}
+
+type DeliveryMemoryStore struct {
+	mu   sync.Mutex
+	data map[deliveryKey]intake.Delivery
+}
+
+func NewDeliveryMemoryStore() *DeliveryMemoryStore {
+	return &DeliveryMemoryStore{
+		data: make(map[deliveryKey]intake.Delivery),
+	}
+}
+
+func (s *DeliveryMemoryStore) Claim(_ context.Context, d intake.Delivery) (intake.Delivery, error) {
+	if d.Source == "" || d.UpstreamID == "" {
+		return intake.Delivery{}, ErrNoUpstreamID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := deliveryKey{source: d.Source, upstreamID: d.UpstreamID}
+	if earlier, ok := s.data[key]; ok {
+		return earlier, intake.ErrDuplicateDelivery
+	}
+	s.data[key] = d
+
+	return d, nil
+}
+
+func (s *DeliveryMemoryStore) Release(_ context.Context, source, upstreamID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, deliveryKey{source: source, upstreamID: upstreamID})
+
+	return nil
+}
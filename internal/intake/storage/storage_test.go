@@ -0,0 +1,69 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/intake"
+	"github.com/gaqzi/incident-reviewer/internal/intake/storage"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestDeliveryMemoryStore(t *testing.T) {
+	DeliveryStorageTest(t, context.Background(), func() intake.DeliveryStorage { return storage.NewDeliveryMemoryStore() })
+}
+
+// DeliveryStorageTest is a base suite used to test across the implementations of intake.DeliveryStorage.
+func DeliveryStorageTest(t *testing.T, ctx context.Context, storeFactory func() intake.DeliveryStorage) {
+	delivery := func() intake.Delivery {
+		return intake.Delivery{Source: "pagerduty", UpstreamID: "P123", ReviewID: a.UUID(), ReceivedAt: time.Now()}
+	}
+
+	t.Run("Claim", func(t *testing.T) {
+		t.Run("returns an error when the source or upstream ID isn't set", func(t *testing.T) {
+			_, err := storeFactory().Claim(ctx, intake.Delivery{Source: "pagerduty"})
+
+			require.ErrorIs(t, err, storage.ErrNoUpstreamID)
+		})
+
+		t.Run("returns the earlier delivery when the incident was delivered before", func(t *testing.T) {
+			store := storeFactory()
+			first := delivery()
+			_, err := store.Claim(ctx, first)
+			require.NoError(t, err)
+
+			earlier, err := store.Claim(ctx, delivery())
+
+			require.ErrorIs(t, err, intake.ErrDuplicateDelivery)
+			require.Equal(t, first.ReviewID, earlier.ReviewID)
+		})
+
+		t.Run("the same upstream ID from another source isn't a duplicate", func(t *testing.T) {
+			store := storeFactory()
+			_, err := store.Claim(ctx, delivery())
+			require.NoError(t, err)
+			other := delivery()
+			other.Source = "incident.io"
+
+			_, err = store.Claim(ctx, other)
+
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		t.Run("a released delivery can be claimed again", func(t *testing.T) {
+			store := storeFactory()
+			_, err := store.Claim(ctx, delivery())
+			require.NoError(t, err)
+
+			require.NoError(t, store.Release(ctx, "pagerduty", "P123"))
+			_, err = store.Claim(ctx, delivery())
+
+			require.NoError(t, err)
+		})
+	})
+}
@@ -3,10 +3,15 @@ package contributing
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
 	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
 )
 
@@ -15,7 +20,11 @@ type Cause struct {
 	Name        string    `validate:"required"`
 	Description string    `validate:"required"`
 	Category    string    `validate:"required"`
+	// TeamID is the team the cause is for, uuid.Nil when it's shared by all teams.
+	TeamID uuid.UUID
 
+	CreatedBy uuid.UUID
+	UpdatedBy uuid.UUID
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -24,6 +33,16 @@ func NewCause() Cause {
 	return Cause{ID: uuid.Must(uuid.NewV7())}
 }
 
+// updateChangedBy records who is saving, and if it's the first save who created it.
+func (cc Cause) updateChangedBy(by uuid.UUID) Cause {
+	if cc.CreatedAt.IsZero() {
+		cc.CreatedBy = by
+	}
+	cc.UpdatedBy = by
+
+	return cc
+}
+
 func (cc Cause) updateTimestamps() Cause {
 	now := time.Now()
 	if cc.CreatedAt.IsZero() {
@@ -35,24 +54,61 @@ func (cc Cause) updateTimestamps() Cause {
 }
 
 type CauseService struct {
-	store CauseStorage
+	store  CauseStorage
+	events event.Publisher
+}
+
+type CauseOption func(s *CauseService)
+
+// WithCauseEvents publishes a normalized.CatalogEntryChanged for every cause that's saved.
+func WithCauseEvents(p event.Publisher) CauseOption {
+	return func(s *CauseService) {
+		s.events = p
+	}
 }
 
-func NewCauseService(store CauseStorage) *CauseService {
-	return &CauseService{store: store}
+func NewCauseService(store CauseStorage, opts ...CauseOption) *CauseService {
+	s := &CauseService{store: store, events: event.Discard}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
+// Save validates and stores the cause, only curators are allowed to change the catalog,
+// and only admins the part shared by all teams when working as a team.
 func (s *CauseService) Save(ctx context.Context, cc Cause) (Cause, error) {
+	if err := actor.Require(ctx, actor.Curator); err != nil {
+		return cc, fmt.Errorf("only curators can change the catalog: %w", err)
+	}
+	if cc.TeamID == uuid.Nil && tenant.ID(ctx) != uuid.Nil {
+		if err := actor.Require(ctx, actor.Admin); err != nil {
+			return cc, fmt.Errorf("only admins can change the catalog shared by all teams: %w", err)
+		}
+	}
+
 	if err := validate.Struct(ctx, cc); err != nil {
 		return cc, fmt.Errorf("failed to validate contributing cause: %w", err)
 	}
 
-	cc = cc.updateTimestamps()
+	cc = cc.updateChangedBy(actor.ID(ctx)).updateTimestamps()
 
 	cc, err := s.store.Save(ctx, cc)
 	if err != nil {
 		return cc, fmt.Errorf("failed to store contributing cause: %w", err)
 	}
+	// The cause has been saved, so a failure to publish doesn't fail the save, see @techdebt(2)
+	if err := s.events.Publish(ctx, normalized.CatalogEntryChanged{
+		Catalog:     normalized.CatalogContributingCauses,
+		ID:          cc.ID,
+		Name:        cc.Name,
+		Description: cc.Description,
+		Category:    cc.Category,
+		TeamID:      cc.TeamID,
+	}); err != nil {
+		slog.Error("failed to publish event", "event", "CatalogEntryChanged", "causeID", cc.ID, "error", err)
+	}
 
 	return cc, nil
 }
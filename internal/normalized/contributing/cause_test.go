@@ -10,10 +10,25 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
 	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
 	"github.com/gaqzi/incident-reviewer/test/a"
 )
 
+// recordingPublisher keeps the events it's told to publish.
+type recordingPublisher struct {
+	events []event.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, events ...event.Event) error {
+	p.events = append(p.events, events...)
+
+	return nil
+}
+
 type causeStorageMock struct {
 	mock.Mock
 }
@@ -33,7 +48,30 @@ func (m *causeStorageMock) All(ctx context.Context) ([]contributing.Cause, error
 	return args.Get(0).([]contributing.Cause), args.Error(1)
 }
 
+// curatorCtx is someone allowed to change the catalogs.
+var curatorCtx = actor.With(context.Background(), a.Actor().Build())
+
 func TestContributingCauseService_Save(t *testing.T) {
+	t.Run("only curators are allowed to change the catalog", func(t *testing.T) {
+		service := contributing.NewCauseService(nil)
+
+		_, err := service.Save(context.Background(), a.ContributingCause().IsNotSaved().Build())
+		require.ErrorIs(t, err, actor.ErrForbidden, "expected nobody to not be allowed")
+
+		_, err = service.Save(actor.With(context.Background(), a.Actor().WithRole(actor.RoleContributor).Build()), a.ContributingCause().IsNotSaved().Build())
+		require.ErrorIs(t, err, actor.ErrForbidden, "expected a contributor to not be allowed")
+	})
+
+	t.Run("only admins can change what's shared by all teams when working as a team", func(t *testing.T) {
+		team := a.UUID()
+		service := contributing.NewCauseService(nil)
+		curator := actor.With(context.Background(), a.Actor().Build())
+
+		_, err := service.Save(tenant.With(curator, team), a.ContributingCause().IsNotSaved().Build())
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
 	t.Run("sets the Created and Updated at when they're not set", func(t *testing.T) {
 		storage := new(causeStorageMock)
 		storage.Test(t)
@@ -44,11 +82,33 @@ func TestContributingCauseService_Save(t *testing.T) {
 			Return(contributing.Cause{}, nil)
 		service := contributing.NewCauseService(storage)
 
-		_, err := service.Save(context.Background(), a.ContributingCause().IsNotSaved().Build())
+		_, err := service.Save(curatorCtx, a.ContributingCause().IsNotSaved().Build())
 
 		require.NoError(t, err)
 	})
 
+	t.Run("records who created it on the first save and who changed it on every save", func(t *testing.T) {
+		creator, changer := a.UUID(), a.UUID()
+		storage := new(causeStorageMock)
+		storage.Test(t)
+		storage.
+			On("Save", mock.Anything, mock.MatchedBy(func(c contributing.Cause) bool { return c.UpdatedBy == creator })).
+			Return(contributing.Cause{}, nil).
+			Once()
+		storage.
+			On("Save", mock.Anything, mock.MatchedBy(func(c contributing.Cause) bool { return c.CreatedBy == uuid.Nil && c.UpdatedBy == changer })).
+			Return(contributing.Cause{}, nil).
+			Once()
+		service := contributing.NewCauseService(storage)
+
+		_, err := service.Save(actor.With(context.Background(), a.Actor().WithID(creator).Build()), a.ContributingCause().IsNotSaved().Build())
+		require.NoError(t, err)
+		_, err = service.Save(actor.With(context.Background(), a.Actor().WithID(changer).Build()), a.ContributingCause().IsSaved().Build())
+		require.NoError(t, err)
+
+		storage.AssertExpectations(t)
+	})
+
 	t.Run("sets the UpdatedAt when updating a previously saved item", func(t *testing.T) {
 		storage := new(causeStorageMock)
 		storage.Test(t)
@@ -59,7 +119,7 @@ func TestContributingCauseService_Save(t *testing.T) {
 			Return(contributing.Cause{}, nil)
 		service := contributing.NewCauseService(storage)
 
-		_, err := service.Save(context.Background(), a.ContributingCause().IsSaved().Build())
+		_, err := service.Save(curatorCtx, a.ContributingCause().IsSaved().Build())
 
 		require.NoError(t, err)
 	})
@@ -67,7 +127,7 @@ func TestContributingCauseService_Save(t *testing.T) {
 	t.Run("validate the Cause object before saving", func(t *testing.T) {
 		service := contributing.NewCauseService(nil)
 
-		_, actual := service.Save(context.Background(), contributing.Cause{})
+		_, actual := service.Save(curatorCtx, contributing.Cause{})
 
 		require.Error(t, actual)
 		require.ErrorContains(t, actual, "failed to validate contributing cause:")
@@ -83,7 +143,7 @@ func TestContributingCauseService_Save(t *testing.T) {
 			Return(contributing.Cause{}, errors.New("uh-oh"))
 		service := contributing.NewCauseService(storage)
 
-		_, err := service.Save(context.Background(), a.ContributingCause().Build())
+		_, err := service.Save(curatorCtx, a.ContributingCause().Build())
 
 		require.Error(t, err, "expected to have failed when the underlying storage always fails")
 		require.ErrorContains(t, err, "failed to store contributing cause:")
@@ -96,11 +156,32 @@ func TestContributingCauseService_Save(t *testing.T) {
 			Return(a.ContributingCause().Build(), nil)
 		service := contributing.NewCauseService(storage)
 
-		actual, err := service.Save(context.Background(), a.ContributingCause().IsNotSaved().Build())
+		actual, err := service.Save(curatorCtx, a.ContributingCause().IsNotSaved().Build())
 
 		require.NoError(t, err)
 		require.Equal(t, a.ContributingCause().Build(), actual)
 	})
+
+	t.Run("publishes that the catalog changed for the saved cause but not for failed saves", func(t *testing.T) {
+		published := new(recordingPublisher)
+		storage := new(causeStorageMock)
+		storage.Test(t)
+		storage.On("Save", mock.Anything, mock.Anything).
+			Return(a.ContributingCause().Build(), nil).Once()
+		storage.On("Save", mock.Anything, mock.Anything).
+			Return(contributing.Cause{}, errors.New("uh-oh"))
+		service := contributing.NewCauseService(storage, contributing.WithCauseEvents(published))
+
+		_, err := service.Save(curatorCtx, a.ContributingCause().IsNotSaved().Build())
+		require.NoError(t, err)
+		_, err = service.Save(curatorCtx, a.ContributingCause().IsNotSaved().Build())
+		require.Error(t, err)
+
+		require.Len(t, published.events, 1)
+		changed := published.events[0].(normalized.CatalogEntryChanged)
+		require.Equal(t, normalized.CatalogContributingCauses, changed.Catalog)
+		require.Equal(t, a.ContributingCause().Build().ID, changed.ID)
+	})
 }
 
 func TestContributingCauseService_Get(t *testing.T) {
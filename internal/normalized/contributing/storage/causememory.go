@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
 )
 
 // TODO: refactor into a generic implementation because the logic is the same across this one and reviewing/storage.MemoryStore.
@@ -22,26 +23,32 @@ func NewCauseMemoryStore() *CauseMemoryStore {
 	}
 }
 
-func (s *CauseMemoryStore) Get(_ context.Context, id uuid.UUID) (contributing.Cause, error) {
+func (s *CauseMemoryStore) Get(ctx context.Context, id uuid.UUID) (contributing.Cause, error) {
 	cause, ok := s.data[id]
-	if !ok {
+	if !ok || !tenant.Shares(ctx, cause.TeamID) {
 		return contributing.Cause{}, &NoCauseError{ID: id}
 	}
 
 	return cause, nil
 }
 
-func (s *CauseMemoryStore) Save(_ context.Context, cause contributing.Cause) (contributing.Cause, error) {
+func (s *CauseMemoryStore) Save(ctx context.Context, cause contributing.Cause) (contributing.Cause, error) {
 	if cause.ID == uuid.Nil {
 		return contributing.Cause{}, ErrNoID
 	}
+	if !tenant.Shares(ctx, cause.TeamID) {
+		return contributing.Cause{}, ErrOtherTeam
+	}
+	if stored, ok := s.data[cause.ID]; ok && !tenant.Shares(ctx, stored.TeamID) {
+		return contributing.Cause{}, &NoCauseError{ID: cause.ID}
+	}
 
 	s.data[cause.ID] = cause
 
 	return cause, nil
 }
 
-func (s *CauseMemoryStore) All(_ context.Context) ([]contributing.Cause, error) {
+func (s *CauseMemoryStore) All(ctx context.Context) ([]contributing.Cause, error) {
 	ret := make([]contributing.Cause, 0, len(s.data))
 
 	// Sort all the keys for the store, which returns keys in a non-deterministic order,
@@ -61,7 +68,9 @@ func (s *CauseMemoryStore) All(_ context.Context) ([]contributing.Cause, error)
 	slices.Reverse(keys)
 
 	for _, r := range keys {
-		ret = append(ret, s.data[r])
+		if tenant.Shares(ctx, s.data[r].TeamID) {
+			ret = append(ret, s.data[r])
+		}
 	}
 
 	return ret, nil
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
 )
 
 type NoCauseError struct {
@@ -12,16 +14,29 @@ type NoCauseError struct {
 }
 
 func (e *NoCauseError) Error() string {
-	return fmt.Sprintf("contributing cause not found by id: %d", e.ID)
+	return fmt.Sprintf("contributing cause not found by id: %s", e.ID)
+}
+
+// Is makes it a failure.NotFound.
+func (e *NoCauseError) Is(target error) bool {
+	return target == failure.NotFound
 }
 
 // ErrNoID indicates that the passed in uuid ID is blank/uninitialized.
 var ErrNoID = errors.New("can't store contributing cause because ID is not set")
 
+// ErrOtherTeam indicates that the catalog entry is for another team than the one being worked as.
+var ErrOtherTeam = failure.New(failure.Forbidden, "can't store catalog entry for another team")
+
 type NoTriggerError struct {
 	ID uuid.UUID
 }
 
 func (e *NoTriggerError) Error() string {
-	return fmt.Sprintf("trigger not found by id: %d", e.ID)
+	return fmt.Sprintf("trigger not found by id: %s", e.ID)
+}
+
+// Is makes it a failure.NotFound.
+func (e *NoTriggerError) Is(target error) bool {
+	return target == failure.NotFound
 }
@@ -0,0 +1,33 @@
+package normalized
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+)
+
+// Catalog is which catalog an entry is in.
+type Catalog string
+
+const (
+	CatalogContributingCauses Catalog = "contributing_causes"
+	CatalogTriggers           Catalog = "triggers"
+)
+
+// CatalogEntryChanged is published after an entry in one of the catalogs has been saved, with the entry as it was saved.
+type CatalogEntryChanged struct {
+	Catalog     Catalog
+	ID          uuid.UUID
+	Name        string
+	Description string
+	// Category is only set for contributing causes.
+	Category string
+	// TeamID is the team the entry is for, uuid.Nil when it's shared by all teams.
+	TeamID uuid.UUID
+}
+
+func (CatalogEntryChanged) EventName() string { return "normalized.CatalogEntryChanged" }
+
+func init() {
+	event.Register[CatalogEntryChanged]()
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/gaqzi/incident-reviewer/internal/normalized/storage"
 
 	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
 	"github.com/gaqzi/incident-reviewer/test/a"
 )
 
@@ -49,6 +51,7 @@ func TriggerStorageTest(t *testing.T, ctx context.Context, storeFactory func() n
 
 			var actualErr *storage2.NoTriggerError
 			require.ErrorAs(t, err, &actualErr, "expected the specific error for not found")
+			require.ErrorIs(t, err, failure.NotFound, "expected it to be a not found failure")
 		})
 
 		t.Run("after saving, gets back the same object as save when asking by ID", func(t *testing.T) {
@@ -111,4 +114,26 @@ func TriggerStorageTest(t *testing.T, ctx context.Context, storeFactory func() n
 			)
 		})
 	})
+	t.Run("only sees what's shared and the current team's", func(t *testing.T) {
+		store := storeFactory()
+		team, other := a.UUID(), a.UUID()
+		teamCtx := tenant.With(ctx, team)
+		shared, err := store.Save(ctx, a.NormalizedTrigger().WithID(a.UUID()).Build())
+		require.NoError(t, err)
+		ours, err := store.Save(teamCtx, a.NormalizedTrigger().WithID(a.UUID()).WithTeam(team).Build())
+		require.NoError(t, err)
+		theirs, err := store.Save(tenant.With(ctx, other), a.NormalizedTrigger().WithID(a.UUID()).WithTeam(other).Build())
+		require.NoError(t, err)
+
+		actual, err := store.All(teamCtx)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []normalized.Trigger{ours, shared}, actual)
+
+		_, err = store.Get(teamCtx, theirs.ID)
+		var notFound *storage2.NoTriggerError
+		require.ErrorAs(t, err, &notFound, "expected another team's to not be found")
+
+		_, err = store.Save(teamCtx, theirs)
+		require.ErrorIs(t, err, storage2.ErrOtherTeam, "expected to not be able to save for another team")
+	})
 }
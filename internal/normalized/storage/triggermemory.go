@@ -9,6 +9,7 @@ import (
 
 	"github.com/gaqzi/incident-reviewer/internal/normalized"
 	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing/storage"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
 )
 
 type TriggerMemoryStore struct {
@@ -21,25 +22,31 @@ func NewTriggerMemoryStore() *TriggerMemoryStore {
 	}
 }
 
-func (s *TriggerMemoryStore) Get(_ context.Context, id uuid.UUID) (normalized.Trigger, error) {
+func (s *TriggerMemoryStore) Get(ctx context.Context, id uuid.UUID) (normalized.Trigger, error) {
 	trigger, ok := s.data[id]
-	if !ok {
+	if !ok || !tenant.Shares(ctx, trigger.TeamID) {
 		return normalized.Trigger{}, &storage.NoTriggerError{ID: id}
 	}
 	return trigger, nil
 }
 
-func (s *TriggerMemoryStore) Save(_ context.Context, trigger normalized.Trigger) (normalized.Trigger, error) {
+func (s *TriggerMemoryStore) Save(ctx context.Context, trigger normalized.Trigger) (normalized.Trigger, error) {
 	if trigger.ID == uuid.Nil {
 		return normalized.Trigger{}, storage.ErrNoID
 	}
+	if !tenant.Shares(ctx, trigger.TeamID) {
+		return normalized.Trigger{}, storage.ErrOtherTeam
+	}
+	if stored, ok := s.data[trigger.ID]; ok && !tenant.Shares(ctx, stored.TeamID) {
+		return normalized.Trigger{}, &storage.NoTriggerError{ID: trigger.ID}
+	}
 
 	s.data[trigger.ID] = trigger
 
 	return trigger, nil
 }
 
-func (s *TriggerMemoryStore) All(_ context.Context) ([]normalized.Trigger, error) {
+func (s *TriggerMemoryStore) All(ctx context.Context) ([]normalized.Trigger, error) {
 	ret := make([]normalized.Trigger, 0, len(s.data))
 
 	// Sort all the keys for the store, which returns keys in a non-deterministic order,
@@ -59,7 +66,9 @@ func (s *TriggerMemoryStore) All(_ context.Context) ([]normalized.Trigger, error
 	slices.Reverse(keys)
 
 	for _, r := range keys {
-		ret = append(ret, s.data[r])
+		if tenant.Shares(ctx, s.data[r].TeamID) {
+			ret = append(ret, s.data[r])
+		}
 	}
 
 	return ret, nil
@@ -3,10 +3,14 @@ package normalized
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
 	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
 )
 
@@ -14,11 +18,25 @@ type Trigger struct {
 	ID          uuid.UUID `validate:"required"`
 	Name        string    `validate:"required"`
 	Description string    `validate:"required"`
+	// TeamID is the team the trigger is for, uuid.Nil when it's shared by all teams.
+	TeamID uuid.UUID
 
+	CreatedBy uuid.UUID
+	UpdatedBy uuid.UUID
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// updateChangedBy records who is saving, and if it's the first save who created it.
+func (t Trigger) updateChangedBy(by uuid.UUID) Trigger {
+	if t.CreatedAt.IsZero() {
+		t.CreatedBy = by
+	}
+	t.UpdatedBy = by
+
+	return t
+}
+
 func (t Trigger) updateTimestamps() Trigger {
 	now := time.Now()
 	if t.CreatedAt.IsZero() {
@@ -34,24 +52,60 @@ func NewTrigger() Trigger {
 }
 
 type TriggerService struct {
-	store TriggerStorage
+	store  TriggerStorage
+	events event.Publisher
+}
+
+type TriggerOption func(s *TriggerService)
+
+// WithTriggerEvents publishes a CatalogEntryChanged for every trigger that's saved.
+func WithTriggerEvents(p event.Publisher) TriggerOption {
+	return func(s *TriggerService) {
+		s.events = p
+	}
 }
 
-func NewTriggerService(store TriggerStorage) *TriggerService {
-	return &TriggerService{store}
+func NewTriggerService(store TriggerStorage, opts ...TriggerOption) *TriggerService {
+	s := &TriggerService{store: store, events: event.Discard}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
+// Save validates and stores the trigger, only curators are allowed to change the catalog,
+// and only admins the part shared by all teams when working as a team.
 func (s *TriggerService) Save(ctx context.Context, t Trigger) (Trigger, error) {
+	if err := actor.Require(ctx, actor.Curator); err != nil {
+		return t, fmt.Errorf("only curators can change the catalog: %w", err)
+	}
+	if t.TeamID == uuid.Nil && tenant.ID(ctx) != uuid.Nil {
+		if err := actor.Require(ctx, actor.Admin); err != nil {
+			return t, fmt.Errorf("only admins can change the catalog shared by all teams: %w", err)
+		}
+	}
+
 	if err := validate.Struct(ctx, t); err != nil {
 		return t, fmt.Errorf("failed to validate trigger: %w", err)
 	}
 
-	t = t.updateTimestamps()
+	t = t.updateChangedBy(actor.ID(ctx)).updateTimestamps()
 
 	t, err := s.store.Save(ctx, t)
 	if err != nil {
 		return t, fmt.Errorf("failed to store normalized trigger: %w", err)
 	}
+	// The trigger has been saved, so a failure to publish doesn't fail the save, see @techdebt(2)
+	if err := s.events.Publish(ctx, CatalogEntryChanged{
+		Catalog:     CatalogTriggers,
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		TeamID:      t.TeamID,
+	}); err != nil {
+		slog.Error("failed to publish event", "event", "CatalogEntryChanged", "triggerID", t.ID, "error", err)
+	}
 
 	return t, nil
 }
@@ -11,9 +11,23 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
 	"github.com/gaqzi/incident-reviewer/test/a"
 )
 
+// recordingPublisher keeps the events it's told to publish.
+type recordingPublisher struct {
+	events []event.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, events ...event.Event) error {
+	p.events = append(p.events, events...)
+
+	return nil
+}
+
 type triggerStorageMock struct {
 	mock.Mock
 }
@@ -33,7 +47,30 @@ func (m *triggerStorageMock) All(ctx context.Context) ([]normalized.Trigger, err
 	return args.Get(0).([]normalized.Trigger), args.Error(1)
 }
 
+// curatorCtx is someone allowed to change the catalogs.
+var curatorCtx = actor.With(context.Background(), a.Actor().Build())
+
 func TestNormalizedTriggerService_Save(t *testing.T) {
+	t.Run("only curators are allowed to change the catalog", func(t *testing.T) {
+		service := normalized.NewTriggerService(nil)
+
+		_, err := service.Save(context.Background(), a.NormalizedTrigger().IsNotSaved().Build())
+		require.ErrorIs(t, err, actor.ErrForbidden, "expected nobody to not be allowed")
+
+		_, err = service.Save(actor.With(context.Background(), a.Actor().WithRole(actor.RoleContributor).Build()), a.NormalizedTrigger().IsNotSaved().Build())
+		require.ErrorIs(t, err, actor.ErrForbidden, "expected a contributor to not be allowed")
+	})
+
+	t.Run("only admins can change what's shared by all teams when working as a team", func(t *testing.T) {
+		team := a.UUID()
+		service := normalized.NewTriggerService(nil)
+		curator := actor.With(context.Background(), a.Actor().Build())
+
+		_, err := service.Save(tenant.With(curator, team), a.NormalizedTrigger().IsNotSaved().Build())
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
 	t.Run("sets the Created and Updated at when they're not set", func(t *testing.T) {
 		storage := new(triggerStorageMock)
 		storage.Test(t)
@@ -44,11 +81,33 @@ func TestNormalizedTriggerService_Save(t *testing.T) {
 			Return(normalized.Trigger{}, nil)
 		service := normalized.NewTriggerService(storage)
 
-		_, err := service.Save(context.Background(), a.NormalizedTrigger().IsNotSaved().Build())
+		_, err := service.Save(curatorCtx, a.NormalizedTrigger().IsNotSaved().Build())
 
 		require.NoError(t, err)
 	})
 
+	t.Run("records who created it on the first save and who changed it on every save", func(t *testing.T) {
+		creator, changer := a.UUID(), a.UUID()
+		storage := new(triggerStorageMock)
+		storage.Test(t)
+		storage.
+			On("Save", mock.Anything, mock.MatchedBy(func(c normalized.Trigger) bool { return c.UpdatedBy == creator })).
+			Return(normalized.Trigger{}, nil).
+			Once()
+		storage.
+			On("Save", mock.Anything, mock.MatchedBy(func(c normalized.Trigger) bool { return c.CreatedBy == uuid.Nil && c.UpdatedBy == changer })).
+			Return(normalized.Trigger{}, nil).
+			Once()
+		service := normalized.NewTriggerService(storage)
+
+		_, err := service.Save(actor.With(context.Background(), a.Actor().WithID(creator).Build()), a.NormalizedTrigger().IsNotSaved().Build())
+		require.NoError(t, err)
+		_, err = service.Save(actor.With(context.Background(), a.Actor().WithID(changer).Build()), a.NormalizedTrigger().IsSaved().Build())
+		require.NoError(t, err)
+
+		storage.AssertExpectations(t)
+	})
+
 	t.Run("sets the UpdatedAt when updating a previously saved item", func(t *testing.T) {
 		storage := new(triggerStorageMock)
 		storage.Test(t)
@@ -59,7 +118,7 @@ func TestNormalizedTriggerService_Save(t *testing.T) {
 			Return(normalized.Trigger{}, nil)
 		service := normalized.NewTriggerService(storage)
 
-		_, err := service.Save(context.Background(), a.NormalizedTrigger().IsSaved().Build())
+		_, err := service.Save(curatorCtx, a.NormalizedTrigger().IsSaved().Build())
 
 		require.NoError(t, err)
 	})
@@ -67,7 +126,7 @@ func TestNormalizedTriggerService_Save(t *testing.T) {
 	t.Run("validate the Trigger object before saving", func(t *testing.T) {
 		service := normalized.NewTriggerService(nil)
 
-		_, actual := service.Save(context.Background(), normalized.Trigger{})
+		_, actual := service.Save(curatorCtx, normalized.Trigger{})
 
 		require.Error(t, actual)
 		require.ErrorContains(t, actual, "failed to validate trigger:")
@@ -83,7 +142,7 @@ func TestNormalizedTriggerService_Save(t *testing.T) {
 			Return(normalized.Trigger{}, errors.New("uh-oh"))
 		service := normalized.NewTriggerService(storage)
 
-		_, err := service.Save(context.Background(), a.NormalizedTrigger().Build())
+		_, err := service.Save(curatorCtx, a.NormalizedTrigger().Build())
 
 		require.Error(t, err, "expected to have failed when the underlying storage always fails")
 		require.ErrorContains(t, err, "failed to store normalized trigger:")
@@ -96,11 +155,32 @@ func TestNormalizedTriggerService_Save(t *testing.T) {
 			Return(a.NormalizedTrigger().Build(), nil)
 		service := normalized.NewTriggerService(storage)
 
-		actual, err := service.Save(context.Background(), a.NormalizedTrigger().IsNotSaved().Build())
+		actual, err := service.Save(curatorCtx, a.NormalizedTrigger().IsNotSaved().Build())
 
 		require.NoError(t, err)
 		require.Equal(t, a.NormalizedTrigger().Build(), actual)
 	})
+
+	t.Run("publishes that the catalog changed for the saved trigger but not for failed saves", func(t *testing.T) {
+		published := new(recordingPublisher)
+		storage := new(triggerStorageMock)
+		storage.Test(t)
+		storage.On("Save", mock.Anything, mock.Anything).
+			Return(a.NormalizedTrigger().Build(), nil).Once()
+		storage.On("Save", mock.Anything, mock.Anything).
+			Return(normalized.Trigger{}, errors.New("uh-oh"))
+		service := normalized.NewTriggerService(storage, normalized.WithTriggerEvents(published))
+
+		_, err := service.Save(curatorCtx, a.NormalizedTrigger().IsNotSaved().Build())
+		require.NoError(t, err)
+		_, err = service.Save(curatorCtx, a.NormalizedTrigger().IsNotSaved().Build())
+		require.Error(t, err)
+
+		require.Len(t, published.events, 1)
+		changed := published.events[0].(normalized.CatalogEntryChanged)
+		require.Equal(t, normalized.CatalogTriggers, changed.Catalog)
+		require.Equal(t, a.NormalizedTrigger().Build().ID, changed.ID)
+	})
 }
 
 func TestNormalizedTriggerService_Get(t *testing.T) {
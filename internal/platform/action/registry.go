@@ -0,0 +1,174 @@
+// Package action provides "dynamic" injection of actions in services.
+// The idea is to make sure your service objects keep doing collaboration while also
+// giving them a way to declare how they want to collaborate with their aggregate roots,
+// which perform business logic, and may need to be called to let the service object
+// perform their various actions.
+// So Instead of forcing each caller to keep track of how to perform all the steps of the
+// action, we can name it, provide a default, and use that during normal execution, and
+// then provide a mocked version in testing.
+package action
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Key names an action and the type of the function performing it, so only a function of that type can be set for it.
+type Key[F any] struct {
+	name string
+}
+
+func NewKey[F any](name string) Key[F] {
+	return Key[F]{name: name}
+}
+
+func (k Key[F]) Name() string {
+	return k.name
+}
+
+// check returns an error unless the registry has a function of the key's type for it.
+func (k Key[F]) check(r *Registry) error {
+	fn, ok := r.actions[k.name]
+	if !ok {
+		return fmt.Errorf("no action found for: %s", k.name)
+	}
+	if _, ok := fn.(F); !ok {
+		return fmt.Errorf("action for %s is %T, expected %s", k.name, fn, reflect.TypeFor[F]())
+	}
+	if v := reflect.ValueOf(fn); v.Kind() == reflect.Func && v.IsNil() {
+		return fmt.Errorf("action for %s is nil", k.name)
+	}
+	for _, mw := range r.middleware[k.name] {
+		if _, ok := mw.fn.(Middleware[F]); !ok {
+			return fmt.Errorf("middleware %s for %s is %T, expected %T", mw.name, k.name, mw.fn, Middleware[F](nil))
+		}
+	}
+
+	return nil
+}
+
+// Middleware runs around an action, it can do something before or after calling next,
+// or return an error without calling next to stop the action from happening.
+type Middleware[F any] func(next F) F
+
+type namedMiddleware struct {
+	name string
+	fn   any
+}
+
+// AnyKey is a Key of any type, for checking a registry has all the keys.
+type AnyKey interface {
+	Name() string
+	check(r *Registry) error
+}
+
+// Registry holds the function to perform for each action, and the middleware to run around it.
+type Registry struct {
+	actions    map[string]any
+	middleware map[string][]namedMiddleware
+}
+
+func NewRegistry() *Registry {
+	return &Registry{actions: make(map[string]any), middleware: make(map[string][]namedMiddleware)}
+}
+
+// Set has fn perform the action for the key, replacing what was there before.
+func Set[F any](r *Registry, k Key[F], fn F) *Registry {
+	r.actions[k.name] = fn
+
+	return r
+}
+
+// Use runs mw around the action for the key, the middleware runs in the order it's added
+// so the first one added is the first to run and the last to see what the action returned.
+// The name is used to tell them apart in Pipeline.
+func Use[F any](r *Registry, k Key[F], name string, mw Middleware[F]) *Registry {
+	r.middleware[k.name] = append(r.middleware[k.name], namedMiddleware{name: name, fn: mw})
+
+	return r
+}
+
+// Get returns the function performing the action for the key, wrapped in its middleware,
+// it panics when there isn't one so use Check when creating what uses the registry.
+func Get[F any](r *Registry, k Key[F]) F {
+	if err := k.check(r); err != nil {
+		panic(err)
+	}
+
+	fn := r.actions[k.name].(F)
+	middleware := r.middleware[k.name]
+	for i := len(middleware) - 1; i >= 0; i-- {
+		fn = middleware[i].fn.(Middleware[F])(fn)
+	}
+
+	return fn
+}
+
+// Override returns a new registry with the actions of r replaced by the ones in o,
+// and the middleware of o running after the middleware of r.
+func (r *Registry) Override(o *Registry) *Registry {
+	ret := &Registry{actions: maps.Clone(r.actions), middleware: make(map[string][]namedMiddleware, len(r.middleware))}
+	maps.Copy(ret.actions, o.actions)
+	for name, middleware := range r.middleware {
+		ret.middleware[name] = slices.Clone(middleware)
+	}
+	for name, middleware := range o.middleware {
+		ret.middleware[name] = append(ret.middleware[name], middleware...)
+	}
+
+	return ret
+}
+
+// Check returns an error for every key that doesn't have a function or middleware of its type,
+// and every action or middleware that isn't for one of the keys.
+func (r *Registry) Check(keys ...AnyKey) error {
+	var errs []error
+	known := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		known[k.Name()] = true
+		if err := k.check(r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(r.actions)) {
+		if !known[name] {
+			errs = append(errs, fmt.Errorf("unknown action: %s", name))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(r.middleware)) {
+		if !known[name] {
+			errs = append(errs, fmt.Errorf("middleware for unknown action: %s", name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Pipeline is what runs when an action is performed.
+type Pipeline struct {
+	Action string
+	// Middleware is the name of each middleware in the order they run.
+	Middleware []string
+}
+
+func (p Pipeline) String() string {
+	return strings.Join(append(slices.Clone(p.Middleware), p.Action), " → ")
+}
+
+// All returns the pipeline of each action ordered by the name of the action.
+func (r *Registry) All() []Pipeline {
+	ret := make([]Pipeline, 0, len(r.actions))
+	for _, name := range slices.Sorted(maps.Keys(r.actions)) {
+		p := Pipeline{Action: name}
+		for _, mw := range r.middleware[name] {
+			p.Middleware = append(p.Middleware, mw.name)
+		}
+		ret = append(ret, p)
+	}
+
+	return ret
+}
@@ -0,0 +1,174 @@
+package action_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/action"
+)
+
+type (
+	testStructA struct{}
+	testStructB struct{}
+)
+
+var complexCalculation = action.NewKey[func(testStructA, testStructB) error]("ComplexCalculation")
+
+func TestRegistry(t *testing.T) {
+	t.Run("Get panics when no action is set for the key", func(t *testing.T) {
+		registry := action.NewRegistry()
+
+		require.PanicsWithError(t, "no action found for: ComplexCalculation", func() {
+			action.Get(registry, complexCalculation)
+		})
+	})
+
+	t.Run("Get returns the function set for the key", func(t *testing.T) {
+		registry := action.NewRegistry()
+		action.Set(registry, complexCalculation, func(a testStructA, b testStructB) error { return nil })
+
+		do := action.Get(registry, complexCalculation)
+
+		require.NoError(t, do(testStructA{}, testStructB{}))
+	})
+
+	t.Run("Override replaces the actions without changing the original", func(t *testing.T) {
+		simple := action.NewKey[func() string]("SimpleFunction")
+		defaults := action.NewRegistry()
+		action.Set(defaults, simple, func() string { return "default" })
+		overrides := action.NewRegistry()
+		action.Set(overrides, simple, func() string { return "override" })
+
+		registry := defaults.Override(overrides)
+
+		require.Equal(t, "override", action.Get(registry, simple)())
+		require.Equal(t, "default", action.Get(defaults, simple)())
+	})
+
+	t.Run("Check", func(t *testing.T) {
+		t.Run("returns nothing when every key has an action of its type", func(t *testing.T) {
+			registry := action.NewRegistry()
+			action.Set(registry, complexCalculation, func(a testStructA, b testStructB) error { return nil })
+
+			require.NoError(t, registry.Check(complexCalculation))
+		})
+
+		t.Run("returns an error for missing, nil, mistyped and unknown actions", func(t *testing.T) {
+			nilled := action.NewKey[func()]("Nilled")
+			mistyped := action.NewKey[func() error]("ComplexCalculation")
+			registry := action.NewRegistry()
+			action.Set(registry, mistyped, func() error { return nil })
+			action.Set(registry, nilled, nil)
+			action.Set(registry, action.NewKey[func()]("Unknown"), func() {})
+
+			err := registry.Check(complexCalculation, nilled, action.NewKey[func()]("Missing"))
+
+			require.ErrorContains(t, err, "action for ComplexCalculation is func() error, expected func(action_test.testStructA, action_test.testStructB) error")
+			require.ErrorContains(t, err, "action for Nilled is nil")
+			require.ErrorContains(t, err, "no action found for: Missing")
+			require.ErrorContains(t, err, "unknown action: Unknown")
+		})
+	})
+
+	t.Run("Use", func(t *testing.T) {
+		greet := action.NewKey[func(name string) (string, error)]("Greet")
+		record := func(calls *[]string, name string) action.Middleware[func(string) (string, error)] {
+			return func(next func(string) (string, error)) func(string) (string, error) {
+				return func(n string) (string, error) {
+					*calls = append(*calls, "before "+name)
+					ret, err := next(n)
+					*calls = append(*calls, "after "+name)
+					return ret, err
+				}
+			}
+		}
+
+		t.Run("runs the middleware around the action in the order it was added", func(t *testing.T) {
+			var calls []string
+			registry := action.NewRegistry()
+			action.Set(registry, greet, func(name string) (string, error) {
+				calls = append(calls, "action")
+				return "Hello " + name, nil
+			})
+			action.Use(registry, greet, "first", record(&calls, "first"))
+			action.Use(registry, greet, "second", record(&calls, "second"))
+
+			actual, err := action.Get(registry, greet)("world")
+
+			require.NoError(t, err)
+			require.Equal(t, "Hello world", actual)
+			require.Equal(t, []string{"before first", "before second", "action", "after second", "after first"}, calls)
+		})
+
+		t.Run("middleware can stop the action by returning an error", func(t *testing.T) {
+			var calls []string
+			registry := action.NewRegistry()
+			action.Set(registry, greet, func(name string) (string, error) {
+				calls = append(calls, "action")
+				return "Hello " + name, nil
+			})
+			action.Use(registry, greet, "deny", func(next func(string) (string, error)) func(string) (string, error) {
+				return func(string) (string, error) { return "", errors.New("not allowed") }
+			})
+			action.Use(registry, greet, "record", record(&calls, "record"))
+
+			_, err := action.Get(registry, greet)("world")
+
+			require.EqualError(t, err, "not allowed")
+			require.Empty(t, calls, "expected neither the later middleware nor the action to run")
+		})
+
+		t.Run("Override runs the overriding middleware after the original middleware", func(t *testing.T) {
+			var calls []string
+			defaults := action.NewRegistry()
+			action.Set(defaults, greet, func(name string) (string, error) { return name, nil })
+			action.Use(defaults, greet, "default", record(&calls, "default"))
+			overrides := action.NewRegistry()
+			action.Use(overrides, greet, "override", record(&calls, "override"))
+
+			_, err := action.Get(defaults.Override(overrides), greet)("world")
+			require.NoError(t, err)
+
+			require.Equal(t, []string{"before default", "before override", "after override", "after default"}, calls)
+			require.Equal(t, []action.Pipeline{{Action: "Greet", Middleware: []string{"default"}}}, defaults.All(), "expected the original to be unchanged")
+		})
+
+		t.Run("Check returns an error for middleware that doesn't match the action or isn't for one", func(t *testing.T) {
+			registry := action.NewRegistry()
+			action.Set(registry, greet, func(name string) (string, error) { return name, nil })
+			action.Use(registry, action.NewKey[func()]("Greet"), "mistyped", func(next func()) func() { return next })
+			action.Use(registry, action.NewKey[func()]("Unknown"), "unknown", func(next func()) func() { return next })
+
+			err := registry.Check(greet)
+
+			require.ErrorContains(t, err, "middleware mistyped for Greet is action.Middleware[func()], expected action.Middleware[func(string) (string, error)]")
+			require.ErrorContains(t, err, "middleware for unknown action: Unknown")
+		})
+	})
+
+	t.Run("All returns the pipeline of each stored action ordered by name", func(t *testing.T) {
+		registry := action.NewRegistry()
+		require.Empty(t, registry.All(), "expected a just initialized registry to have nothing to show")
+
+		simple := action.NewKey[func()]("SimpleFunction")
+		action.Set(registry, simple, func() {})
+		action.Set(registry, action.NewKey[func()]("ComplexFunction"), func() {})
+		action.Use(registry, simple, "audit", func(next func()) func() { return next })
+		action.Use(registry, simple, "validate", func(next func()) func() { return next })
+
+		actual := registry.All()
+
+		require.Equal(
+			t,
+			[]action.Pipeline{
+				{Action: "ComplexFunction"},
+				{Action: "SimpleFunction", Middleware: []string{"audit", "validate"}},
+			},
+			actual,
+			"expected the pipeline of each stored action to be returned",
+		)
+		require.Equal(t, "audit → validate → SimpleFunction", actual[1].String())
+	})
+}
@@ -0,0 +1,92 @@
+// Package actor keeps track of who is making a change through the context.
+// It exists so the domain packages can record who did something, and check what they're allowed to do,
+// without knowing about accounts.
+package actor
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+// Role decides what someone is allowed to do across the whole app.
+type Role string
+
+const (
+	// RoleAdmin can do everything, including managing users.
+	RoleAdmin Role = "admin"
+	// RoleFacilitator curates the catalogs and can start reviews, which they then facilitate.
+	RoleFacilitator Role = "facilitator"
+	// RoleContributor can work on the reviews they've been made part of.
+	RoleContributor Role = "contributor"
+	// RoleViewer can only read.
+	RoleViewer Role = "viewer"
+)
+
+// Roles are all the roles from the most to the least allowed.
+var Roles = []Role{RoleAdmin, RoleFacilitator, RoleContributor, RoleViewer}
+
+// IsCurator is true for the roles that can change the catalogs of contributing causes and triggers.
+func (r Role) IsCurator() bool {
+	return r == RoleAdmin || r == RoleFacilitator
+}
+
+// CanContribute is true for the roles that can change anything at all.
+func (r Role) CanContribute() bool {
+	return r == RoleAdmin || r == RoleFacilitator || r == RoleContributor
+}
+
+// ErrForbidden is returned when the actor isn't allowed to do what they tried.
+var ErrForbidden = failure.New(failure.Forbidden, "not allowed")
+
+// Actor is who is making changes.
+type Actor struct {
+	ID   uuid.UUID
+	Role Role
+}
+
+// System is for changes the app makes on its own, like adding the default catalog entries on startup.
+var System = Actor{Role: RoleAdmin}
+
+type ctxKey struct{}
+
+// With returns a context where a is the one making changes.
+func With(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, ctxKey{}, a)
+}
+
+// From returns who is making changes, ok is false when it's not known.
+func From(ctx context.Context) (a Actor, ok bool) {
+	a, ok = ctx.Value(ctxKey{}).(Actor)
+
+	return a, ok
+}
+
+// ID returns who is making changes, or uuid.Nil when it's not known.
+func ID(ctx context.Context) uuid.UUID {
+	a, _ := From(ctx)
+
+	return a.ID
+}
+
+// Require returns ErrForbidden unless there's an actor and allowed says yes for them.
+func Require(ctx context.Context, allowed func(Actor) bool) error {
+	a, ok := From(ctx)
+	if !ok || !allowed(a) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// Curator is used with Require for changes to the catalogs.
+func Curator(a Actor) bool {
+	return a.Role.IsCurator()
+}
+
+// Admin is used with Require for changes only admins can make.
+func Admin(a Actor) bool {
+	return a.Role == RoleAdmin
+}
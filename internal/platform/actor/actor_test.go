@@ -0,0 +1,40 @@
+package actor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+func TestID(t *testing.T) {
+	require.Equal(t, uuid.Nil, actor.ID(context.Background()), "expected nobody when nothing has been set")
+
+	id := uuid.Must(uuid.NewV7())
+	require.Equal(t, id, actor.ID(actor.With(context.Background(), actor.Actor{ID: id, Role: actor.RoleViewer})))
+}
+
+func TestRequire(t *testing.T) {
+	ctx := context.Background()
+
+	require.ErrorIs(t, actor.Require(ctx, actor.Curator), actor.ErrForbidden, "expected nobody to not be allowed anything")
+	require.ErrorIs(t, actor.Require(ctx, actor.Curator), failure.Forbidden)
+
+	for role, allowed := range map[actor.Role]bool{
+		actor.RoleAdmin:       true,
+		actor.RoleFacilitator: true,
+		actor.RoleContributor: false,
+		actor.RoleViewer:      false,
+	} {
+		err := actor.Require(actor.With(ctx, actor.Actor{ID: uuid.Must(uuid.NewV7()), Role: role}), actor.Curator)
+		if allowed {
+			require.NoError(t, err, "expected %s to be a curator", role)
+		} else {
+			require.ErrorIs(t, err, actor.ErrForbidden, "expected %s to not be a curator", role)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package blob keeps files, like what's attached to reviews, outside the stores of what they belong to.
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+// ErrNotFound is returned when nothing is stored by the key.
+var ErrNotFound = failure.New(failure.NotFound, "nothing stored by the key")
+
+// Store keeps the contents of files by a key picked by whoever stores them.
+// Keys are a single name, like a UUID, and not paths.
+type Store interface {
+	// Put stores the contents by the key, replacing what was stored by it before.
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Open returns the contents stored by the key or ErrNotFound, it has to be closed when done.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes what's stored by the key, it's not an error when nothing is.
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryStore keeps everything in memory, it's for tests since it's lost on restart.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	data map[string][]byte
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: make(map[string][]byte)}
+}
+
+func (s *MemoryStore) Put(_ context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read blob %q: %w", key, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = data
+
+	return nil
+}
+
+func (s *MemoryStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, ok := s.data[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+
+	return nil
+}
+
+// FileSystemStore keeps every blob as a file named by its key in a directory.
+// The directory is opened as an os.Root so a key can't reach outside of it.
+type FileSystemStore struct {
+	root *os.Root
+}
+
+// NewFileSystemStore creates the directory if it doesn't exist.
+func NewFileSystemStore(dir string) (*FileSystemStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory %q: %w", dir, err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob directory %q: %w", dir, err)
+	}
+
+	return &FileSystemStore{root: root}, nil
+}
+
+func (s *FileSystemStore) Put(_ context.Context, key string, r io.Reader) error {
+	f, err := s.root.OpenFile(key, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to create blob %q: %w", key, err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		_ = s.root.Remove(key)
+		return fmt.Errorf("failed to write blob %q: %w", key, err)
+	}
+	if err := f.Close(); err != nil {
+		_ = s.root.Remove(key)
+		return fmt.Errorf("failed to write blob %q: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *FileSystemStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	f, err := s.root.Open(key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob %q: %w", key, err)
+	}
+
+	return f, nil
+}
+
+func (s *FileSystemStore) Delete(_ context.Context, key string) error {
+	if err := s.root.Remove(key); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob %q: %w", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package blob_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/blob"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+func StoreTest(t *testing.T, ctx context.Context, store blob.Store) {
+	t.Run("returns what was put", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "a-key", strings.NewReader("hello")))
+
+		actual := read(t, ctx, store, "a-key")
+
+		require.Equal(t, "hello", actual)
+	})
+
+	t.Run("putting by the same key again replaces it", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "replaced", strings.NewReader("a longer first version")))
+		require.NoError(t, store.Put(ctx, "replaced", strings.NewReader("second")))
+
+		require.Equal(t, "second", read(t, ctx, store, "replaced"))
+	})
+
+	t.Run("returns not found for what was never put", func(t *testing.T) {
+		_, err := store.Open(ctx, "never-put")
+
+		require.ErrorIs(t, err, blob.ErrNotFound)
+		require.ErrorIs(t, err, failure.NotFound)
+	})
+
+	t.Run("deleting removes it, and deleting again is fine", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "deleted", strings.NewReader("bye")))
+
+		require.NoError(t, store.Delete(ctx, "deleted"))
+		require.NoError(t, store.Delete(ctx, "deleted"))
+
+		_, err := store.Open(ctx, "deleted")
+		require.ErrorIs(t, err, blob.ErrNotFound)
+	})
+}
+
+func read(t *testing.T, ctx context.Context, store blob.Store, key string) string {
+	t.Helper()
+
+	r, err := store.Open(ctx, key)
+	require.NoError(t, err)
+	defer (func() { _ = r.Close() })()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestMemoryStore(t *testing.T) {
+	StoreTest(t, context.Background(), blob.NewMemoryStore())
+}
+
+func TestFileSystemStore(t *testing.T) {
+	store, err := blob.NewFileSystemStore(t.TempDir())
+	require.NoError(t, err)
+
+	StoreTest(t, context.Background(), store)
+
+	t.Run("keys can't reach outside the directory", func(t *testing.T) {
+		err := store.Put(context.Background(), "../outside", strings.NewReader("nope"))
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,194 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bus publishes events to the outbox and hands them to the subscribers from there, see Run.
+type Bus struct {
+	outbox      OutboxStorage
+	mu          sync.RWMutex
+	subscribers []Subscriber
+	wake        chan struct{}
+	attempts    int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	poll        time.Duration
+}
+
+type BusOption func(b *Bus)
+
+// WithRetries changes how many times a subscriber gets to handle an event before it's given up on,
+// and how long to wait before the first retry, the wait doubles for every retry after that up to maxBackoff.
+func WithRetries(attempts int, backoff, maxBackoff time.Duration) BusOption {
+	return func(b *Bus) {
+		b.attempts = attempts
+		b.backoff = backoff
+		b.maxBackoff = maxBackoff
+	}
+}
+
+// WithPollInterval is how often the outbox is checked for retries that are due.
+func WithPollInterval(d time.Duration) BusOption {
+	return func(b *Bus) {
+		b.poll = d
+	}
+}
+
+func NewBus(outbox OutboxStorage, opts ...BusOption) *Bus {
+	b := &Bus{
+		outbox:     outbox,
+		wake:       make(chan struct{}, 1),
+		attempts:   10,
+		backoff:    time.Second,
+		maxBackoff: 10 * time.Minute,
+		poll:       time.Second,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Subscribe has the subscriber handle every event published after it, subscribe everyone before publishing anything.
+func (b *Bus) Subscribe(s Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, s)
+}
+
+// Publish stores the events in the outbox for each subscriber, they're handled by Run.
+func (b *Bus) Publish(ctx context.Context, events ...Event) error {
+	b.mu.RLock()
+	subscribers := slices.Clone(b.subscribers)
+	b.mu.RUnlock()
+
+	now := time.Now()
+	records := make([]Record, 0, len(events)*len(subscribers))
+	for _, e := range events {
+		payload, err := encode(e)
+		if err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+
+		eventID := uuid.Must(uuid.NewV7())
+		for _, s := range subscribers {
+			records = append(records, Record{
+				ID:            uuid.Must(uuid.NewV7()),
+				EventID:       eventID,
+				Name:          e.EventName(),
+				Payload:       payload,
+				Subscriber:    s.Name(),
+				OccurredAt:    now,
+				NextAttemptAt: now,
+			})
+		}
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	if err := b.outbox.Add(ctx, records...); err != nil {
+		return fmt.Errorf("failed to add events to the outbox: %w", err)
+	}
+
+	select {
+	case b.wake <- struct{}{}:
+	default: // Already woken up
+	}
+
+	return nil
+}
+
+// Run hands the events in the outbox to the subscribers until the context is done,
+// starting with what was left in the outbox the last time it ran.
+func (b *Bus) Run(ctx context.Context) error {
+	ticker := time.NewTicker(b.poll)
+	defer ticker.Stop()
+
+	for {
+		if err := b.Dispatch(ctx); err != nil {
+			slog.Error("failed to dispatch events", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch hands every event that's due to its subscriber once.
+func (b *Bus) Dispatch(ctx context.Context) error {
+	records, err := b.outbox.Pending(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get pending events: %w", err)
+	}
+
+	b.mu.RLock()
+	subscribers := make(map[string]Subscriber, len(b.subscribers))
+	for _, s := range b.subscribers {
+		subscribers[s.Name()] = s
+	}
+	b.mu.RUnlock()
+
+	now := time.Now()
+	for _, r := range records {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if r.NextAttemptAt.After(now) {
+			continue
+		}
+		s, ok := subscribers[r.Subscriber]
+		if !ok {
+			// Left by a subscriber that's no longer around, or one that hasn't subscribed yet
+			continue
+		}
+
+		r = b.handle(ctx, s, r)
+		if _, err := b.outbox.Save(ctx, r); err != nil {
+			return fmt.Errorf("failed to save handled event %s for %s: %w", r.EventID, r.Subscriber, err)
+		}
+	}
+
+	return nil
+}
+
+func (b *Bus) handle(ctx context.Context, s Subscriber, r Record) Record {
+	e, err := decode(r.Name, r.Payload)
+	if err == nil {
+		err = s.Handle(ctx, Envelope{ID: r.EventID, OccurredAt: r.OccurredAt, Attempts: r.Attempts, Event: e})
+	}
+	if err == nil {
+		r.HandledAt = time.Now()
+		return r
+	}
+
+	r.Attempts++
+	r.LastError = err.Error()
+	if r.Attempts >= b.attempts {
+		slog.Error("giving up on event", "eventID", r.EventID, "event", r.Name, "subscriber", r.Subscriber, "attempts", r.Attempts, "error", err)
+		r.FailedAt = time.Now()
+		return r
+	}
+
+	wait := b.backoff << (r.Attempts - 1)
+	if wait > b.maxBackoff || wait <= 0 {
+		wait = b.maxBackoff
+	}
+	r.NextAttemptAt = time.Now().Add(wait)
+	slog.Warn("failed to handle event, retrying", "eventID", r.EventID, "event", r.Name, "subscriber", r.Subscriber, "in", wait, "error", err)
+
+	return r
+}
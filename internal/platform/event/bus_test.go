@@ -0,0 +1,165 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event/storage"
+)
+
+type happened struct {
+	What string
+}
+
+func (happened) EventName() string { return "test.Happened" }
+
+type unregistered struct{}
+
+func (unregistered) EventName() string { return "test.Unregistered" }
+
+func init() {
+	event.Register[happened]()
+}
+
+// subscriber records the events it handles and fails the first `failures` times.
+type subscriber struct {
+	name     string
+	failures int
+
+	mu       sync.Mutex
+	attempts int
+	handled  []event.Envelope
+}
+
+func (s *subscriber) Name() string { return s.name }
+
+func (s *subscriber) Handle(_ context.Context, e event.Envelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("uh-oh")
+	}
+	s.handled = append(s.handled, e)
+
+	return nil
+}
+
+func (s *subscriber) Handled() []event.Envelope {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.handled
+}
+
+func TestBus(t *testing.T) {
+	ctx := context.Background()
+	noWait := event.WithRetries(3, 0, 0)
+
+	t.Run("hands the published events to every subscriber", func(t *testing.T) {
+		bus := event.NewBus(storage.NewOutboxMemoryStore())
+		first, second := &subscriber{name: "first"}, &subscriber{name: "second"}
+		bus.Subscribe(first)
+		bus.Subscribe(second)
+
+		require.NoError(t, bus.Publish(ctx, happened{What: "one"}, happened{What: "two"}))
+		require.NoError(t, bus.Dispatch(ctx))
+
+		require.Len(t, first.Handled(), 2)
+		require.Equal(t, happened{What: "one"}, first.Handled()[0].Event)
+		require.Equal(t, happened{What: "two"}, first.Handled()[1].Event)
+		require.Equal(t, first.Handled(), second.Handled(), "expected both subscribers to get the same events and IDs")
+		require.NotEqual(t, first.Handled()[0].ID, first.Handled()[1].ID)
+	})
+
+	t.Run("only retries the subscriber that failed", func(t *testing.T) {
+		bus := event.NewBus(storage.NewOutboxMemoryStore(), noWait)
+		failing, working := &subscriber{name: "failing", failures: 1}, &subscriber{name: "working"}
+		bus.Subscribe(failing)
+		bus.Subscribe(working)
+		require.NoError(t, bus.Publish(ctx, happened{What: "one"}))
+
+		require.NoError(t, bus.Dispatch(ctx))
+		require.Empty(t, failing.Handled())
+		require.NoError(t, bus.Dispatch(ctx))
+
+		require.Len(t, failing.Handled(), 1)
+		require.Equal(t, 1, failing.Handled()[0].Attempts, "expected to be told about the failed attempt")
+		require.Len(t, working.Handled(), 1, "expected the working subscriber to not get the event again")
+		require.Zero(t, working.Handled()[0].Attempts)
+	})
+
+	t.Run("waits before retrying", func(t *testing.T) {
+		bus := event.NewBus(storage.NewOutboxMemoryStore(), event.WithRetries(3, time.Hour, time.Hour))
+		failing := &subscriber{name: "failing", failures: 1}
+		bus.Subscribe(failing)
+		require.NoError(t, bus.Publish(ctx, happened{What: "one"}))
+
+		require.NoError(t, bus.Dispatch(ctx))
+		require.NoError(t, bus.Dispatch(ctx))
+
+		require.Equal(t, 1, failing.attempts, "expected to not retry before the backoff has passed")
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		outbox := storage.NewOutboxMemoryStore()
+		bus := event.NewBus(outbox, noWait)
+		failing := &subscriber{name: "failing", failures: 10}
+		bus.Subscribe(failing)
+		require.NoError(t, bus.Publish(ctx, happened{What: "one"}))
+
+		for range 5 {
+			require.NoError(t, bus.Dispatch(ctx))
+		}
+
+		require.Equal(t, 3, failing.attempts)
+		pending, err := outbox.Pending(ctx)
+		require.NoError(t, err)
+		require.Empty(t, pending)
+	})
+
+	t.Run("hands what's left in the outbox to the subscribers of a new bus", func(t *testing.T) {
+		outbox := storage.NewOutboxMemoryStore()
+		before := event.NewBus(outbox)
+		before.Subscribe(&subscriber{name: "restarted"})
+		require.NoError(t, before.Publish(ctx, happened{What: "one"}))
+
+		after := event.NewBus(outbox)
+		restarted := &subscriber{name: "restarted"}
+		after.Subscribe(restarted)
+		require.NoError(t, after.Dispatch(ctx))
+
+		require.Len(t, restarted.Handled(), 1)
+	})
+
+	t.Run("Run handles events as they're published until the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		bus := event.NewBus(storage.NewOutboxMemoryStore(), event.WithPollInterval(time.Hour))
+		s := &subscriber{name: "running"}
+		bus.Subscribe(s)
+		done := make(chan error)
+		go func() { done <- bus.Run(ctx) }()
+
+		require.NoError(t, bus.Publish(ctx, happened{What: "one"}))
+
+		require.Eventually(t, func() bool { return len(s.Handled()) == 1 }, time.Second, time.Millisecond)
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+	})
+
+	t.Run("returns an error for events that aren't registered", func(t *testing.T) {
+		bus := event.NewBus(storage.NewOutboxMemoryStore())
+		bus.Subscribe(&subscriber{name: "test"})
+
+		err := bus.Publish(ctx, unregistered{})
+
+		require.ErrorContains(t, err, `event "test.Unregistered" isn't registered`)
+	})
+}
@@ -0,0 +1,95 @@
+// Package event lets services tell the rest of the app what happened, without knowing who wants to know.
+// Events go through an outbox, so a subscriber that fails, or isn't running when the event is published,
+// still gets it later.
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is something that happened, it's stored as JSON in the outbox so it has to survive being encoded and decoded.
+type Event interface {
+	// EventName is unique for every type of event, like "reviewing.ReviewCreated".
+	EventName() string
+}
+
+// Envelope is an event with what's known about when it was published.
+type Envelope struct {
+	// ID is the same every time the event is handled, subscribers can use it to not do the same thing twice.
+	ID         uuid.UUID
+	OccurredAt time.Time
+	// Attempts is how many times handling the event has failed before.
+	Attempts int
+	Event    Event
+}
+
+// Subscriber is told about every event after it's been published.
+// An event is handled again when Handle returns an error, so it can be called more than once for the same event.
+type Subscriber interface {
+	// Name identifies the subscriber in the outbox, so it has to stay the same between restarts.
+	Name() string
+	Handle(ctx context.Context, e Envelope) error
+}
+
+// Publisher is what services publish their events with.
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+type discard struct{}
+
+func (discard) Publish(context.Context, ...Event) error { return nil }
+
+// Discard is a Publisher for when nobody is listening.
+var Discard Publisher = discard{}
+
+var registry = struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+}{types: make(map[string]reflect.Type)}
+
+// Register makes it possible to decode the event from the outbox, every event has to be registered before it's published.
+func Register[E Event]() {
+	var e E
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if t, ok := registry.types[e.EventName()]; ok && t != reflect.TypeFor[E]() {
+		panic(fmt.Sprintf("event %q is already registered as %s", e.EventName(), t))
+	}
+	registry.types[e.EventName()] = reflect.TypeFor[E]()
+}
+
+func encode(e Event) (json.RawMessage, error) {
+	registry.mu.RLock()
+	_, ok := registry.types[e.EventName()]
+	registry.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("event %q isn't registered", e.EventName())
+	}
+
+	return json.Marshal(e)
+}
+
+func decode(name string, payload json.RawMessage) (Event, error) {
+	registry.mu.RLock()
+	t, ok := registry.types[name]
+	registry.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("event %q isn't registered", name)
+	}
+
+	v := reflect.New(t)
+	if err := json.Unmarshal(payload, v.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to decode event %q: %w", name, err)
+	}
+
+	return v.Elem().Interface().(Event), nil
+}
@@ -0,0 +1,43 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Record is an event waiting in the outbox for one subscriber to handle it.
+type Record struct {
+	ID         uuid.UUID
+	EventID    uuid.UUID
+	Name       string
+	Payload    json.RawMessage
+	Subscriber string
+	OccurredAt time.Time
+
+	// Attempts is how many times the subscriber has failed to handle it, and LastError why it failed the last time.
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	// HandledAt is when the subscriber handled it, and FailedAt when it was given up on.
+	HandledAt time.Time
+	FailedAt  time.Time
+}
+
+// IsPending is true until the subscriber has handled the event or it's been given up on.
+func (r Record) IsPending() bool {
+	return r.HandledAt.IsZero() && r.FailedAt.IsZero()
+}
+
+type OutboxStorage interface {
+	// Add stores the records, all of them or none of them.
+	Add(ctx context.Context, records ...Record) error
+
+	// Save stores what happened when handling the record.
+	Save(ctx context.Context, r Record) (Record, error)
+
+	// Pending returns the records that are pending with the oldest first.
+	Pending(ctx context.Context) ([]Record, error)
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+type NoRecordError struct {
+	ID uuid.UUID
+}
+
+func (e *NoRecordError) Error() string {
+	return fmt.Sprintf("outbox record not found by id: %s", e.ID)
+}
+
+// Is makes it a failure.NotFound.
+func (e *NoRecordError) Is(target error) bool {
+	return target == failure.NotFound
+}
+
+// ErrNoID indicates that the passed in ID is blank/uninitialized.
+var ErrNoID = errors.New("can't store outbox record because ID is not set")
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+)
+
+// OutboxFileStore keeps the pending records in a file, so events that haven't been handled survive a restart.
+// The whole file is replaced on every change, which is fine since only what's pending is kept.
+type OutboxFileStore struct {
+	mu   sync.Mutex
+	path string
+	data map[uuid.UUID]event.Record
+}
+
+// NewOutboxFileStore creates the directory if it doesn't exist and loads what was pending when the app stopped.
+func NewOutboxFileStore(dir string) (*OutboxFileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory %q: %w", dir, err)
+	}
+
+	s := &OutboxFileStore{
+		path: filepath.Join(dir, "outbox.json"),
+		data: make(map[uuid.UUID]event.Record),
+	}
+
+	contents, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox %q: %w", s.path, err)
+	}
+
+	var records []event.Record
+	if err := json.Unmarshal(contents, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode outbox %q: %w", s.path, err)
+	}
+	for _, r := range records {
+		s.data[r.ID] = r
+	}
+
+	return s, nil
+}
+
+func (s *OutboxFileStore) Add(_ context.Context, records ...event.Record) error {
+	for _, r := range records {
+		if r.ID == uuid.Nil {
+			return ErrNoID
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := maps.Clone(s.data)
+	for _, r := range records {
+		data[r.ID] = r
+	}
+
+	return s.write(data)
+}
+
+func (s *OutboxFileStore) Save(_ context.Context, r event.Record) (event.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data[r.ID]; !ok {
+		return event.Record{}, &NoRecordError{ID: r.ID}
+	}
+
+	// Nothing more will happen to what's done, so only keep what's pending
+	data := maps.Clone(s.data)
+	if r.IsPending() {
+		data[r.ID] = r
+	} else {
+		delete(data, r.ID)
+	}
+
+	if err := s.write(data); err != nil {
+		return event.Record{}, err
+	}
+
+	return r, nil
+}
+
+func (s *OutboxFileStore) Pending(_ context.Context) ([]event.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return sortedPending(s.data), nil
+}
+
+// write replaces the file with data before keeping it in memory, so the file is never behind what's been returned.
+// The file is written next to the old one and renamed over it, so a crash leaves either the old or the new file.
+func (s *OutboxFileStore) write(data map[uuid.UUID]event.Record) error {
+	contents, err := json.Marshal(sortedPending(data))
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to write outbox %q: %w", tmp, err)
+	}
+	if _, err := f.Write(contents); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write outbox %q: %w", tmp, err)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write outbox %q: %w", tmp, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write outbox %q: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace outbox %q: %w", s.path, err)
+	}
+
+	s.data = data
+	return nil
+}
+
+// sortedPending returns the pending records with the oldest first, the IDs are UUIDv7 and sort by when they were created.
+func sortedPending(data map[uuid.UUID]event.Record) []event.Record {
+	keys := slices.SortedFunc(maps.Keys(data), func(u uuid.UUID, u2 uuid.UUID) int {
+		return bytes.Compare(u[:], u2[:])
+	})
+
+	ret := make([]event.Record, 0, len(keys))
+	for _, k := range keys {
+		if data[k].IsPending() {
+			ret = append(ret, data[k])
+		}
+	}
+
+	return ret
+}
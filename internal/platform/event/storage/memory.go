@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+)
+
+type OutboxMemoryStore struct {
+	mu   sync.Mutex
+	data map[uuid.UUID]event.Record
+}
+
+func NewOutboxMemoryStore() *OutboxMemoryStore {
+	return &OutboxMemoryStore{
+		data: make(map[uuid.UUID]event.Record),
+	}
+}
+
+func (s *OutboxMemoryStore) Add(_ context.Context, records ...event.Record) error {
+	for _, r := range records {
+		if r.ID == uuid.Nil {
+			return ErrNoID
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range records {
+		s.data[r.ID] = r
+	}
+
+	return nil
+}
+
+func (s *OutboxMemoryStore) Save(_ context.Context, r event.Record) (event.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data[r.ID]; !ok {
+		return event.Record{}, &NoRecordError{ID: r.ID}
+	}
+
+	// Nothing more will happen to what's done, so only keep what's pending
+	if r.IsPending() {
+		s.data[r.ID] = r
+	} else {
+		delete(s.data, r.ID)
+	}
+
+	return r, nil
+}
+
+func (s *OutboxMemoryStore) Pending(_ context.Context) ([]event.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return sortedPending(s.data), nil
+}
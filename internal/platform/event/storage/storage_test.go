@@ -0,0 +1,121 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event/storage"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestOutboxMemoryStore(t *testing.T) {
+	OutboxStorageTest(t, context.Background(), func() event.OutboxStorage { return storage.NewOutboxMemoryStore() })
+}
+
+func TestOutboxFileStore(t *testing.T) {
+	OutboxStorageTest(t, context.Background(), func() event.OutboxStorage {
+		store, err := storage.NewOutboxFileStore(t.TempDir())
+		require.NoError(t, err)
+		return store
+	})
+
+	t.Run("what's pending is still there when opened again", func(t *testing.T) {
+		ctx := context.Background()
+		dir := t.TempDir()
+		store, err := storage.NewOutboxFileStore(dir)
+		require.NoError(t, err)
+		pending, handled := record(), record()
+		require.NoError(t, store.Add(ctx, pending, handled))
+		handled.HandledAt = time.Now()
+		_, err = store.Save(ctx, handled)
+		require.NoError(t, err)
+
+		reopened, err := storage.NewOutboxFileStore(dir)
+		require.NoError(t, err)
+
+		actual, err := reopened.Pending(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []event.Record{pending}, actual)
+	})
+}
+
+func record() event.Record {
+	return event.Record{
+		ID:         a.UUID(),
+		EventID:    a.UUID(),
+		Name:       "test.Happened",
+		Payload:    []byte(`{}`),
+		Subscriber: "test",
+		// Without the monotonic clock, so it's the same after being read back from a file
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// OutboxStorageTest is a base suite used to test across the implementations of event.OutboxStorage.
+func OutboxStorageTest(t *testing.T, ctx context.Context, storeFactory func() event.OutboxStorage) {
+	t.Run("Add", func(t *testing.T) {
+		t.Run("returns an error and adds nothing when an ID isn't set", func(t *testing.T) {
+			store := storeFactory()
+
+			err := store.Add(ctx, record(), event.Record{})
+
+			require.ErrorIs(t, err, storage.ErrNoID)
+			pending, err := store.Pending(ctx)
+			require.NoError(t, err)
+			require.Empty(t, pending)
+		})
+	})
+
+	t.Run("Save", func(t *testing.T) {
+		t.Run("returns NoRecordError when it hasn't been added", func(t *testing.T) {
+			r := record()
+
+			_, err := storeFactory().Save(ctx, r)
+
+			var expected *storage.NoRecordError
+			require.ErrorAs(t, err, &expected)
+			require.ErrorIs(t, err, failure.NotFound, "expected it to be a not found failure")
+			require.Equal(t, r.ID, expected.ID)
+		})
+
+		t.Run("stores what happened when it's still pending", func(t *testing.T) {
+			store := storeFactory()
+			r := record()
+			require.NoError(t, store.Add(ctx, r))
+
+			r.Attempts = 1
+			r.LastError = "uh-oh"
+			_, err := store.Save(ctx, r)
+			require.NoError(t, err)
+
+			pending, err := store.Pending(ctx)
+			require.NoError(t, err)
+			require.Equal(t, []event.Record{r}, pending)
+		})
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		t.Run("returns the pending records with the oldest first", func(t *testing.T) {
+			store := storeFactory()
+			first, handled, failed, last := record(), record(), record(), record()
+			require.NoError(t, store.Add(ctx, first, handled, failed, last))
+
+			handled.HandledAt = time.Now()
+			_, err := store.Save(ctx, handled)
+			require.NoError(t, err)
+			failed.FailedAt = time.Now()
+			_, err = store.Save(ctx, failed)
+			require.NoError(t, err)
+
+			actual, err := store.Pending(ctx)
+
+			require.NoError(t, err)
+			require.Equal(t, []event.Record{first, last}, actual)
+		})
+	})
+}
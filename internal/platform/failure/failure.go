@@ -0,0 +1,70 @@
+// Package failure names the kinds of errors the stores and services return.
+// It exists so the handlers can decide how to answer from the kind of error alone,
+// without knowing about the errors of every store and service.
+package failure
+
+import "errors"
+
+// Kind is what went wrong, use errors.Is to check if an error is of a kind.
+type Kind string
+
+const (
+	// NotFound is for when what was asked for doesn't exist, or isn't visible to whoever asked.
+	NotFound Kind = "not found"
+	// Conflict is for when the change can't be made in the current state of things.
+	Conflict Kind = "conflict"
+	// Invalid is for when what was given is wrong, like a required field being empty.
+	Invalid Kind = "invalid"
+	// Forbidden is for when whoever asked isn't allowed to do it.
+	Forbidden Kind = "forbidden"
+)
+
+// Kinds are all the kinds, ordered by which wins when an error is of more than one.
+var Kinds = []Kind{Forbidden, NotFound, Conflict, Invalid}
+
+func (k Kind) Error() string {
+	return string(k)
+}
+
+// New returns an error with the message that is of the kind.
+func New(k Kind, msg string) error {
+	return &kindError{kind: k, err: errors.New(msg)}
+}
+
+// Mark returns err as being of the kind, errors.As still finds what err is.
+// It returns nil when err is nil, so it can wrap a call that may fail.
+func Mark(k Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &kindError{kind: k, err: err}
+}
+
+// KindOf returns the kind of err, it's empty when err isn't of any kind.
+func KindOf(err error) Kind {
+	for _, k := range Kinds {
+		if errors.Is(err, k) {
+			return k
+		}
+	}
+
+	return ""
+}
+
+type kindError struct {
+	kind Kind
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
@@ -0,0 +1,52 @@
+package failure_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+type lookupError struct {
+	ID string
+}
+
+func (e *lookupError) Error() string {
+	return "nothing by id: " + e.ID
+}
+
+func TestNew(t *testing.T) {
+	err := failure.New(failure.Conflict, "already done")
+
+	require.EqualError(t, err, "already done")
+	require.ErrorIs(t, err, failure.Conflict)
+	require.NotErrorIs(t, err, failure.NotFound)
+	require.ErrorIs(t, fmt.Errorf("failed to do it: %w", err), failure.Conflict, "expected the kind to be kept when wrapped")
+}
+
+func TestMark(t *testing.T) {
+	require.NoError(t, failure.Mark(failure.Invalid, nil), "expected nothing to stay nothing")
+
+	err := failure.Mark(failure.NotFound, &lookupError{ID: "abc"})
+
+	require.EqualError(t, err, "nothing by id: abc")
+	require.ErrorIs(t, err, failure.NotFound)
+	var lookupErr *lookupError
+	require.ErrorAs(t, err, &lookupErr, "expected the marked error to still be found")
+	require.Equal(t, "abc", lookupErr.ID)
+}
+
+func TestKindOf(t *testing.T) {
+	require.Equal(t, failure.Kind(""), failure.KindOf(nil))
+	require.Equal(t, failure.Kind(""), failure.KindOf(errors.New("something else")))
+	require.Equal(t, failure.NotFound, failure.KindOf(fmt.Errorf("wrapped: %w", failure.New(failure.NotFound, "gone"))))
+	require.Equal(
+		t,
+		failure.Forbidden,
+		failure.KindOf(errors.Join(failure.New(failure.Invalid, "bad"), failure.New(failure.Forbidden, "no"))),
+		"expected forbidden to win so nothing is told about what they can't see",
+	)
+}
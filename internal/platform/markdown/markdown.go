@@ -0,0 +1,144 @@
+// Package markdown renders what people write in the long text fields of reviews, as sanitized HTML for the pages
+// and as plain text for when it's sent to other systems.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+var (
+	// md keeps the line breaks as they're written, since what's pasted in is rarely written as Markdown.
+	md = goldmark.New(
+		goldmark.WithExtensions(extension.Table, extension.Strikethrough, extension.Linkify),
+		goldmark.WithRendererOptions(html.WithHardWraps()),
+	)
+	// policy is what's left of the HTML after rendering, goldmark already leaves out raw HTML and dangerous links,
+	// this is so nothing that slips through can run on the page.
+	policy = bluemonday.UGCPolicy().
+		RequireNoReferrerOnLinks(true).
+		AddTargetBlankToFullyQualifiedLinks(true)
+)
+
+// HTML renders the Markdown as HTML that's safe to put on a page as is.
+func HTML(src string) template.HTML {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(src), &buf); err != nil {
+		// Rendering only fails when writing fails, which doesn't happen to a buffer
+		return template.HTML("<p>" + template.HTMLEscapeString(src) + "</p>")
+	}
+
+	return template.HTML(policy.SanitizeBytes(buf.Bytes()))
+}
+
+// Text is the Markdown as plain text, for where HTML can't be shown.
+// The structure is kept as lines, list items start with a dash or their number and links have the address after them.
+func Text(src string) string {
+	source := []byte(src)
+	doc := md.Parser().Parse(text.NewReader(source))
+
+	return blocksText(doc, source, "\n\n")
+}
+
+// blocksText is the text of every block in n, with sep between them.
+func blocksText(n ast.Node, source []byte, sep string) string {
+	var parts []string
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if t := blockText(c, source); t != "" {
+			parts = append(parts, t)
+		}
+	}
+
+	return strings.Join(parts, sep)
+}
+
+func blockText(n ast.Node, source []byte) string {
+	switch n := n.(type) {
+	case *ast.Paragraph, *ast.TextBlock, *ast.Heading:
+		return strings.TrimSpace(inlineText(n, source))
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		var b strings.Builder
+		lines := n.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			seg := lines.At(i)
+			b.Write(seg.Value(source))
+		}
+		return strings.TrimRight(b.String(), "\n")
+	case *ast.List:
+		sep := "\n\n"
+		if n.IsTight {
+			sep = "\n"
+		}
+		var items []string
+		i := 0
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			marker := "- "
+			if n.IsOrdered() {
+				marker = fmt.Sprintf("%d. ", n.Start+i)
+			}
+			items = append(items, marker+indent(blocksText(c, source, sep), len(marker)))
+			i++
+		}
+		return strings.Join(items, "\n")
+	case *ast.Blockquote:
+		return "> " + strings.ReplaceAll(blocksText(n, source, "\n\n"), "\n", "\n> ")
+	case *ast.ThematicBreak, *ast.HTMLBlock:
+		return ""
+	case *east.Table:
+		var rows []string
+		for row := n.FirstChild(); row != nil; row = row.NextSibling() {
+			var cells []string
+			for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+				cells = append(cells, strings.TrimSpace(inlineText(cell, source)))
+			}
+			rows = append(rows, strings.Join(cells, " | "))
+		}
+		return strings.Join(rows, "\n")
+	default:
+		return blocksText(n, source, "\n\n")
+	}
+}
+
+func inlineText(n ast.Node, source []byte) string {
+	var b strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c := c.(type) {
+		case *ast.Text:
+			b.Write(c.Segment.Value(source))
+			if c.SoftLineBreak() || c.HardLineBreak() {
+				b.WriteString("\n")
+			}
+		case *ast.String:
+			b.Write(c.Value)
+		case *ast.AutoLink:
+			b.Write(c.Label(source))
+		case *ast.Link:
+			label := inlineText(c, source)
+			b.WriteString(label)
+			if dest := string(c.Destination); dest != label {
+				b.WriteString(" (" + dest + ")")
+			}
+		case *ast.RawHTML:
+			// Left out of the HTML as well
+		default:
+			b.WriteString(inlineText(c, source))
+		}
+	}
+
+	return b.String()
+}
+
+// indent indents every line but the first by n spaces, to line up with the first line after the marker of a list item.
+func indent(s string, n int) string {
+	return strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", n))
+}
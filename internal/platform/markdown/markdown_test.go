@@ -0,0 +1,53 @@
+package markdown_test
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/markdown"
+)
+
+func TestHTML(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		src      string
+		expected template.HTML
+	}{
+		{name: "renders formatting", src: "Some **bold** and `code`", expected: "<p>Some <strong>bold</strong> and <code>code</code></p>\n"},
+		{name: "keeps the line breaks as written", src: "first\nsecond", expected: "<p>first<br>\nsecond</p>\n"},
+		{name: "renders lists", src: "- one\n- two", expected: "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n"},
+		{name: "renders code blocks as is", src: "```\n<b>log</b>\n```", expected: "<pre><code>&lt;b&gt;log&lt;/b&gt;\n</code></pre>\n"},
+		{name: "leaves out raw HTML", src: "<script>alert(1)</script>\n\nafter", expected: "\n<p>after</p>\n"},
+		{name: "leaves out scripts in links", src: "[click](javascript:alert(1))", expected: "<p>click</p>\n"},
+		{name: "leaves out event handlers", src: `<img src="x" onerror="alert(1)">`, expected: "\n"},
+		{name: "opens other sites in a new tab", src: "https://example.com", expected: `<p><a href="https://example.com" rel="nofollow noreferrer noopener" target="_blank">https://example.com</a></p>` + "\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, markdown.HTML(tc.src))
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{name: "leaves out formatting", src: "# Summary\n\nSome **bold** and `code`", expected: "Summary\n\nSome bold and code"},
+		{name: "keeps the line breaks as written", src: "first\nsecond", expected: "first\nsecond"},
+		{name: "marks list items", src: "- one\n- two\n\n3. three\n4. four", expected: "- one\n- two\n\n3. three\n4. four"},
+		{name: "indents what's in a list item", src: "- one\n  more", expected: "- one\n  more"},
+		{name: "keeps code blocks as is", src: "```\n<b>log</b>\n  indented\n```", expected: "<b>log</b>\n  indented"},
+		{name: "has the address after links", src: "[the runbook](https://example.com/runbook) and https://example.com", expected: "the runbook (https://example.com/runbook) and https://example.com"},
+		{name: "leaves out raw HTML", src: "<div>\nhidden\n</div>\n\nshown <b>bold</b>", expected: "shown bold"},
+		{name: "quotes", src: "> one\n> two", expected: "> one\n> two"},
+		{name: "has a line per row of tables", src: "| a | b |\n|---|---|\n| 1 | 2 |", expected: "a | b\n1 | 2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, markdown.Text(tc.src))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Package tenant keeps track of which team someone is working as through the context.
+// Storage uses it to only return what belongs to the current team, without knowing about teams.
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ctxKey struct{}
+
+type unscopedKey struct{}
+
+// With returns a context working as the team, uuid.Nil is for working without a team.
+func With(ctx context.Context, teamID uuid.UUID) context.Context {
+	return context.WithValue(ctx, ctxKey{}, teamID)
+}
+
+// ID returns the team being worked as, or uuid.Nil when working without a team.
+func ID(ctx context.Context) uuid.UUID {
+	id, _ := ctx.Value(ctxKey{}).(uuid.UUID)
+
+	return id
+}
+
+// Unscoped returns a context that sees what belongs to every team,
+// it's for the few places that work across teams, like moving a review between them.
+func Unscoped(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unscopedKey{}, true)
+}
+
+// IsUnscoped is true for contexts from Unscoped.
+func IsUnscoped(ctx context.Context) bool {
+	unscoped, _ := ctx.Value(unscopedKey{}).(bool)
+
+	return unscoped
+}
+
+// Owns is true when something belonging to teamID is visible to the current team.
+func Owns(ctx context.Context, teamID uuid.UUID) bool {
+	return IsUnscoped(ctx) || ID(ctx) == teamID
+}
+
+// Shares is like Owns, but also sees what's shared by all teams, which belongs to uuid.Nil.
+func Shares(ctx context.Context, teamID uuid.UUID) bool {
+	return teamID == uuid.Nil || Owns(ctx, teamID)
+}
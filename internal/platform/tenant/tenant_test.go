@@ -0,0 +1,34 @@
+package tenant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+)
+
+func TestOwns(t *testing.T) {
+	team, other := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+	ctx := tenant.With(context.Background(), team)
+
+	require.Equal(t, team, tenant.ID(ctx))
+	require.True(t, tenant.Owns(ctx, team), "expected to see the current team's")
+	require.False(t, tenant.Owns(ctx, other), "expected to not see another team's")
+	require.False(t, tenant.Owns(ctx, uuid.Nil), "expected to not see what doesn't belong to a team")
+	require.True(t, tenant.Owns(tenant.Unscoped(ctx), other), "expected unscoped to see every team's")
+
+	require.True(t, tenant.Owns(context.Background(), uuid.Nil), "expected to see what doesn't belong to a team when not in one")
+	require.False(t, tenant.Owns(context.Background(), team), "expected to not see a team's when not in one")
+}
+
+func TestShares(t *testing.T) {
+	team, other := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+	ctx := tenant.With(context.Background(), team)
+
+	require.True(t, tenant.Shares(ctx, uuid.Nil), "expected to see what's shared by all teams")
+	require.True(t, tenant.Shares(ctx, team), "expected to see the current team's")
+	require.False(t, tenant.Shares(ctx, other), "expected to not see another team's")
+}
@@ -2,8 +2,14 @@ package validate
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
 )
 
 var validate *validator.Validate
@@ -13,7 +19,86 @@ func init() {
 }
 
 // Struct is a thin wrapper around validator.Validate's StructCtx.
-// This exists purely to ensure that we only have one validator cache.
+// This exists purely to ensure that we only have one validator cache,
+// and so the errors are failure.Invalid.
 func Struct(ctx context.Context, s any) error {
-	return validate.StructCtx(ctx, s)
+	return failure.Mark(failure.Invalid, validate.StructCtx(ctx, s))
+}
+
+// FieldErrors is why each field failed to validate, in words for people, by the name of the field in the struct.
+type FieldErrors map[string]string
+
+// fieldsError is from Fail, for the fields checked without the struct tags.
+type fieldsError struct {
+	fields FieldErrors
+	err    error
+}
+
+func (e *fieldsError) Error() string {
+	msgs := make([]string, 0, len(e.fields)+1)
+	if e.err != nil {
+		msgs = append(msgs, e.err.Error())
+	}
+	for _, name := range slices.Sorted(maps.Keys(e.fields)) {
+		msgs = append(msgs, name+": "+e.fields[name])
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (e *fieldsError) Unwrap() error {
+	return e.err
+}
+
+// Fail is for fields that can't be checked with struct tags, like values checked against a schema that's only known
+// when running. Fields returns them together with what's in err, which is what else failed to validate or nil.
+func Fail(fields FieldErrors, err error) error {
+	return failure.Mark(failure.Invalid, &fieldsError{fields: fields, err: err})
+}
+
+// Fields returns why each field failed to validate when err is from Struct or Fail, and false for any other error.
+// Fields in nested structs are named without the struct they're in, so a BoundCause's Why is "Why".
+func Fields(err error) (FieldErrors, bool) {
+	ret := make(FieldErrors)
+	var fe *fieldsError
+	failed := errors.As(err, &fe)
+	if failed {
+		maps.Copy(ret, fe.fields)
+	}
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		if !failed {
+			return nil, false
+		}
+		return ret, true
+	}
+
+	for _, e := range errs {
+		// Only tell them about the first problem, they'll see the next one after fixing it
+		if _, ok := ret[e.Field()]; !ok {
+			ret[e.Field()] = message(e)
+		}
+	}
+
+	return ret, true
+}
+
+func message(e validator.FieldError) string {
+	switch e.Tag() {
+	case "required", "required_if":
+		return "Can't be empty."
+	case "http_url", "url":
+		return "Has to be a link starting with http:// or https://."
+	case "email":
+		return "Has to be an email address."
+	case "oneof":
+		return "Has to be one of: " + strings.Join(strings.Fields(e.Param()), ", ") + "."
+	case "min":
+		return "Has to be at least " + e.Param() + " long."
+	case "max":
+		return "Can't be longer than " + e.Param() + "."
+	default:
+		return "Isn't valid."
+	}
 }
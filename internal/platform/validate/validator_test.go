@@ -2,10 +2,13 @@ package validate_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
 	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
 )
 
@@ -16,6 +19,64 @@ type testStruct struct {
 func TestStruct(t *testing.T) {
 	ctx := context.Background()
 
-	require.Error(t, validate.Struct(ctx, testStruct{}), "expected an error for an empty object")
+	require.ErrorIs(t, validate.Struct(ctx, testStruct{}), failure.Invalid, "expected an invalid error for an empty object")
 	require.NoError(t, validate.Struct(ctx, testStruct{"Hello"}), "when the struct is valid don't error")
 }
+
+type testFields struct {
+	URL    string       `validate:"required,http_url"`
+	State  string       `validate:"oneof=draft published"`
+	Nested testStruct   `validate:"required"`
+	Items  []testStruct `validate:"dive"`
+}
+
+func TestFields(t *testing.T) {
+	t.Run("returns false for errors that aren't from validating", func(t *testing.T) {
+		_, ok := validate.Fields(errors.New("uh-oh"))
+
+		require.False(t, ok)
+	})
+
+	t.Run("returns the first reason each field failed by the name of the field, also when wrapped", func(t *testing.T) {
+		err := validate.Struct(context.Background(), testFields{Items: []testStruct{{}}})
+
+		actual, ok := validate.Fields(fmt.Errorf("failed to validate: %w", err))
+
+		require.True(t, ok)
+		require.Equal(t, validate.FieldErrors{
+			"URL":   "Can't be empty.",
+			"State": "Has to be one of: draft, published.",
+			"Hello": "Can't be empty.",
+		}, actual)
+	})
+
+	t.Run("explains what a valid link looks like", func(t *testing.T) {
+		err := validate.Struct(context.Background(), testFields{URL: "example.com", State: "draft", Nested: testStruct{"Hello"}})
+
+		actual, ok := validate.Fields(err)
+
+		require.True(t, ok)
+		require.Equal(t, validate.FieldErrors{"URL": "Has to be a link starting with http:// or https://."}, actual)
+	})
+}
+
+func TestFail(t *testing.T) {
+	t.Run("is invalid with the fields that failed", func(t *testing.T) {
+		err := validate.Fail(validate.FieldErrors{"Region": "Has to be one of: EU, US."}, nil)
+
+		actual, ok := validate.Fields(fmt.Errorf("failed to validate: %w", err))
+
+		require.ErrorIs(t, err, failure.Invalid)
+		require.True(t, ok)
+		require.Equal(t, validate.FieldErrors{"Region": "Has to be one of: EU, US."}, actual)
+	})
+
+	t.Run("includes what else failed to validate", func(t *testing.T) {
+		err := validate.Fail(validate.FieldErrors{"Region": "Can't be empty."}, validate.Struct(context.Background(), testStruct{}))
+
+		actual, ok := validate.Fields(err)
+
+		require.True(t, ok)
+		require.Equal(t, validate.FieldErrors{"Region": "Can't be empty.", "Hello": "Can't be empty."}, actual)
+	})
+}
@@ -0,0 +1,120 @@
+package reviewing
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+// Permission is what someone wants to do with a Review.
+type Permission string
+
+const (
+	// PermissionContribute is for working on the contents: editing, binding, voting, and discussing.
+	PermissionContribute Permission = "contribute to"
+	// PermissionFacilitate is for moving the review through its lifecycle and deciding who is part of it.
+	PermissionFacilitate Permission = "facilitate"
+)
+
+// MemberKind is how someone is part of a Review.
+type MemberKind string
+
+const (
+	MemberFacilitator MemberKind = "facilitator"
+	MemberParticipant MemberKind = "participant"
+)
+
+// ErrLastFacilitator is returned when removing the only facilitator, since nobody could run the review then.
+var ErrLastFacilitator = failure.New(failure.Conflict, "a review needs at least one facilitator")
+
+// CanStartReview is used with actor.Require for creating reviews, whoever creates one becomes its facilitator.
+func CanStartReview(a actor.Actor) bool {
+	return a.Role == actor.RoleAdmin || a.Role == actor.RoleFacilitator
+}
+
+// Allows checks whether the actor can do p on the review.
+// Admins can do everything, viewers nothing, and everyone else depends on how they're part of the review.
+func (r Review) Allows(a actor.Actor, p Permission) bool {
+	switch {
+	case a.Role == actor.RoleAdmin:
+		return true
+	case !a.Role.CanContribute():
+		return false
+	case p == PermissionFacilitate:
+		return slices.Contains(r.Facilitators, a.ID)
+	default:
+		return slices.Contains(r.Facilitators, a.ID) || slices.Contains(r.Participants, a.ID)
+	}
+}
+
+// authorize is the guard the service uses before changing the review.
+func (r Review) authorize(ctx context.Context, p Permission) error {
+	if err := actor.Require(ctx, func(a actor.Actor) bool { return r.Allows(a, p) }); err != nil {
+		return fmt.Errorf("%w to %s this review", err, p)
+	}
+
+	return nil
+}
+
+// authorizeComment is the guard for changing a comment, see CanChangeComment.
+// Comments that don't exist are left for the change itself to report.
+func (r Review) authorizeComment(ctx context.Context, commentID uuid.UUID) error {
+	i := slices.IndexFunc(r.Comments, func(c Comment) bool { return c.ID == commentID })
+	if i == -1 {
+		return nil
+	}
+
+	if err := actor.Require(ctx, func(a actor.Actor) bool { return r.CanChangeComment(a, r.Comments[i]) }); err != nil {
+		return fmt.Errorf("%w to change a comment someone else wrote", err)
+	}
+
+	return nil
+}
+
+// AddMember makes someone part of the review, adding someone again changes how they're part of it.
+func (r Review) AddMember(id uuid.UUID, kind MemberKind) (Review, error) {
+	if id == uuid.Nil {
+		return r, failure.New(failure.Invalid, "can't add a member without an id")
+	}
+	if kind != MemberFacilitator && kind != MemberParticipant {
+		return r, failure.New(failure.Invalid, "unknown kind of member: "+string(kind))
+	}
+	if kind == MemberParticipant && r.isLastFacilitator(id) {
+		return r, ErrLastFacilitator
+	}
+
+	r = r.without(id)
+	switch kind {
+	case MemberFacilitator:
+		r.Facilitators = append(r.Facilitators, id)
+	case MemberParticipant:
+		r.Participants = append(r.Participants, id)
+	}
+
+	return r, nil
+}
+
+// RemoveMember takes someone off the review, it's not an error if they weren't part of it.
+func (r Review) RemoveMember(id uuid.UUID) (Review, error) {
+	if r.isLastFacilitator(id) {
+		return r, ErrLastFacilitator
+	}
+
+	return r.without(id), nil
+}
+
+func (r Review) isLastFacilitator(id uuid.UUID) bool {
+	return len(r.Facilitators) == 1 && r.Facilitators[0] == id
+}
+
+func (r Review) without(id uuid.UUID) Review {
+	r.Facilitators = slices.DeleteFunc(slices.Clone(r.Facilitators), func(f uuid.UUID) bool { return f == id })
+	r.Participants = slices.DeleteFunc(slices.Clone(r.Participants), func(p uuid.UUID) bool { return p == id })
+
+	return r
+}
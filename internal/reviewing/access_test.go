@@ -0,0 +1,76 @@
+package reviewing_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestReview_Allows(t *testing.T) {
+	facilitator, participant, outsider := a.UUID(), a.UUID(), a.UUID()
+	review := a.Review().WithFacilitator(facilitator).WithParticipant(participant).Build()
+
+	for _, tc := range []struct {
+		name       string
+		actor      actor.Actor
+		contribute bool
+		facilitate bool
+	}{
+		{"an admin who isn't part of it", actor.Actor{ID: outsider, Role: actor.RoleAdmin}, true, true},
+		{"its facilitator", actor.Actor{ID: facilitator, Role: actor.RoleFacilitator}, true, true},
+		{"a facilitator of other reviews", actor.Actor{ID: outsider, Role: actor.RoleFacilitator}, false, false},
+		{"a participant", actor.Actor{ID: participant, Role: actor.RoleContributor}, true, false},
+		{"a participant who is a viewer", actor.Actor{ID: participant, Role: actor.RoleViewer}, false, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.contribute, review.Allows(tc.actor, reviewing.PermissionContribute), "contribute")
+			require.Equal(t, tc.facilitate, review.Allows(tc.actor, reviewing.PermissionFacilitate), "facilitate")
+		})
+	}
+}
+
+func TestReview_AddMember(t *testing.T) {
+	facilitator, someone := a.UUID(), a.UUID()
+	review := a.Review().WithFacilitator(facilitator).Build()
+
+	t.Run("adding someone again changes how they're part of the review", func(t *testing.T) {
+		actual, err := review.AddMember(someone, reviewing.MemberParticipant)
+		require.NoError(t, err)
+
+		actual, err = actual.AddMember(someone, reviewing.MemberFacilitator)
+		require.NoError(t, err)
+
+		require.Equal(t, []uuid.UUID{facilitator, someone}, actual.Facilitators)
+		require.Empty(t, actual.Participants)
+		require.Equal(t, []uuid.UUID{facilitator}, review.Facilitators, "expected the original review to not have changed")
+	})
+
+	t.Run("the last facilitator can't become a participant", func(t *testing.T) {
+		_, err := review.AddMember(facilitator, reviewing.MemberParticipant)
+
+		require.ErrorIs(t, err, reviewing.ErrLastFacilitator)
+	})
+
+	t.Run("an unknown kind of member is an error", func(t *testing.T) {
+		_, err := review.AddMember(someone, reviewing.MemberKind("observer"))
+
+		require.ErrorContains(t, err, "unknown kind of member")
+	})
+}
+
+func TestReview_RemoveMember(t *testing.T) {
+	facilitator, participant := a.UUID(), a.UUID()
+	review := a.Review().WithFacilitator(facilitator).WithParticipant(participant).Build()
+
+	actual, err := review.RemoveMember(participant)
+	require.NoError(t, err)
+	require.Empty(t, actual.Participants)
+
+	_, err = review.RemoveMember(facilitator)
+	require.ErrorIs(t, err, reviewing.ErrLastFacilitator)
+}
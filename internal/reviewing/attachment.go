@@ -0,0 +1,293 @@
+package reviewing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/action"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/blob"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+// Attachment is a file, like a graph, screenshot or log excerpt, attached to a review or one of its bound causes.
+// The contents are kept in a blob.Store by Key, and for images a smaller version by ThumbnailKey.
+type Attachment struct {
+	ID uuid.UUID
+	// BoundCauseID is the bound cause the file is attached to, uuid.Nil when it's attached to the review itself.
+	BoundCauseID uuid.UUID
+	Name         string
+	// ContentType is detected from the contents, not taken from whoever uploaded it.
+	ContentType  string
+	Size         int64
+	HasThumbnail bool
+
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+}
+
+func (a Attachment) Key() string {
+	return a.ID.String()
+}
+
+func (a Attachment) ThumbnailKey() string {
+	return a.ID.String() + "-thumbnail"
+}
+
+func (a Attachment) IsImage() bool {
+	return strings.HasPrefix(a.ContentType, "image/")
+}
+
+// AttachmentLimits are what files can be attached.
+type AttachmentLimits struct {
+	// MaxSize is the most bytes a file can be.
+	MaxSize int64
+	// ContentTypes are the kinds of files that can be attached, by the media type detected from the contents.
+	ContentTypes []string
+}
+
+// DefaultAttachmentLimits allow images, PDFs and plain text of up to 10 MB.
+// What a browser would run, like HTML and SVG, isn't allowed since the files are served from the same site.
+func DefaultAttachmentLimits() AttachmentLimits {
+	return AttachmentLimits{
+		MaxSize:      10 << 20,
+		ContentTypes: []string{"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain"},
+	}
+}
+
+// check returns the media type of the contents, or why they can't be attached.
+func (l AttachmentLimits) check(contents []byte) (string, error) {
+	if int64(len(contents)) > l.MaxSize {
+		return "", failure.New(failure.Invalid, fmt.Sprintf("the file is larger than the limit of %d MB", l.MaxSize>>20))
+	}
+	if len(contents) == 0 {
+		return "", failure.New(failure.Invalid, "the file is empty")
+	}
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(contents))
+	if err != nil || !slices.Contains(l.ContentTypes, contentType) {
+		return "", failure.New(failure.Invalid, "files of this kind can't be attached, only: "+strings.Join(l.ContentTypes, ", "))
+	}
+
+	return contentType, nil
+}
+
+// WithAttachments keeps the contents of attachments in blobs instead of in memory, and only allows what fits the limits.
+func WithAttachments(blobs blob.Store, limits AttachmentLimits) Option {
+	return func(s *Service) {
+		s.blobs = blobs
+		s.attachmentLimits = limits
+	}
+}
+
+// AddAttachment attaches the file to the review, or to one of its bound causes when a.BoundCauseID is set.
+// Attachments can only be added to bound causes currently on the review.
+func (r Review) AddAttachment(a Attachment) (Review, error) {
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
+	if a.BoundCauseID != uuid.Nil && !slices.ContainsFunc(r.BoundCauses, func(bc BoundCause) bool { return bc.ID == a.BoundCauseID }) {
+		return r, failure.New(failure.NotFound, "cannot attach to a contributing cause that isn't bound to the review: "+a.BoundCauseID.String())
+	}
+	if strings.TrimSpace(a.Name) == "" {
+		return r, failure.New(failure.Invalid, "cannot attach a file without a name")
+	}
+
+	if a.ID == uuid.Nil {
+		a.ID = uuid.Must(uuid.NewV7())
+	}
+	a.CreatedAt = time.Now()
+
+	r.Attachments = append(slices.Clone(r.Attachments), a)
+
+	return r, nil
+}
+
+// RemoveAttachment takes the file off the review, the contents are deleted by the service.
+func (r Review) RemoveAttachment(attachmentID uuid.UUID) (Review, error) {
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
+	i := slices.IndexFunc(r.Attachments, func(a Attachment) bool { return a.ID == attachmentID })
+	if i == -1 {
+		return r, failure.New(failure.NotFound, "cannot remove an attachment that doesn't exist: "+attachmentID.String())
+	}
+
+	r.Attachments = slices.Delete(slices.Clone(r.Attachments), i, i+1)
+
+	return r, nil
+}
+
+// Attachment finds the attachment on the review.
+func (r Review) Attachment(attachmentID uuid.UUID) (Attachment, bool) {
+	i := slices.IndexFunc(r.Attachments, func(a Attachment) bool { return a.ID == attachmentID })
+	if i == -1 {
+		return Attachment{}, false
+	}
+
+	return r.Attachments[i], true
+}
+
+// AttachmentsOf returns what's attached to the bound cause in the order they were attached,
+// uuid.Nil for what's attached to the review itself.
+func (r Review) AttachmentsOf(boundCauseID uuid.UUID) []Attachment {
+	var ret []Attachment
+	for _, a := range r.Attachments {
+		if a.BoundCauseID == boundCauseID {
+			ret = append(ret, a)
+		}
+	}
+
+	return ret
+}
+
+// Attach stores the contents and attaches them to the review, see Review.AddAttachment.
+// The contents have to fit the AttachmentLimits, and a thumbnail is made for the images that can be decoded.
+func (s *Service) Attach(ctx context.Context, reviewID uuid.UUID, attachment Attachment, contents io.Reader) (Attachment, error) {
+	// Set the ID here so we can find the attachment again after it's been saved.
+	if attachment.ID == uuid.Nil {
+		attachment.ID = uuid.Must(uuid.NewV7())
+	}
+
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return Attachment{}, err
+	}
+
+	// Read one byte past the limit to know when it's too large without reading all of it
+	data, err := io.ReadAll(io.LimitReader(contents, s.attachmentLimits.MaxSize+1))
+	if err != nil {
+		return Attachment{}, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	attachment.ContentType, err = s.attachmentLimits.check(data)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("failed to attach %q: %w", attachment.Name, err)
+	}
+	attachment.Size = int64(len(data))
+	attachment.CreatedBy = actor.ID(ctx)
+
+	var thumb []byte
+	if attachment.IsImage() {
+		thumb, attachment.HasThumbnail = thumbnail(data)
+	}
+
+	do := action.Get(s.actions, ActionAddAttachment())
+
+	review, err = do(review, attachment)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("action to add attachment failed: %w", err)
+	}
+
+	if err := s.blobs.Put(ctx, attachment.Key(), bytes.NewReader(data)); err != nil {
+		return Attachment{}, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	if attachment.HasThumbnail {
+		if err := s.blobs.Put(ctx, attachment.ThumbnailKey(), bytes.NewReader(thumb)); err != nil {
+			s.deleteBlobs(ctx, attachment)
+			return Attachment{}, fmt.Errorf("failed to store thumbnail of attachment: %w", err)
+		}
+	}
+
+	updatedReview, err := s.save(ctx, review)
+	if err != nil {
+		s.deleteBlobs(ctx, attachment)
+		return Attachment{}, fmt.Errorf("failed to save review after attaching: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: updatedReview})
+
+	if a, ok := updatedReview.Attachment(attachment.ID); ok {
+		return a, nil
+	}
+
+	return Attachment{}, errors.New("unexpected error: added attachment not found")
+}
+
+// OpenAttachment returns the attachment with its contents, or the contents of its thumbnail, which have to be closed.
+// Anyone who can see the review can see what's attached to it.
+func (s *Service) OpenAttachment(ctx context.Context, reviewID uuid.UUID, attachmentID uuid.UUID, thumbnail bool) (Attachment, io.ReadCloser, error) {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return Attachment{}, nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	a, ok := review.Attachment(attachmentID)
+	if !ok {
+		return Attachment{}, nil, failure.New(failure.NotFound, "no attachment on the review: "+attachmentID.String())
+	}
+
+	key := a.Key()
+	if thumbnail {
+		if !a.HasThumbnail {
+			return Attachment{}, nil, failure.New(failure.NotFound, "no thumbnail of the attachment: "+attachmentID.String())
+		}
+		key = a.ThumbnailKey()
+	}
+
+	contents, err := s.blobs.Open(ctx, key)
+	if err != nil {
+		return Attachment{}, nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+
+	return a, contents, nil
+}
+
+// RemoveAttachment takes the attachment off the review and deletes its contents.
+func (s *Service) RemoveAttachment(ctx context.Context, reviewID uuid.UUID, attachmentID uuid.UUID) (Attachment, error) {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return Attachment{}, err
+	}
+
+	attachment, _ := review.Attachment(attachmentID)
+
+	do := action.Get(s.actions, ActionRemoveAttachment())
+
+	review, err = do(review, attachmentID)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("action to remove attachment failed: %w", err)
+	}
+
+	review, err = s.save(ctx, review)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("failed to save review after removing attachment: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: review})
+	s.deleteBlobs(ctx, attachment)
+
+	return attachment, nil
+}
+
+// deleteBlobs doesn't fail what's being done when the contents can't be deleted, they're only left behind.
+func (s *Service) deleteBlobs(ctx context.Context, a Attachment) {
+	keys := []string{a.Key()}
+	if a.HasThumbnail {
+		keys = append(keys, a.ThumbnailKey())
+	}
+
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			slog.Error("failed to delete attachment contents", "attachmentID", a.ID, "key", key, "error", err)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package reviewing_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/blob"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing/storage"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestReview_AddAttachment(t *testing.T) {
+	t.Run("attaching to a cause that isn't bound returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+
+		_, err := review.AddAttachment(reviewing.Attachment{Name: "graph.png", BoundCauseID: a.UUID()})
+
+		require.ErrorIs(t, err, failure.NotFound)
+		require.ErrorContains(t, err, "cannot attach to a contributing cause that isn't bound to the review:")
+	})
+
+	t.Run("attaching without a name returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+
+		_, err := review.AddAttachment(reviewing.Attachment{Name: " "})
+
+		require.ErrorIs(t, err, failure.Invalid)
+	})
+
+	t.Run("attaching to a published review returns an error", func(t *testing.T) {
+		review := a.Review().WithState(reviewing.StatePublished).Build()
+
+		_, err := review.AddAttachment(reviewing.Attachment{Name: "graph.png"})
+
+		require.ErrorIs(t, err, reviewing.ErrReadOnly)
+	})
+
+	t.Run("adds the attachment to the bound cause and sets the ID and when it was attached", func(t *testing.T) {
+		review := a.Review().WithContributingCause().Build()
+		boundCauseID := review.BoundCauses[0].ID
+
+		actual, err := review.AddAttachment(reviewing.Attachment{Name: "graph.png", BoundCauseID: boundCauseID})
+
+		require.NoError(t, err)
+		require.Len(t, actual.AttachmentsOf(boundCauseID), 1)
+		require.Empty(t, actual.AttachmentsOf(review.ID), "expected nothing attached to the review itself")
+		attached := actual.AttachmentsOf(boundCauseID)[0]
+		require.NotZero(t, attached.ID)
+		require.NotZero(t, attached.CreatedAt)
+		require.Empty(t, review.Attachments, "expected the original review to not be changed")
+	})
+}
+
+func TestReview_RemoveAttachment(t *testing.T) {
+	t.Run("removing an attachment that doesn't exist returns an error", func(t *testing.T) {
+		_, err := a.Review().Build().RemoveAttachment(a.UUID())
+
+		require.ErrorIs(t, err, failure.NotFound)
+	})
+
+	t.Run("removes only that attachment", func(t *testing.T) {
+		review, err := a.Review().Build().AddAttachment(reviewing.Attachment{Name: "first.txt"})
+		require.NoError(t, err)
+		review, err = review.AddAttachment(reviewing.Attachment{Name: "second.txt"})
+		require.NoError(t, err)
+
+		actual, err := review.RemoveAttachment(review.Attachments[0].ID)
+
+		require.NoError(t, err)
+		require.Len(t, actual.Attachments, 1)
+		require.Equal(t, "second.txt", actual.Attachments[0].Name)
+	})
+}
+
+func pngOf(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+
+	return buf.Bytes()
+}
+
+func TestService_Attach(t *testing.T) {
+	newService := func(t *testing.T) (*reviewing.Service, *blob.MemoryStore, reviewing.Review) {
+		t.Helper()
+		blobs := blob.NewMemoryStore()
+		service, err := reviewing.NewService(
+			storage.NewMemoryStore(), nil, nil,
+			reviewing.WithAttachments(blobs, reviewing.AttachmentLimits{MaxSize: 1 << 20, ContentTypes: []string{"image/png", "text/plain"}}),
+		)
+		require.NoError(t, err)
+		review, err := service.Save(adminCtx, a.Review().IsNotSaved().Build())
+		require.NoError(t, err)
+
+		return service, blobs, review
+	}
+
+	t.Run("files larger than the limit aren't attached", func(t *testing.T) {
+		service, _, review := newService(t)
+
+		_, err := service.Attach(adminCtx, review.ID, reviewing.Attachment{Name: "huge.txt"}, strings.NewReader(strings.Repeat("a", 1<<20+1)))
+
+		require.ErrorIs(t, err, failure.Invalid)
+		require.ErrorContains(t, err, "larger than the limit of 1 MB")
+	})
+
+	t.Run("the kind of file is detected from the contents and has to be allowed", func(t *testing.T) {
+		service, _, review := newService(t)
+
+		_, err := service.Attach(adminCtx, review.ID, reviewing.Attachment{Name: "graph.png"}, strings.NewReader("<html><script>alert(1)</script></html>"))
+
+		require.ErrorIs(t, err, failure.Invalid)
+		require.ErrorContains(t, err, "files of this kind can't be attached")
+	})
+
+	t.Run("viewers can't attach files but can open them", func(t *testing.T) {
+		service, _, review := newService(t)
+		attachment, err := service.Attach(adminCtx, review.ID, reviewing.Attachment{Name: "log.txt"}, strings.NewReader("an error happened"))
+		require.NoError(t, err)
+		viewerCtx := actor.With(context.Background(), a.Actor().WithRole(actor.RoleViewer).Build())
+
+		_, err = service.Attach(viewerCtx, review.ID, reviewing.Attachment{Name: "log.txt"}, strings.NewReader("more"))
+		require.ErrorIs(t, err, failure.Forbidden)
+
+		actual, contents, err := service.OpenAttachment(viewerCtx, review.ID, attachment.ID, false)
+		require.NoError(t, err)
+		defer (func() { _ = contents.Close() })()
+		data, err := io.ReadAll(contents)
+		require.NoError(t, err)
+		require.Equal(t, "an error happened", string(data))
+		require.Equal(t, "text/plain", actual.ContentType)
+		require.Equal(t, int64(len("an error happened")), actual.Size)
+		require.False(t, actual.HasThumbnail, "expected no thumbnail for text")
+	})
+
+	t.Run("images get a thumbnail that fits within 256 pixels", func(t *testing.T) {
+		service, _, review := newService(t)
+
+		attachment, err := service.Attach(adminCtx, review.ID, reviewing.Attachment{Name: "graph.png"}, bytes.NewReader(pngOf(t, 1024, 512)))
+
+		require.NoError(t, err)
+		require.True(t, attachment.HasThumbnail)
+		_, contents, err := service.OpenAttachment(adminCtx, review.ID, attachment.ID, true)
+		require.NoError(t, err)
+		defer (func() { _ = contents.Close() })()
+		thumb, err := png.DecodeConfig(contents)
+		require.NoError(t, err)
+		require.Equal(t, 256, thumb.Width)
+		require.Equal(t, 128, thumb.Height)
+	})
+
+	t.Run("removing an attachment deletes its contents", func(t *testing.T) {
+		service, blobs, review := newService(t)
+		attachment, err := service.Attach(adminCtx, review.ID, reviewing.Attachment{Name: "graph.png"}, bytes.NewReader(pngOf(t, 10, 10)))
+		require.NoError(t, err)
+
+		_, err = service.RemoveAttachment(adminCtx, review.ID, attachment.ID)
+
+		require.NoError(t, err)
+		_, _, err = service.OpenAttachment(adminCtx, review.ID, attachment.ID, false)
+		require.ErrorIs(t, err, failure.NotFound)
+		_, err = blobs.Open(adminCtx, attachment.Key())
+		require.ErrorIs(t, err, blob.ErrNotFound)
+		_, err = blobs.Open(adminCtx, attachment.ThumbnailKey())
+		require.ErrorIs(t, err, blob.ErrNotFound)
+	})
+}
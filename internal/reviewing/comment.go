@@ -0,0 +1,194 @@
+package reviewing
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+type CommentSubjectKind string
+
+const (
+	CommentOnReview       CommentSubjectKind = "review"
+	CommentOnBoundCause   CommentSubjectKind = "bound-cause"
+	CommentOnBoundTrigger CommentSubjectKind = "bound-trigger"
+)
+
+// CommentSubject is what a comment is about.
+// For a review the ID is the review's ID, and for bound causes and triggers it's the ID of the binding,
+// not the catalog entry, so the discussion stays with the review even when the catalog changes.
+type CommentSubject struct {
+	Kind CommentSubjectKind
+	ID   uuid.UUID
+}
+
+type Comment struct {
+	ID uuid.UUID
+	// ParentID is the comment this is a reply to, or uuid.Nil when it starts a thread.
+	ParentID uuid.UUID
+	Subject  CommentSubject
+	// AuthorID is the user who wrote it, only they can change it unless they facilitate the review, see CanChangeComment.
+	AuthorID  uuid.UUID
+	Body      string
+	IsDeleted bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CommentThread is a comment with all the replies to it, and the replies to those.
+type CommentThread struct {
+	Comment
+	Replies []CommentThread
+}
+
+// AddComment starts a new thread, or replies to an existing comment when c.ParentID is set.
+// Comments can only be added to items currently on the review, but they're kept if the item is unbound later.
+func (r Review) AddComment(c Comment) (Review, error) {
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
+	if c.AuthorID == uuid.Nil {
+		return r, failure.New(failure.Invalid, "cannot add a comment without knowing who wrote it")
+	}
+
+	if strings.TrimSpace(c.Body) == "" {
+		return r, failure.New(failure.Invalid, "cannot add a comment without a body")
+	}
+
+	if !r.HasCommentSubject(c.Subject) {
+		return r, failure.New(failure.NotFound, "cannot comment on something that isn't part of the review: "+string(c.Subject.Kind)+" "+c.Subject.ID.String())
+	}
+
+	if c.ParentID != uuid.Nil {
+		i := slices.IndexFunc(r.Comments, func(o Comment) bool { return o.ID == c.ParentID })
+		if i == -1 {
+			return r, failure.New(failure.NotFound, "cannot reply to a comment that doesn't exist: "+c.ParentID.String())
+		}
+		if r.Comments[i].Subject != c.Subject {
+			return r, failure.New(failure.Invalid, "cannot reply to a comment about something else")
+		}
+	}
+
+	if c.ID == uuid.Nil {
+		c.ID = uuid.Must(uuid.NewV7())
+	}
+	now := time.Now()
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	c.IsDeleted = false
+
+	r.Comments = append(slices.Clone(r.Comments), c)
+
+	return r, nil
+}
+
+// CanChangeComment checks whether the actor can edit or delete the comment,
+// which is whoever wrote it while they can contribute, and those who facilitate the review.
+func (r Review) CanChangeComment(a actor.Actor, c Comment) bool {
+	if c.AuthorID == a.ID && r.Allows(a, PermissionContribute) {
+		return true
+	}
+
+	return r.Allows(a, PermissionFacilitate)
+}
+
+// EditComment replaces the body of a comment.
+func (r Review) EditComment(commentID uuid.UUID, body string) (Review, error) {
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return r, failure.New(failure.Invalid, "cannot remove the body of a comment, delete it instead")
+	}
+
+	i := slices.IndexFunc(r.Comments, func(c Comment) bool { return c.ID == commentID })
+	if i == -1 {
+		return r, failure.New(failure.NotFound, "cannot edit a comment that doesn't exist: "+commentID.String())
+	}
+	if r.Comments[i].IsDeleted {
+		return r, failure.New(failure.Conflict, "cannot edit a deleted comment")
+	}
+
+	r.Comments = slices.Clone(r.Comments)
+	r.Comments[i].Body = body
+	r.Comments[i].UpdatedAt = time.Now()
+
+	return r, nil
+}
+
+// DeleteComment removes the body of the comment but keeps it around so the replies to it still make sense.
+func (r Review) DeleteComment(commentID uuid.UUID) (Review, error) {
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
+	i := slices.IndexFunc(r.Comments, func(c Comment) bool { return c.ID == commentID })
+	if i == -1 {
+		return r, failure.New(failure.NotFound, "cannot delete a comment that doesn't exist: "+commentID.String())
+	}
+
+	r.Comments = slices.Clone(r.Comments)
+	r.Comments[i].Body = ""
+	r.Comments[i].IsDeleted = true
+	r.Comments[i].UpdatedAt = time.Now()
+
+	return r, nil
+}
+
+// CommentThreads returns the threads about subject in the order they were started.
+func (r Review) CommentThreads(subject CommentSubject) []CommentThread {
+	return r.commentThreads(func(c Comment) bool { return c.Subject == subject })
+}
+
+// CommentThreadsOnRemovedItems returns the threads about bound causes and triggers that have been unbound from the review.
+func (r Review) CommentThreadsOnRemovedItems() []CommentThread {
+	return r.commentThreads(func(c Comment) bool { return !r.HasCommentSubject(c.Subject) })
+}
+
+func (r Review) commentThreads(include func(Comment) bool) []CommentThread {
+	replies := make(map[uuid.UUID][]Comment)
+	for _, c := range r.Comments {
+		replies[c.ParentID] = append(replies[c.ParentID], c)
+	}
+
+	var thread func(c Comment) CommentThread
+	thread = func(c Comment) CommentThread {
+		t := CommentThread{Comment: c}
+		for _, reply := range replies[c.ID] {
+			t.Replies = append(t.Replies, thread(reply))
+		}
+
+		return t
+	}
+
+	var ret []CommentThread
+	for _, c := range replies[uuid.Nil] {
+		if include(c) {
+			ret = append(ret, thread(c))
+		}
+	}
+
+	return ret
+}
+
+// HasCommentSubject checks whether the subject is currently part of the review.
+func (r Review) HasCommentSubject(s CommentSubject) bool {
+	switch s.Kind {
+	case CommentOnReview:
+		return s.ID == r.ID
+	case CommentOnBoundCause:
+		return slices.ContainsFunc(r.BoundCauses, func(bc BoundCause) bool { return bc.ID == s.ID })
+	case CommentOnBoundTrigger:
+		return slices.ContainsFunc(r.BoundTriggers, func(bt BoundTrigger) bool { return bt.ID == s.ID })
+	default:
+		return false
+	}
+}
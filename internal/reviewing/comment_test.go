@@ -0,0 +1,205 @@
+package reviewing_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestReview_AddComment(t *testing.T) {
+	t.Run("a comment without a body returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+
+		_, err := review.AddComment(a.Comment().WithBody(" \n ").Build())
+
+		require.ErrorContains(t, err, "cannot add a comment without a body")
+	})
+
+	for _, tc := range []struct {
+		name    string
+		subject reviewing.CommentSubject
+	}{
+		{"another review", reviewing.CommentSubject{Kind: reviewing.CommentOnReview, ID: a.UUID()}},
+		{"a cause that isn't bound", reviewing.CommentSubject{Kind: reviewing.CommentOnBoundCause, ID: a.UUID()}},
+		{"a trigger that isn't bound", reviewing.CommentSubject{Kind: reviewing.CommentOnBoundTrigger, ID: a.UUID()}},
+		{"an unknown kind", reviewing.CommentSubject{Kind: "unknown", ID: a.Review().Build().ID}},
+	} {
+		t.Run("commenting on "+tc.name+" returns an error", func(t *testing.T) {
+			review := a.Review().Build()
+
+			_, err := review.AddComment(a.Comment().WithSubject(tc.subject.Kind, tc.subject.ID).Build())
+
+			require.ErrorContains(t, err, "cannot comment on something that isn't part of the review:")
+		})
+	}
+
+	t.Run("a comment without an author returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+
+		_, err := review.AddComment(a.Comment().WithAuthor(uuid.Nil).Build())
+
+		require.ErrorIs(t, err, failure.Invalid)
+		require.ErrorContains(t, err, "cannot add a comment without knowing who wrote it")
+	})
+
+	t.Run("replying to a comment that doesn't exist returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+
+		_, err := review.AddComment(a.Comment().IsNotSaved().IsReplyTo(a.Comment().Build()).Build())
+
+		require.ErrorContains(t, err, "cannot reply to a comment that doesn't exist:")
+	})
+
+	t.Run("replying to a comment about something else returns an error", func(t *testing.T) {
+		review := a.Review().WithContributingCause().WithComment(a.Comment().Build()).Build()
+		reply := a.Comment().IsNotSaved().IsReplyTo(a.Comment().Build()).
+			WithSubject(reviewing.CommentOnBoundCause, review.BoundCauses[0].ID).
+			Build()
+
+		_, err := review.AddComment(reply)
+
+		require.ErrorContains(t, err, "cannot reply to a comment about something else")
+	})
+
+	t.Run("adds the comment and sets the ID and timestamps", func(t *testing.T) {
+		review := a.Review().WithContributingCause().Build()
+		comment := a.Comment().IsNotSaved().WithSubject(reviewing.CommentOnBoundCause, review.BoundCauses[0].ID).Build()
+
+		actual, err := review.AddComment(comment)
+
+		require.NoError(t, err)
+		require.Len(t, actual.Comments, 1)
+		require.NotEqual(t, uuid.Nil, actual.Comments[0].ID)
+		require.False(t, actual.Comments[0].CreatedAt.IsZero())
+		require.Equal(t, actual.Comments[0].CreatedAt, actual.Comments[0].UpdatedAt)
+	})
+}
+
+func TestReview_CanChangeComment(t *testing.T) {
+	author, other, facilitator := a.UUID(), a.UUID(), a.UUID()
+	review := a.Review().WithParticipant(author, other).WithFacilitator(facilitator).Build()
+	comment := a.Comment().WithAuthor(author).Build()
+
+	for _, tc := range []struct {
+		name     string
+		actor    actor.Actor
+		expected bool
+	}{
+		{"the author", a.Actor().WithID(author).WithRole(actor.RoleContributor).Build(), true},
+		{"another participant", a.Actor().WithID(other).WithRole(actor.RoleContributor).Build(), false},
+		{"a facilitator", a.Actor().WithID(facilitator).WithRole(actor.RoleContributor).Build(), true},
+		{"an admin", a.Actor().WithID(a.UUID()).WithRole(actor.RoleAdmin).Build(), true},
+		{"the author as a viewer", a.Actor().WithID(author).WithRole(actor.RoleViewer).Build(), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, review.CanChangeComment(tc.actor, comment))
+		})
+	}
+}
+
+func TestReview_EditComment(t *testing.T) {
+	t.Run("editing a comment that doesn't exist returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+
+		_, err := review.EditComment(a.UUID(), "hello")
+
+		require.ErrorContains(t, err, "cannot edit a comment that doesn't exist:")
+	})
+
+	t.Run("editing a deleted comment returns an error", func(t *testing.T) {
+		review, err := a.Review().WithComment(a.Comment().Build()).Build().DeleteComment(a.Comment().Build().ID)
+		require.NoError(t, err)
+
+		_, err = review.EditComment(a.Comment().Build().ID, "hello")
+
+		require.ErrorContains(t, err, "cannot edit a deleted comment")
+	})
+
+	t.Run("editing to an empty body returns an error", func(t *testing.T) {
+		review := a.Review().WithComment(a.Comment().Build()).Build()
+
+		_, err := review.EditComment(a.Comment().Build().ID, "")
+
+		require.ErrorContains(t, err, "cannot remove the body of a comment, delete it instead")
+	})
+
+	t.Run("changes the body and when it was updated", func(t *testing.T) {
+		original := a.Comment().Build()
+		review := a.Review().WithComment(original).Build()
+
+		actual, err := review.EditComment(original.ID, "I was wrong")
+
+		require.NoError(t, err)
+		require.Equal(t, "I was wrong", actual.Comments[0].Body)
+		require.True(t, actual.Comments[0].UpdatedAt.After(original.UpdatedAt))
+		require.Equal(t, original.Body, review.Comments[0].Body, "expected the original review to not have been changed")
+	})
+}
+
+func TestReview_DeleteComment(t *testing.T) {
+	t.Run("deleting a comment that doesn't exist returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+
+		_, err := review.DeleteComment(a.UUID())
+
+		require.ErrorContains(t, err, "cannot delete a comment that doesn't exist:")
+	})
+
+	t.Run("removes the body but keeps the comment so the replies are kept in the thread", func(t *testing.T) {
+		parent := a.Comment().Build()
+		reply := a.Comment().WithID(a.UUID()).IsReplyTo(parent).Build()
+		review := a.Review().WithComment(parent, reply).Build()
+
+		actual, err := review.DeleteComment(parent.ID)
+
+		require.NoError(t, err)
+		threads := actual.CommentThreads(parent.Subject)
+		require.Len(t, threads, 1)
+		require.True(t, threads[0].IsDeleted)
+		require.Empty(t, threads[0].Body)
+		require.Equal(t, []reviewing.CommentThread{{Comment: reply}}, threads[0].Replies)
+	})
+}
+
+func TestReview_CommentThreads(t *testing.T) {
+	t.Run("returns the threads about the subject with their replies nested", func(t *testing.T) {
+		review := a.Review().WithContributingCause().Build()
+		onCause := a.Comment().WithID(a.UUID()).WithSubject(reviewing.CommentOnBoundCause, review.BoundCauses[0].ID).Build()
+		first := a.Comment().WithID(a.UUID()).Build()
+		reply := a.Comment().WithID(a.UUID()).IsReplyTo(first).Build()
+		replyToReply := a.Comment().WithID(a.UUID()).IsReplyTo(reply).Build()
+		second := a.Comment().WithID(a.UUID()).Build()
+		review = a.Review().WithContributingCause().WithComment(first, onCause, reply, second, replyToReply).Build()
+
+		actual := review.CommentThreads(reviewing.CommentSubject{Kind: reviewing.CommentOnReview, ID: review.ID})
+
+		require.Equal(
+			t,
+			[]reviewing.CommentThread{
+				{Comment: first, Replies: []reviewing.CommentThread{{Comment: reply, Replies: []reviewing.CommentThread{{Comment: replyToReply}}}}},
+				{Comment: second},
+			},
+			actual,
+		)
+	})
+	t.Run("threads about items no longer on the review are returned as on removed items", func(t *testing.T) {
+		review := a.Review().WithContributingCause().WithBoundTrigger(a.BoundTrigger().Build()).Build()
+		onCause := a.Comment().WithID(a.UUID()).WithSubject(reviewing.CommentOnBoundCause, review.BoundCauses[0].ID).Build()
+		onTrigger := a.Comment().WithID(a.UUID()).WithSubject(reviewing.CommentOnBoundTrigger, review.BoundTriggers[0].ID).Build()
+		review = a.Review().WithContributingCause().WithBoundTrigger(a.BoundTrigger().Build()).WithComment(a.Comment().Build(), onCause, onTrigger).Build()
+		require.Empty(t, review.CommentThreadsOnRemovedItems(), "expected nothing on removed items while they're bound")
+
+		review, err := review.UnbindContributingCause(review.BoundCauses[0].ID)
+		require.NoError(t, err)
+		review, err = review.UnbindTrigger(review.BoundTriggers[0].ID)
+		require.NoError(t, err)
+
+		require.Equal(t, []reviewing.CommentThread{{Comment: onCause}, {Comment: onTrigger}}, review.CommentThreadsOnRemovedItems())
+	})
+}
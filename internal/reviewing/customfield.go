@@ -0,0 +1,217 @@
+package reviewing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/action"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
+)
+
+// FieldKind is what kind of values a custom field takes.
+type FieldKind string
+
+const (
+	FieldText    FieldKind = "text"
+	FieldNumber  FieldKind = "number"
+	FieldDate    FieldKind = "date"
+	FieldEnum    FieldKind = "enum"
+	FieldBoolean FieldKind = "boolean"
+)
+
+// FieldKinds are all the kinds of custom fields in the order they're offered.
+var FieldKinds = []FieldKind{FieldText, FieldNumber, FieldDate, FieldEnum, FieldBoolean}
+
+// DateLayout is how the values of date fields are written.
+const DateLayout = time.DateOnly
+
+// CustomField is an extra field the reviews of a team have, like "customer tier affected" or "region".
+// The values are kept on the review by the ID of the field, see Review.CustomValues.
+type CustomField struct {
+	ID     uuid.UUID `validate:"required"`
+	TeamID uuid.UUID
+	Name   string    `validate:"required"`
+	Kind   FieldKind `validate:"required,oneof=text number date enum boolean"`
+	// Options are what can be picked for an enum.
+	Options []string `validate:"required_if=Kind enum,dive,required"`
+	// Required fields have to be filled in when someone creates a review, and when a draft the system created,
+	// like from an incident, is moved out of draft since nobody could fill them in before that.
+	// The reviews created before the field was added aren't stopped from changing by it.
+	Required bool
+
+	CreatedBy uuid.UUID
+	UpdatedBy uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewCustomField() CustomField {
+	return CustomField{ID: uuid.Must(uuid.NewV7()), Kind: FieldText}
+}
+
+// Check returns why the value doesn't fit the field, in words for people. An empty value is only wrong when required.
+func (f CustomField) Check(value string) error {
+	if value == "" {
+		if f.Required {
+			return errors.New("Can't be empty.")
+		}
+		return nil
+	}
+
+	switch f.Kind {
+	case FieldNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return errors.New("Has to be a number.")
+		}
+	case FieldDate:
+		if _, err := time.Parse(DateLayout, value); err != nil {
+			return errors.New("Has to be a date like " + DateLayout + ".")
+		}
+	case FieldEnum:
+		if !slices.Contains(f.Options, value) {
+			return errors.New("Has to be one of: " + strings.Join(f.Options, ", ") + ".")
+		}
+	case FieldBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return errors.New("Has to be yes or no.")
+		}
+	}
+
+	return nil
+}
+
+// checkCustomValues checks the values of the review against the fields, by the ID of the field.
+// Only the values that changed from before are checked, so changing a field doesn't stop the reviews created before
+// it changed from being saved, and Required is only checked when required is set, see CustomField.Required.
+func checkCustomValues(fields []CustomField, r Review, before map[uuid.UUID]string, required bool) validate.FieldErrors {
+	errs := make(validate.FieldErrors)
+	for _, f := range fields {
+		f.Required = f.Required && required
+		value := r.CustomValues[f.ID]
+		if !r.CreatedAt.IsZero() && value == before[f.ID] && !f.Required {
+			continue
+		}
+		if err := f.Check(value); err != nil {
+			errs[f.ID.String()] = err.Error()
+		}
+	}
+
+	return errs
+}
+
+// updateChangedBy records who is saving, and if it's the first save who created it.
+func (f CustomField) updateChangedBy(by uuid.UUID) CustomField {
+	if f.CreatedAt.IsZero() {
+		f.CreatedBy = by
+	}
+	f.UpdatedBy = by
+
+	return f
+}
+
+func (f CustomField) updateTimestamps() CustomField {
+	now := time.Now()
+	if f.CreatedAt.IsZero() {
+		f.CreatedAt = now
+	}
+	f.UpdatedAt = now
+
+	return f
+}
+
+type customFieldLister interface {
+	All(ctx context.Context) ([]CustomField, error)
+}
+
+// WithCustomFields checks the custom values of the reviews against the custom fields of the team before saving.
+func WithCustomFields(fields customFieldLister) Option {
+	return func(s *Service) {
+		action.Use(s.actions, ActionSave(), "customFields", func(next func(context.Context, Review) (Review, error)) func(context.Context, Review) (Review, error) {
+			return func(ctx context.Context, r Review) (Review, error) {
+				all, err := fields.All(ctx)
+				if err != nil {
+					return r, fmt.Errorf("failed to get custom fields: %w", err)
+				}
+				// The system has no ID, and the drafts it creates are filled in by people before they move on
+				required := r.CreatedAt.IsZero() && actor.ID(ctx) != uuid.Nil
+				var before map[uuid.UUID]string
+				if !r.CreatedAt.IsZero() {
+					stored, err := s.reviewStore.Get(ctx, r.ID)
+					if err != nil {
+						return r, fmt.Errorf("failed to get review to check its custom values: %w", err)
+					}
+					before = stored.CustomValues
+					required = r.CreatedBy == uuid.Nil && stored.State == StateDraft && r.State != StateDraft
+				}
+				errs := checkCustomValues(all, r, before, required)
+
+				saved, err := next(ctx, r)
+				if len(errs) > 0 {
+					// Tell them everything that's wrong at once, not only about the custom fields
+					return r, fmt.Errorf("failed to validate review: %w", validate.Fail(errs, err))
+				}
+
+				return saved, err
+			}
+		})
+	}
+}
+
+type CustomFieldService struct {
+	store CustomFieldStorage
+}
+
+func NewCustomFieldService(store CustomFieldStorage) *CustomFieldService {
+	return &CustomFieldService{store: store}
+}
+
+// Save validates and stores the field for the team being worked as, only admins can manage custom fields.
+func (s *CustomFieldService) Save(ctx context.Context, f CustomField) (CustomField, error) {
+	if err := actor.Require(ctx, actor.Admin); err != nil {
+		return f, fmt.Errorf("only admins can manage custom fields: %w", err)
+	}
+	if f.CreatedAt.IsZero() {
+		f.TeamID = tenant.ID(ctx)
+	}
+
+	if err := validate.Struct(ctx, f); err != nil {
+		return f, fmt.Errorf("failed to validate custom field: %w", err)
+	}
+
+	f = f.updateChangedBy(actor.ID(ctx)).updateTimestamps()
+
+	f, err := s.store.Save(ctx, f)
+	if err != nil {
+		return f, fmt.Errorf("failed to store custom field: %w", err)
+	}
+
+	return f, nil
+}
+
+func (s *CustomFieldService) Get(ctx context.Context, id uuid.UUID) (CustomField, error) {
+	f, err := s.store.Get(ctx, id)
+	if err != nil {
+		return CustomField{}, fmt.Errorf("failed to get custom field: %w", err)
+	}
+
+	return f, nil
+}
+
+// All returns the custom fields of the team being worked as.
+func (s *CustomFieldService) All(ctx context.Context) ([]CustomField, error) {
+	ret, err := s.store.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all custom fields: %w", err)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,181 @@
+package reviewing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
+	contribstorage "github.com/gaqzi/incident-reviewer/internal/normalized/contributing/storage"
+	normstorage "github.com/gaqzi/incident-reviewer/internal/normalized/storage"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing/storage"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestCustomField_Check(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		field    reviewing.CustomField
+		value    string
+		expected string
+	}{
+		{"any text is fine", reviewing.CustomField{Kind: reviewing.FieldText}, "anything", ""},
+		{"an empty value is fine when not required", reviewing.CustomField{Kind: reviewing.FieldNumber}, "", ""},
+		{"an empty value is not fine when required", reviewing.CustomField{Kind: reviewing.FieldText, Required: true}, "", "Can't be empty."},
+		{"a number", reviewing.CustomField{Kind: reviewing.FieldNumber}, "12.5", ""},
+		{"not a number", reviewing.CustomField{Kind: reviewing.FieldNumber}, "twelve", "Has to be a number."},
+		{"a date", reviewing.CustomField{Kind: reviewing.FieldDate}, "2025-01-31", ""},
+		{"not a date", reviewing.CustomField{Kind: reviewing.FieldDate}, "31/01/2025", "Has to be a date like 2006-01-02."},
+		{"one of the options", reviewing.CustomField{Kind: reviewing.FieldEnum, Options: []string{"EU", "US"}}, "US", ""},
+		{"not one of the options", reviewing.CustomField{Kind: reviewing.FieldEnum, Options: []string{"EU", "US"}}, "APAC", "Has to be one of: EU, US."},
+		{"a boolean", reviewing.CustomField{Kind: reviewing.FieldBoolean}, "true", ""},
+		{"not a boolean", reviewing.CustomField{Kind: reviewing.FieldBoolean}, "maybe", "Has to be yes or no."},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.field.Check(tc.value)
+
+			if tc.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReview_Update_customValues(t *testing.T) {
+	region, tier := a.UUID(), a.UUID()
+	review := a.Review().Modify(func(r *reviewing.Review) { r.CustomValues = map[uuid.UUID]string{region: "EU", tier: "Gold"} }).Build()
+
+	updated := review.Update(reviewing.Review{CustomValues: map[uuid.UUID]string{region: "US", tier: ""}})
+
+	require.Equal(t, map[uuid.UUID]string{region: "US"}, updated.CustomValues, "expected an empty value to clear it")
+	require.Equal(t, "EU", review.CustomValues[region], "expected the original review to not be changed")
+	require.Equal(t, updated.CustomValues, updated.Update(reviewing.Review{}).CustomValues, "expected to keep the values when updating without them")
+}
+
+func TestWithCustomFields(t *testing.T) {
+	setup := func(t *testing.T, fields ...reviewing.CustomField) *reviewing.Service {
+		t.Helper()
+		customFields := reviewing.NewCustomFieldService(storage.NewCustomFieldMemoryStore())
+		for _, f := range fields {
+			_, err := customFields.Save(adminCtx, f)
+			require.NoError(t, err)
+		}
+
+		service, err := reviewing.NewService(
+			storage.NewMemoryStore(),
+			contributing.NewCauseService(contribstorage.NewCauseMemoryStore()),
+			normalized.NewTriggerService(normstorage.NewTriggerMemoryStore()),
+			reviewing.WithCustomFields(customFields),
+		)
+		require.NoError(t, err)
+
+		return service
+	}
+	region := reviewing.NewCustomField()
+	region.Name = "Region"
+	region.Kind = reviewing.FieldEnum
+	region.Options = []string{"EU", "US"}
+	region.Required = true
+
+	t.Run("the values are checked together with the rest of the review", func(t *testing.T) {
+		service := setup(t, region)
+
+		_, err := service.Save(adminCtx, a.Review().IsNotSaved().Modify(func(r *reviewing.Review) {
+			r.Title = ""
+			r.CustomValues = map[uuid.UUID]string{region.ID: "APAC"}
+		}).Build())
+
+		errs, ok := validate.Fields(err)
+		require.True(t, ok, "expected a validation error, got: %v", err)
+		require.Equal(t, validate.FieldErrors{region.ID.String(): "Has to be one of: EU, US.", "Title": "Can't be empty."}, errs)
+	})
+
+	t.Run("a required field only has to be filled in for new reviews", func(t *testing.T) {
+		service := setup(t, region)
+
+		_, err := service.Save(adminCtx, a.Review().IsNotSaved().Build())
+		require.ErrorContains(t, err, region.ID.String()+": Can't be empty.")
+
+		review, err := service.Save(adminCtx, a.Review().IsNotSaved().Modify(func(r *reviewing.Review) {
+			r.CustomValues = map[uuid.UUID]string{region.ID: "EU"}
+		}).Build())
+		require.NoError(t, err)
+		update := review
+		update.CustomValues = map[uuid.UUID]string{region.ID: ""}
+
+		updated, err := service.Update(adminCtx, review.ID, update)
+
+		require.NoError(t, err, "expected a review that's been created to be able to change without it")
+		require.Empty(t, updated.CustomValues)
+	})
+
+	t.Run("only the values that changed are checked, so changing a field doesn't stop the reviews from changing", func(t *testing.T) {
+		customFields := reviewing.NewCustomFieldService(storage.NewCustomFieldMemoryStore())
+		region, err := customFields.Save(adminCtx, region)
+		require.NoError(t, err)
+		service, err := reviewing.NewService(storage.NewMemoryStore(), nil, nil, reviewing.WithCustomFields(customFields))
+		require.NoError(t, err)
+		review, err := service.Save(adminCtx, a.Review().IsNotSaved().Modify(func(r *reviewing.Review) {
+			r.CustomValues = map[uuid.UUID]string{region.ID: "EU"}
+		}).Build())
+		require.NoError(t, err)
+		region.Options = []string{"US"}
+		_, err = customFields.Save(adminCtx, region)
+		require.NoError(t, err)
+
+		review.Title = "Changed"
+		_, err = service.Save(adminCtx, review)
+		require.NoError(t, err, "expected the value that didn't change to not be checked")
+
+		review.CustomValues = map[uuid.UUID]string{region.ID: "APAC"}
+		_, err = service.Save(adminCtx, review)
+		require.ErrorContains(t, err, "Has to be one of: US.")
+	})
+}
+
+func TestCustomFieldService_Save(t *testing.T) {
+	t.Run("only admins can manage custom fields", func(t *testing.T) {
+		service := reviewing.NewCustomFieldService(storage.NewCustomFieldMemoryStore())
+
+		_, err := service.Save(actor.With(context.Background(), a.Actor().Build()), reviewing.NewCustomField())
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
+	t.Run("an enum needs options", func(t *testing.T) {
+		service := reviewing.NewCustomFieldService(storage.NewCustomFieldMemoryStore())
+		f := reviewing.NewCustomField()
+		f.Name = "Region"
+		f.Kind = reviewing.FieldEnum
+
+		_, err := service.Save(adminCtx, f)
+
+		errs, ok := validate.Fields(err)
+		require.True(t, ok)
+		require.Contains(t, errs, "Options")
+	})
+
+	t.Run("belongs to the team being worked as", func(t *testing.T) {
+		team := a.UUID()
+		service := reviewing.NewCustomFieldService(storage.NewCustomFieldMemoryStore())
+		f := reviewing.NewCustomField()
+		f.Name = "Customer tier affected"
+
+		saved, err := service.Save(tenant.With(adminCtx, team), f)
+
+		require.NoError(t, err)
+		require.Equal(t, team, saved.TeamID)
+		all, err := service.All(adminCtx)
+		require.NoError(t, err)
+		require.Empty(t, all, "expected another team to not see it")
+	})
+}
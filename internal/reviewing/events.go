@@ -0,0 +1,60 @@
+package reviewing
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+)
+
+// The events are published after the review has been saved, with the review as it was saved.
+// Every change that isn't one of the more specific events is a ReviewUpdated.
+type (
+	ReviewCreated struct {
+		Review Review
+	}
+	ReviewUpdated struct {
+		Review Review
+	}
+	ReviewTransitioned struct {
+		Review Review
+		From   State
+	}
+	CauseBound struct {
+		Review     Review
+		BoundCause BoundCause
+	}
+	TriggerBound struct {
+		Review       Review
+		BoundTrigger BoundTrigger
+	}
+)
+
+func (ReviewCreated) EventName() string      { return "reviewing.ReviewCreated" }
+func (ReviewUpdated) EventName() string      { return "reviewing.ReviewUpdated" }
+func (ReviewTransitioned) EventName() string { return "reviewing.ReviewTransitioned" }
+func (CauseBound) EventName() string         { return "reviewing.CauseBound" }
+func (TriggerBound) EventName() string       { return "reviewing.TriggerBound" }
+
+func init() {
+	event.Register[ReviewCreated]()
+	event.Register[ReviewUpdated]()
+	event.Register[ReviewTransitioned]()
+	event.Register[CauseBound]()
+	event.Register[TriggerBound]()
+}
+
+// WithEvents publishes what happens to reviews.
+func WithEvents(p event.Publisher) Option {
+	return func(s *Service) {
+		s.events = p
+	}
+}
+
+// publish doesn't fail the change when the event can't be published, since the review has already been saved.
+// Once published the event is in the outbox until every subscriber has handled it, even across restarts.
+func (s *Service) publish(ctx context.Context, e event.Event) {
+	if err := s.events.Publish(ctx, e); err != nil {
+		slog.Error("failed to publish event", "event", e.EventName(), "error", err)
+	}
+}
@@ -0,0 +1,172 @@
+package reviewing_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
+	contribstorage "github.com/gaqzi/incident-reviewer/internal/normalized/contributing/storage"
+	normstorage "github.com/gaqzi/incident-reviewer/internal/normalized/storage"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing/storage"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+// recordingPublisher keeps the events it's told to publish.
+type recordingPublisher struct {
+	events []event.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, events ...event.Event) error {
+	p.events = append(p.events, events...)
+
+	return nil
+}
+
+func TestWithEvents(t *testing.T) {
+	published := new(recordingPublisher)
+	causes := contributing.NewCauseService(contribstorage.NewCauseMemoryStore())
+	triggers := normalized.NewTriggerService(normstorage.NewTriggerMemoryStore())
+	service, err := reviewing.NewService(storage.NewMemoryStore(), causes, triggers, reviewing.WithEvents(published))
+	require.NoError(t, err)
+	cause, err := causes.Save(adminCtx, a.ContributingCause().IsNotSaved().Build())
+	require.NoError(t, err)
+	trigger, err := triggers.Save(adminCtx, a.NormalizedTrigger().IsNotSaved().Build())
+	require.NoError(t, err)
+
+	review, err := service.Save(adminCtx, a.Review().IsNotSaved().Build())
+	require.NoError(t, err)
+	review, err = service.Update(adminCtx, review.ID, a.Review().Build())
+	require.NoError(t, err)
+	boundCause := a.BoundCause().WithCause(cause).Build()
+	require.NoError(t, service.BindContributingCause(adminCtx, review.ID, cause.ID, boundCause))
+	require.NoError(t, service.BindTrigger(adminCtx, review.ID, trigger.ID, reviewing.UnboundTrigger{Why: "It happened"}))
+	review, err = service.Transition(adminCtx, review.ID, reviewing.StateInReview)
+	require.NoError(t, err)
+	_, err = service.Transition(adminCtx, review.ID, reviewing.StatePublished)
+	require.Error(t, err, "expected the review to not be able to skip to published")
+
+	require.Len(t, published.events, 5, "expected to only publish the changes that were saved")
+	require.IsType(t, reviewing.ReviewCreated{}, published.events[0])
+	require.IsType(t, reviewing.ReviewUpdated{}, published.events[1])
+	require.Equal(t, boundCause.ID, published.events[2].(reviewing.CauseBound).BoundCause.ID)
+	require.Equal(t, trigger.ID, published.events[3].(reviewing.TriggerBound).BoundTrigger.Trigger.ID)
+	transitioned := published.events[4].(reviewing.ReviewTransitioned)
+	require.Equal(t, reviewing.StateDraft, transitioned.From)
+	require.Equal(t, reviewing.StateInReview, transitioned.Review.State)
+
+}
+
+func TestWithEvents_BindContributingCause(t *testing.T) {
+	published := new(recordingPublisher)
+	causes := contributing.NewCauseService(contribstorage.NewCauseMemoryStore())
+	service, err := reviewing.NewService(storage.NewMemoryStore(), causes, nil, reviewing.WithEvents(published))
+	require.NoError(t, err)
+	cause, err := causes.Save(adminCtx, a.ContributingCause().IsNotSaved().Build())
+	require.NoError(t, err)
+	review, err := service.Save(adminCtx, a.Review().IsNotSaved().Build())
+	require.NoError(t, err)
+
+	require.NoError(t, service.BindContributingCause(adminCtx, review.ID, cause.ID, reviewing.BoundCause{Why: "It happened"}))
+
+	require.Len(t, published.events, 2, "expected the bound cause to be published without its ID set in advance")
+	bound := published.events[1].(reviewing.CauseBound).BoundCause
+	require.NotZero(t, bound.ID)
+	require.Equal(t, "It happened", bound.Why)
+}
+
+func TestWithEvents_ReviewUpdated(t *testing.T) {
+	published := new(recordingPublisher)
+	causes := contributing.NewCauseService(contribstorage.NewCauseMemoryStore())
+	triggers := normalized.NewTriggerService(normstorage.NewTriggerMemoryStore())
+	service, err := reviewing.NewService(storage.NewMemoryStore(), causes, triggers, reviewing.WithEvents(published))
+	require.NoError(t, err)
+	cause, err := causes.Save(adminCtx, a.ContributingCause().IsNotSaved().Build())
+	require.NoError(t, err)
+	trigger, err := triggers.Save(adminCtx, a.NormalizedTrigger().IsNotSaved().Build())
+	require.NoError(t, err)
+	review, err := service.Save(adminCtx, a.Review().IsNotSaved().Build())
+	require.NoError(t, err)
+	require.NoError(t, service.BindContributingCause(adminCtx, review.ID, cause.ID, reviewing.BoundCause{Why: "It happened"}))
+	require.NoError(t, service.BindTrigger(adminCtx, review.ID, trigger.ID, reviewing.UnboundTrigger{Why: "It happened"}))
+	review, err = service.Get(adminCtx, review.ID)
+	require.NoError(t, err)
+	boundCause, boundTrigger := review.BoundCauses[0], review.BoundTriggers[0]
+
+	for _, tc := range []struct {
+		name   string
+		change func() error
+	}{
+		{"UpdateBoundContributingCause", func() error {
+			_, err := service.UpdateBoundContributingCause(adminCtx, review.ID, boundCause)
+			return err
+		}},
+		{"UpdateBoundTrigger", func() error {
+			_, err := service.UpdateBoundTrigger(adminCtx, review.ID, boundTrigger)
+			return err
+		}},
+		{"VoteOnBoundContributingCause", func() error {
+			_, err := service.VoteOnBoundContributingCause(adminCtx, review.ID, boundCause.ID, a.Vote().Build())
+			return err
+		}},
+		{"VoteOnBoundTrigger", func() error {
+			_, err := service.VoteOnBoundTrigger(adminCtx, review.ID, boundTrigger.ID, a.Vote().Build())
+			return err
+		}},
+		{"AddComment, EditComment and DeleteComment", func() error {
+			comment, err := service.AddComment(adminCtx, review.ID, a.Comment().IsNotSaved().WithSubject(reviewing.CommentOnReview, review.ID).Build())
+			if err != nil {
+				return err
+			}
+			if _, err := service.EditComment(adminCtx, review.ID, comment.ID, "Changed my mind"); err != nil {
+				return err
+			}
+			_, err = service.DeleteComment(adminCtx, review.ID, comment.ID)
+			return err
+		}},
+		{"Attach and RemoveAttachment", func() error {
+			attachment, err := service.Attach(adminCtx, review.ID, reviewing.Attachment{Name: "log.txt"}, strings.NewReader("an error happened"))
+			if err != nil {
+				return err
+			}
+			_, err = service.RemoveAttachment(adminCtx, review.ID, attachment.ID)
+			return err
+		}},
+		{"AddMember and RemoveMember", func() error {
+			userID := a.UUID()
+			if _, err := service.AddMember(adminCtx, review.ID, userID, reviewing.MemberParticipant); err != nil {
+				return err
+			}
+			_, err := service.RemoveMember(adminCtx, review.ID, userID)
+			return err
+		}},
+		{"UnbindContributingCause", func() error {
+			return service.UnbindContributingCause(adminCtx, review.ID, boundCause.ID)
+		}},
+		{"UnbindTrigger", func() error {
+			return service.UnbindTrigger(adminCtx, review.ID, boundTrigger.ID)
+		}},
+		{"MoveToTeam", func() error {
+			_, err := service.MoveToTeam(adminCtx, review.ID, a.UUID())
+			return err
+		}},
+	} {
+		t.Run(tc.name+" publishes that the review was updated", func(t *testing.T) {
+			published.events = nil
+
+			require.NoError(t, tc.change())
+
+			require.NotEmpty(t, published.events)
+			for _, e := range published.events {
+				updated, ok := e.(reviewing.ReviewUpdated)
+				require.True(t, ok, "expected every change to publish ReviewUpdated, got %T", e)
+				require.Equal(t, review.ID, updated.Review.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package reviewing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+type PublicationRuleKind string
+
+const (
+	// RuleProximalCause requires one of the bound causes to be marked as the proximal cause.
+	RuleProximalCause PublicationRuleKind = "proximal-cause"
+	// RuleBoundTrigger requires at least Min triggers to be bound, one if Min isn't set.
+	RuleBoundTrigger PublicationRuleKind = "bound-trigger"
+	// RuleMinLength requires Field to be at least Min characters long, ignoring surrounding whitespace.
+	RuleMinLength PublicationRuleKind = "min-length"
+)
+
+// minLengthFields are the fields on a Review that RuleMinLength can be used on.
+var minLengthFields = map[string]func(Review) string{
+	"Title":               func(r Review) string { return r.Title },
+	"Description":         func(r Review) string { return r.Description },
+	"Impact":              func(r Review) string { return r.Impact },
+	"Where":               func(r Review) string { return r.Where },
+	"ReportProximalCause": func(r Review) string { return r.ReportProximalCause },
+	"ReportTrigger":       func(r Review) string { return r.ReportTrigger },
+}
+
+// PublicationRule is a requirement a Review has to meet before it can be published.
+type PublicationRule struct {
+	Kind  PublicationRuleKind `json:"kind"`
+	Field string              `json:"field,omitempty"`
+	Min   int                 `json:"min,omitempty"`
+	// Description is what's shown to people in the checklist, a description is generated when it's empty.
+	Description string `json:"description,omitempty"`
+}
+
+// Describe returns the description of the rule to show in a checklist.
+func (pr PublicationRule) Describe() string {
+	if pr.Description != "" {
+		return pr.Description
+	}
+
+	switch pr.Kind {
+	case RuleProximalCause:
+		return "A contributing cause is marked as the proximal cause"
+	case RuleBoundTrigger:
+		if pr.Min > 1 {
+			return fmt.Sprintf("At least %d triggers are bound", pr.Min)
+		}
+		return "At least one trigger is bound"
+	case RuleMinLength:
+		return fmt.Sprintf("%s is at least %d characters", pr.Field, pr.Min)
+	default:
+		return string(pr.Kind)
+	}
+}
+
+func (pr PublicationRule) validate() error {
+	switch pr.Kind {
+	case RuleProximalCause, RuleBoundTrigger:
+		return nil
+	case RuleMinLength:
+		if _, ok := minLengthFields[pr.Field]; !ok {
+			return errors.New("unknown field for " + string(RuleMinLength) + ": " + pr.Field)
+		}
+		if pr.Min < 1 {
+			return errors.New(string(RuleMinLength) + " for " + pr.Field + " needs a min above zero")
+		}
+		return nil
+	default:
+		return errors.New("unknown publication rule kind: " + string(pr.Kind))
+	}
+}
+
+func (pr PublicationRule) passes(r Review) bool {
+	switch pr.Kind {
+	case RuleProximalCause:
+		return slices.ContainsFunc(r.BoundCauses, func(bc BoundCause) bool { return bc.IsProximalCause })
+	case RuleBoundTrigger:
+		return len(r.BoundTriggers) >= max(pr.Min, 1)
+	case RuleMinLength:
+		field, ok := minLengthFields[pr.Field]
+		return ok && utf8.RuneCountInString(strings.TrimSpace(field(r))) >= pr.Min
+	default:
+		return false
+	}
+}
+
+type PublicationRules []PublicationRule
+
+// DefaultPublicationRules are used when no rules have been configured.
+func DefaultPublicationRules() PublicationRules {
+	return PublicationRules{
+		{Kind: RuleProximalCause},
+		{Kind: RuleBoundTrigger},
+		{Kind: RuleMinLength, Field: "Impact", Min: 20},
+	}
+}
+
+// LoadPublicationRules reads a JSON list of rules and makes sure they're all known,
+// so a mistake in the configuration is found at startup and not when someone tries to publish.
+func LoadPublicationRules(r io.Reader) (PublicationRules, error) {
+	var rules PublicationRules
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("failed to decode publication rules: %w", err)
+	}
+
+	for i, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("invalid publication rule at position %d: %w", i, err)
+		}
+	}
+
+	return rules, nil
+}
+
+// ChecklistItem is the outcome of checking a single rule.
+type ChecklistItem struct {
+	Rule   PublicationRule
+	Passed bool
+}
+
+type Checklist []ChecklistItem
+
+// Check runs all the rules against the review.
+func (rules PublicationRules) Check(r Review) Checklist {
+	ret := make(Checklist, 0, len(rules))
+	for _, rule := range rules {
+		ret = append(ret, ChecklistItem{Rule: rule, Passed: rule.passes(r)})
+	}
+
+	return ret
+}
+
+// Passed is true when all the rules passed.
+func (c Checklist) Passed() bool {
+	return !slices.ContainsFunc(c, func(i ChecklistItem) bool { return !i.Passed })
+}
+
+// PublicationError is returned when a Review doesn't pass all the rules to be published.
+type PublicationError struct {
+	Failed Checklist
+}
+
+func (e *PublicationError) Error() string {
+	descriptions := make([]string, 0, len(e.Failed))
+	for _, item := range e.Failed {
+		descriptions = append(descriptions, item.Rule.Describe())
+	}
+
+	return "review doesn't meet the rules for publishing: " + strings.Join(descriptions, "; ")
+}
+
+// Is makes it a failure.Conflict, since the review has to change before it can be published.
+func (e *PublicationError) Is(target error) bool {
+	return target == failure.Conflict
+}
+
+// Publishable returns a PublicationError with the rules that failed unless the review passes all the rules.
+func (rules PublicationRules) Publishable(r Review) error {
+	var failed Checklist
+	for _, item := range rules.Check(r) {
+		if !item.Passed {
+			failed = append(failed, item)
+		}
+	}
+
+	if len(failed) > 0 {
+		return &PublicationError{Failed: failed}
+	}
+
+	return nil
+}
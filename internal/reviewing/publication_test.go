@@ -0,0 +1,130 @@
+package reviewing_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestPublicationRules_Check(t *testing.T) {
+	t.Run("a review without causes, triggers, or a described impact fails all the default rules", func(t *testing.T) {
+		review := a.Review().Modify(func(r *reviewing.Review) { r.Impact = "bad" }).Build()
+
+		actual := reviewing.DefaultPublicationRules().Check(review)
+
+		require.False(t, actual.Passed())
+		for _, item := range actual {
+			require.False(t, item.Passed, "expected %q to have failed", item.Rule.Describe())
+		}
+	})
+
+	t.Run("a review with a proximal cause, a trigger, and a long enough impact passes the default rules", func(t *testing.T) {
+		review := a.Review().
+			WithContributingCause(a.BoundCause().WithIsProximalCause(true).Build()).
+			WithBoundTrigger(a.BoundTrigger().Build()).
+			Modify(func(r *reviewing.Review) { r.Impact = "Checkout was down for all users" }).
+			Build()
+
+		actual := reviewing.DefaultPublicationRules().Check(review)
+
+		require.True(t, actual.Passed())
+	})
+
+	t.Run("bound causes that aren't the proximal cause don't count", func(t *testing.T) {
+		review := a.Review().WithContributingCause(a.BoundCause().Build()).Build()
+
+		actual := reviewing.PublicationRules{{Kind: reviewing.RuleProximalCause}}.Check(review)
+
+		require.False(t, actual.Passed())
+	})
+
+	t.Run("min-length ignores surrounding whitespace", func(t *testing.T) {
+		review := a.Review().Modify(func(r *reviewing.Review) { r.Where = "   abc   " }).Build()
+		rules := reviewing.PublicationRules{{Kind: reviewing.RuleMinLength, Field: "Where", Min: 4}}
+
+		require.False(t, rules.Check(review).Passed())
+	})
+
+	t.Run("bound-trigger uses min when set", func(t *testing.T) {
+		review := a.Review().WithBoundTrigger(a.BoundTrigger().Build()).Build()
+		rules := reviewing.PublicationRules{{Kind: reviewing.RuleBoundTrigger, Min: 2}}
+
+		require.False(t, rules.Check(review).Passed())
+		require.Equal(t, "At least 2 triggers are bound", rules[0].Describe())
+	})
+}
+
+func TestPublicationRules_Publishable(t *testing.T) {
+	t.Run("returns the failed rules in the error", func(t *testing.T) {
+		rules := reviewing.PublicationRules{
+			{Kind: reviewing.RuleBoundTrigger},
+			{Kind: reviewing.RuleMinLength, Field: "Title", Min: 1, Description: "Has a title"},
+		}
+
+		err := rules.Publishable(a.Review().Build())
+
+		var pubErr *reviewing.PublicationError
+		require.ErrorAs(t, err, &pubErr)
+		require.Len(t, pubErr.Failed, 1)
+		require.ErrorContains(t, err, "At least one trigger is bound")
+		require.ErrorIs(t, err, failure.Conflict, "expected the review to have to change before it can be published")
+	})
+
+	t.Run("returns nil when all rules pass", func(t *testing.T) {
+		require.NoError(t, reviewing.PublicationRules{}.Publishable(a.Review().Build()))
+	})
+}
+
+func TestLoadPublicationRules(t *testing.T) {
+	t.Run("reads the rules from JSON", func(t *testing.T) {
+		actual, err := reviewing.LoadPublicationRules(strings.NewReader(`[
+			{"kind": "proximal-cause"},
+			{"kind": "min-length", "field": "Impact", "min": 50, "description": "Impact is described"}
+		]`))
+
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			reviewing.PublicationRules{
+				{Kind: reviewing.RuleProximalCause},
+				{Kind: reviewing.RuleMinLength, Field: "Impact", Min: 50, Description: "Impact is described"},
+			},
+			actual,
+		)
+	})
+
+	t.Run("the example configuration is the default rules", func(t *testing.T) {
+		f, err := os.Open("../../docs/publication-rules.example.json")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = f.Close() })
+
+		actual, err := reviewing.LoadPublicationRules(f)
+
+		require.NoError(t, err)
+		require.Equal(t, reviewing.DefaultPublicationRules(), actual, "expected the documented example to match what's used when nothing is configured")
+	})
+
+	for _, tc := range []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"malformed JSON", `[{"kind":`, "failed to decode publication rules:"},
+		{"unknown attribute", `[{"kind": "proximal-cause", "minimum": 1}]`, "failed to decode publication rules:"},
+		{"unknown kind", `[{"kind": "reviewed-by-ceo"}]`, "unknown publication rule kind: reviewed-by-ceo"},
+		{"unknown field", `[{"kind": "min-length", "field": "Summary", "min": 1}]`, "unknown field for min-length: Summary"},
+		{"min-length without min", `[{"kind": "min-length", "field": "Impact"}]`, "needs a min above zero"},
+	} {
+		t.Run("returns an error for "+tc.name, func(t *testing.T) {
+			_, err := reviewing.LoadPublicationRules(strings.NewReader(tc.input))
+
+			require.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
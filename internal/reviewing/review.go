@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -14,6 +15,11 @@ import (
 
 	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
 	"github.com/gaqzi/incident-reviewer/internal/platform/action"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/blob"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
 )
 
 type Review struct {
@@ -26,16 +32,44 @@ type Review struct {
 	ReportProximalCause string    `validate:"required"`
 	ReportTrigger       string    `validate:"required"`
 
-	BoundCauses   []BoundCause
-	BoundTriggers []BoundTrigger
+	State       State `validate:"required,oneof=draft in_review awaiting_approval published archived"`
+	Transitions []Transition
 
+	BoundCauses   []BoundCause   `validate:"dive"`
+	BoundTriggers []BoundTrigger `validate:"dive"`
+	Comments      []Comment
+	// Attachments are the files attached to the review and its bound causes, see Attachment.
+	Attachments []Attachment
+
+	// Facilitators run the review and Participants work on it, see Allows for what they can do.
+	Facilitators []uuid.UUID
+	Participants []uuid.UUID
+
+	// TeamID is the team the review belongs to, uuid.Nil when it doesn't belong to one.
+	TeamID uuid.UUID
+
+	// IncidentStartedAt and IncidentResolvedAt are when the incident itself happened, zero when not known.
+	IncidentStartedAt  time.Time
+	IncidentResolvedAt time.Time
+
+	// TemplateID is the template the review was created from, uuid.Nil when it wasn't created from one.
+	// Guidance and Answers are copied from the template when it's created, see Template.Apply.
+	TemplateID uuid.UUID
+	Guidance   string
+	Answers    []Answer `validate:"dive"`
+
+	// CustomValues are the values of the custom fields of the team by the ID of the field, see CustomField.
+	CustomValues map[uuid.UUID]string
+
+	CreatedBy uuid.UUID
+	UpdatedBy uuid.UUID
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
-// NewReview returns a reviewing.Review with a valid ID set.
+// NewReview returns a reviewing.Review with a valid ID set as a draft.
 func NewReview() Review {
-	return Review{ID: uuid.Must(uuid.NewV7())}
+	return Review{ID: uuid.Must(uuid.NewV7()), State: StateDraft}
 }
 
 // Update takes the values from the passed in review and sets the fields that are allowed for mass changes.
@@ -49,6 +83,58 @@ func (r Review) Update(o Review) Review {
 	r.Where = o.Where
 	r.ReportProximalCause = o.ReportProximalCause
 	r.ReportTrigger = o.ReportTrigger
+	// Only the custom values passed in change, an empty value clears it
+	r.CustomValues = maps.Clone(r.CustomValues)
+	for id, v := range o.CustomValues {
+		if r.CustomValues == nil {
+			r.CustomValues = make(map[uuid.UUID]string)
+		}
+		if v == "" {
+			delete(r.CustomValues, id)
+		} else {
+			r.CustomValues[id] = v
+		}
+	}
+	// Only the answers passed in change, so updating without them, like from the API, keeps what was answered
+	r.Answers = slices.Clone(r.Answers)
+	for _, a := range o.Answers {
+		if i := slices.IndexFunc(r.Answers, func(ra Answer) bool { return ra.Field == a.Field }); i >= 0 {
+			r.Answers[i].Text = a.Text
+		}
+	}
+
+	return r
+}
+
+// Answer is the text answered for the field of the template, empty when it's not answered.
+func (r Review) Answer(field string) string {
+	for _, a := range r.Answers {
+		if a.Field == field {
+			return a.Text
+		}
+	}
+
+	return ""
+}
+
+// MoveToTeam has the review belong to another team, uuid.Nil for it to not belong to any team.
+// What has been bound to it stays, even if it came from the old team's catalog.
+func (r Review) MoveToTeam(teamID uuid.UUID) Review {
+	r.TeamID = teamID
+
+	return r
+}
+
+// updateChangedBy records who is saving, and if it's the first save who created it and is facilitating it.
+// Like updateTimestamps it's called by the service before storing.
+func (r Review) updateChangedBy(by uuid.UUID) Review {
+	if r.CreatedAt.IsZero() {
+		r.CreatedBy = by
+		if by != uuid.Nil && !slices.Contains(r.Facilitators, by) {
+			r.Facilitators = append(slices.Clone(r.Facilitators), by)
+		}
+	}
+	r.UpdatedBy = by
 
 	return r
 }
@@ -69,6 +155,10 @@ func (r Review) updateTimestamps() Review {
 
 // BindContributingCause validates the rc for uniqueness and ensures only one proximal cause at a time.
 func (r Review) BindContributingCause(rc BoundCause) (Review, error) {
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
 	// If the new BoundCause is proximal we need to ensure the other ones aren't, so unset when we're iterating over.
 	unsetProximal := func(c BoundCause) BoundCause { return c }
 	if rc.IsProximalCause {
@@ -82,11 +172,14 @@ func (r Review) BindContributingCause(rc BoundCause) (Review, error) {
 	if rc.ID == uuid.Nil {
 		rc.ID = uuid.Must(uuid.NewV7())
 	}
+	if rc.BoundAt.IsZero() {
+		rc.BoundAt = time.Now()
+	}
 
 	for i, c := range r.BoundCauses {
 		if c.Cause.ID == rc.Cause.ID &&
 			strings.EqualFold(strings.TrimSpace(c.Why), strings.TrimSpace(rc.Why)) {
-			return r, errors.New("cannot bind contributing cause with the same why: " + rc.Why)
+			return r, failure.New(failure.Conflict, "cannot bind contributing cause with the same why: "+rc.Why)
 		}
 
 		r.BoundCauses[i] = unsetProximal(c)
@@ -98,11 +191,22 @@ func (r Review) BindContributingCause(rc BoundCause) (Review, error) {
 }
 
 func (r Review) UpdateBoundContributingCause(o BoundCause) (Review, error) {
-	causes := slices.DeleteFunc(r.BoundCauses, func(rc BoundCause) bool { return rc.ID == o.ID })
-	if len(causes) == len(r.BoundCauses) {
-		return r, errors.New("cannot update contributing cause that isn't already bound")
+	if err := r.ensureEditable(); err != nil {
+		return r, err
 	}
 
+	i := slices.IndexFunc(r.BoundCauses, func(rc BoundCause) bool { return rc.ID == o.ID })
+	if i == -1 {
+		return r, failure.New(failure.NotFound, "cannot update contributing cause that isn't already bound")
+	}
+	// The votes are cast by the participants and not part of the update, so keep them around.
+	if o.Votes == nil {
+		o.Votes = r.BoundCauses[i].Votes
+	}
+	o.BoundAt = r.BoundCauses[i].BoundAt
+
+	causes := slices.DeleteFunc(r.BoundCauses, func(rc BoundCause) bool { return rc.ID == o.ID })
+
 	r.BoundCauses = causes
 	r, err := r.BindContributingCause(o)
 	if err != nil {
@@ -113,10 +217,15 @@ func (r Review) UpdateBoundContributingCause(o BoundCause) (Review, error) {
 }
 
 func (r Review) BindTrigger(t normalized.Trigger, ubt UnboundTrigger) (Review, error) {
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
 	bt := BoundTrigger{
 		ID:             uuid.Must(uuid.NewV7()),
 		Trigger:        t,
 		UnboundTrigger: ubt,
+		BoundAt:        time.Now(),
 	}
 
 	r.BoundTriggers = append(r.BoundTriggers, bt)
@@ -125,10 +234,20 @@ func (r Review) BindTrigger(t normalized.Trigger, ubt UnboundTrigger) (Review, e
 }
 
 func (r Review) UpdateBoundTrigger(o BoundTrigger) (Review, error) {
-	triggers := slices.DeleteFunc(r.BoundTriggers, func(bt BoundTrigger) bool { return bt.ID == o.ID })
-	if len(triggers) == len(r.BoundTriggers) {
-		return r, errors.New("cannot update trigger that isn't already bound")
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
+	i := slices.IndexFunc(r.BoundTriggers, func(bt BoundTrigger) bool { return bt.ID == o.ID })
+	if i == -1 {
+		return r, failure.New(failure.NotFound, "cannot update trigger that isn't already bound")
 	}
+	if o.Votes == nil {
+		o.Votes = r.BoundTriggers[i].Votes
+	}
+	o.BoundAt = r.BoundTriggers[i].BoundAt
+
+	triggers := slices.DeleteFunc(r.BoundTriggers, func(bt BoundTrigger) bool { return bt.ID == o.ID })
 
 	triggers = append(triggers, o)
 	r.BoundTriggers = triggers
@@ -136,11 +255,48 @@ func (r Review) UpdateBoundTrigger(o BoundTrigger) (Review, error) {
 	return r, nil
 }
 
+// UnbindContributingCause removes the cause from the review together with what's attached to it,
+// the discussion about it is kept and shown as on a removed item.
+func (r Review) UnbindContributingCause(boundCauseID uuid.UUID) (Review, error) {
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
+	i := slices.IndexFunc(r.BoundCauses, func(bc BoundCause) bool { return bc.ID == boundCauseID })
+	if i == -1 {
+		return r, failure.New(failure.NotFound, "cannot unbind a contributing cause that isn't bound: "+boundCauseID.String())
+	}
+
+	r.BoundCauses = slices.Delete(slices.Clone(r.BoundCauses), i, i+1)
+	r.Attachments = slices.DeleteFunc(slices.Clone(r.Attachments), func(a Attachment) bool { return a.BoundCauseID == boundCauseID })
+
+	return r, nil
+}
+
+// UnbindTrigger removes the trigger from the review, the discussion about it is kept and shown as on a removed item.
+func (r Review) UnbindTrigger(boundTriggerID uuid.UUID) (Review, error) {
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
+	i := slices.IndexFunc(r.BoundTriggers, func(bt BoundTrigger) bool { return bt.ID == boundTriggerID })
+	if i == -1 {
+		return r, failure.New(failure.NotFound, "cannot unbind a trigger that isn't bound: "+boundTriggerID.String())
+	}
+
+	r.BoundTriggers = slices.Delete(slices.Clone(r.BoundTriggers), i, i+1)
+
+	return r, nil
+}
+
 type BoundCause struct {
 	ID              uuid.UUID
 	Cause           contributing.Cause `validate:"required"`
 	Why             string             `validate:"required"`
 	IsProximalCause bool
+	Votes           Votes
+	// BoundAt is when the cause was first bound, updating it keeps it.
+	BoundAt time.Time
 }
 
 type UnboundTrigger struct {
@@ -151,6 +307,9 @@ type BoundTrigger struct {
 	ID      uuid.UUID
 	Trigger normalized.Trigger `validate:"required"`
 	UnboundTrigger
+	Votes Votes
+	// BoundAt is when the trigger was first bound, updating it keeps it.
+	BoundAt time.Time
 }
 
 func NewBoundCause() BoundCause {
@@ -166,10 +325,14 @@ type triggerStore interface {
 }
 
 type Service struct {
-	reviewStore  Storage
-	causeStore   causeStore
-	action       *action.Mapper
-	triggerStore triggerStore
+	reviewStore      Storage
+	causeStore       causeStore
+	actions          *action.Registry
+	triggerStore     triggerStore
+	publicationRules PublicationRules
+	events           event.Publisher
+	blobs            blob.Store
+	attachmentLimits AttachmentLimits
 }
 
 func (s *Service) BindTrigger(ctx context.Context, reviewID uuid.UUID, triggerID uuid.UUID, unboundTrigger UnboundTrigger) error {
@@ -178,67 +341,150 @@ func (s *Service) BindTrigger(ctx context.Context, reviewID uuid.UUID, triggerID
 		return fmt.Errorf("failed to get review: %w", err)
 	}
 
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return err
+	}
+
 	trigger, err := s.triggerStore.Get(ctx, triggerID)
 	if err != nil {
 		return fmt.Errorf("failed to get trigger: %w", err)
 	}
 
-	doer, err := s.action.Get("BindTrigger")
-	if err != nil {
-		return fmt.Errorf("failed to get action for binding trigger: %w", err)
-	}
-	do, ok := doer.(func(Review, normalized.Trigger, UnboundTrigger) (Review, error))
-	if !ok {
-		return fmt.Errorf("failed to cast action for binding trigger: %w", err)
-	}
+	do := action.Get(s.actions, ActionBindTrigger())
 
 	review, err = do(review, trigger, unboundTrigger)
 	if err != nil {
 		return fmt.Errorf("failed binding trigger to review: %w", err)
 	}
 
-	_, err = s.Save(ctx, review)
+	review, err = s.save(ctx, review)
 	if err != nil {
 		return fmt.Errorf("failed to save review: %w", err)
 	}
+	// The trigger is bound last, see Review.BindTrigger
+	if len(review.BoundTriggers) > 0 {
+		s.publish(ctx, TriggerBound{Review: review, BoundTrigger: review.BoundTriggers[len(review.BoundTriggers)-1]})
+	}
 
 	return nil
 }
 
 type Option func(s *Service)
 
-func WithActionMapper(mapper *action.Mapper) Option {
+// WithActions replaces the default actions with the ones in the registry, see the Action keys.
+func WithActions(overrides *action.Registry) Option {
+	return func(s *Service) {
+		s.actions = s.actions.Override(overrides)
+	}
+}
+
+// WithMiddleware runs mw around the action, like auditing every bound contributing cause, or checking a rule before
+// every save. It runs after the middleware added before it, see action.Use.
+func WithMiddleware[F any](k action.Key[F], name string, mw action.Middleware[F]) Option {
+	return func(s *Service) {
+		action.Use(s.actions, k, name, mw)
+	}
+}
+
+// Pipelines returns what runs for each action the service performs.
+func (s *Service) Pipelines() []action.Pipeline {
+	return s.actions.All()
+}
+
+// WithPublicationRules replaces the DefaultPublicationRules checked before a review is published.
+func WithPublicationRules(rules PublicationRules) Option {
 	return func(s *Service) {
-		s.action = mapper
+		s.publicationRules = rules
 	}
 }
 
-func NewService(reviewStore Storage, causeStore causeStore, triggerStore triggerStore, opts ...Option) *Service {
+// NewService fails when an action from WithActions isn't one the service performs or doesn't match its key.
+func NewService(reviewStore Storage, causeStore causeStore, triggerStore triggerStore, opts ...Option) (*Service, error) {
 	s := Service{
-		reviewStore:  reviewStore,
-		causeStore:   causeStore,
-		triggerStore: triggerStore,
-		action:       reviewServiceActions(),
+		reviewStore:      reviewStore,
+		causeStore:       causeStore,
+		triggerStore:     triggerStore,
+		actions:          reviewServiceActions(),
+		publicationRules: DefaultPublicationRules(),
+		events:           event.Discard,
+		blobs:            blob.NewMemoryStore(),
+		attachmentLimits: DefaultAttachmentLimits(),
 	}
 
 	for _, opt := range opts {
 		opt(&s)
 	}
+	// A missing or mistyped action is a programming error, so fail when starting rather than on first use
+	if err := s.actions.Check(actionKeys()...); err != nil {
+		return nil, fmt.Errorf("invalid actions: %w", err)
+	}
 
-	return &s
+	return &s, nil
 }
 
+// Save validates and stores the review.
+// A new review can only be started by those allowed to with CanStartReview, they become its facilitator,
+// it belongs to the team they're working as and starts as a draft.
+// Changing an existing review requires being able to contribute to it while it's editable, and only changes its own fields.
+// Its state, what's bound to it, discussed and attached, who is part of it and which team it belongs to are kept,
+// those are changed with Transition, the bind and vote methods, AddComment, Attach, AddMember and MoveToTeam.
 func (s *Service) Save(ctx context.Context, review Review) (Review, error) {
-	doer, err := s.action.Get("Save")
+	if review.CreatedAt.IsZero() {
+		if err := actor.Require(ctx, CanStartReview); err != nil {
+			return review, fmt.Errorf("%w to start a review", err)
+		}
+		review.TeamID = tenant.ID(ctx)
+		// Every review starts as a draft and is moved along with Transition
+		review.State = StateDraft
+		review.Transitions = nil
+
+		created, err := s.save(ctx, review)
+		if err != nil {
+			return created, err
+		}
+		s.publish(ctx, ReviewCreated{Review: created})
+
+		return created, nil
+	}
+
+	stored, err := s.reviewStore.Get(ctx, review.ID)
 	if err != nil {
-		return review, fmt.Errorf("failed to get action for save: %w", err)
+		return review, fmt.Errorf("failed to get review: %w", err)
+	}
+	if err := stored.authorize(ctx, PermissionContribute); err != nil {
+		return review, err
 	}
-	do, ok := doer.(func(context.Context, Review) (Review, error))
-	if !ok {
-		return review, errors.New("action for save doesn't match")
+	if err := stored.ensureEditable(); err != nil {
+		return review, err
 	}
+	// Saving only changes the review's own fields, everything else is changed through its own method
+	// so its rules, like the publication rules or who can edit a comment, can't be skipped.
+	review.State = stored.State
+	review.Transitions = stored.Transitions
+	review.BoundCauses = stored.BoundCauses
+	review.BoundTriggers = stored.BoundTriggers
+	review.Comments = stored.Comments
+	review.Attachments = stored.Attachments
+	review.Facilitators = stored.Facilitators
+	review.Participants = stored.Participants
+	review.TeamID = stored.TeamID
+	review.CreatedBy = stored.CreatedBy
+	review.CreatedAt = stored.CreatedAt
+
+	review, err = s.save(ctx, review)
+	if err != nil {
+		return review, err
+	}
+	s.publish(ctx, ReviewUpdated{Review: review})
 
-	review, err = do(ctx, review)
+	return review, nil
+}
+
+// save is used after the service has checked the changes are allowed.
+func (s *Service) save(ctx context.Context, review Review) (Review, error) {
+	do := action.Get(s.actions, ActionSave())
+
+	review, err := do(ctx, review)
 	if err != nil {
 		return review, fmt.Errorf("pre-save action failed: %w", err)
 	}
@@ -269,35 +515,118 @@ func (s *Service) All(ctx context.Context) ([]Review, error) {
 	return ret, nil
 }
 
+// Find returns the reviews matching the filter with the most recent first.
+func (s *Service) Find(ctx context.Context, f Filter) ([]Review, error) {
+	reviews, err := s.reviewStore.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews to filter: %w", err)
+	}
+
+	ret := make([]Review, 0, len(reviews))
+	for _, r := range reviews {
+		if f.Matches(r) {
+			ret = append(ret, r)
+		}
+	}
+
+	return ret, nil
+}
+
+// Update changes the fields on the review that are allowed for mass changes, see Review.Update.
+func (s *Service) Update(ctx context.Context, reviewID uuid.UUID, update Review) (Review, error) {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return Review{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return Review{}, err
+	}
+
+	do := action.Get(s.actions, ActionUpdate())
+
+	review, err = do(review, update)
+	if err != nil {
+		return Review{}, fmt.Errorf("action to update review failed: %w", err)
+	}
+
+	review, err = s.save(ctx, review)
+	if err != nil {
+		return Review{}, fmt.Errorf("failed to save updated review: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: review})
+
+	return review, nil
+}
+
+// PublicationChecklist checks the review against the configured publication rules.
+func (s *Service) PublicationChecklist(review Review) Checklist {
+	return s.publicationRules.Check(review)
+}
+
+// Transition moves the review to the state, see Review.TransitionTo for which transitions are allowed.
+// Publishing also requires the review to pass the publication rules.
+func (s *Service) Transition(ctx context.Context, reviewID uuid.UUID, to State) (Review, error) {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return Review{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionFacilitate); err != nil {
+		return Review{}, err
+	}
+
+	do := action.Get(s.actions, ActionTransition())
+
+	from := review.State
+	review, err = do(review, to, s.publicationRules)
+	if err != nil {
+		return Review{}, fmt.Errorf("action to transition review failed: %w", err)
+	}
+
+	review, err = s.save(ctx, review)
+	if err != nil {
+		return Review{}, fmt.Errorf("failed to save transitioned review: %w", err)
+	}
+	s.publish(ctx, ReviewTransitioned{Review: review, From: from})
+
+	return review, nil
+}
+
 func (s *Service) BindContributingCause(ctx context.Context, reviewID uuid.UUID, causeID uuid.UUID, boundCause BoundCause) error {
+	// Set the ID here so we can find the bound cause again after it's been saved.
+	if boundCause.ID == uuid.Nil {
+		boundCause.ID = uuid.Must(uuid.NewV7())
+	}
+
 	review, err := s.reviewStore.Get(ctx, reviewID)
 	if err != nil {
 		return fmt.Errorf("failed to get review: %w", err)
 	}
 
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return err
+	}
+
 	cause, err := s.causeStore.Get(ctx, causeID)
 	if err != nil {
 		return fmt.Errorf("failed to get contributing cause: %w", err)
 	}
 
-	doer, err := s.action.Get("BindContributingCause")
-	if err != nil {
-		return fmt.Errorf("failed to get action for adding contributing cause: %w", err)
-	}
-	do, ok := doer.(func(Review, contributing.Cause, BoundCause) (Review, error))
-	if !ok {
-		return fmt.Errorf("failed to cast action for adding contributing cause: %w", err)
-	}
+	do := action.Get(s.actions, ActionBindContributingCause())
 
 	review, err = do(review, cause, boundCause)
 	if err != nil {
 		return fmt.Errorf("failed to add contributing cause to review: %w", err)
 	}
 
-	_, err = s.Save(ctx, review)
+	review, err = s.save(ctx, review)
 	if err != nil {
 		return fmt.Errorf("failed to save review: %w", err)
 	}
+	if i := slices.IndexFunc(review.BoundCauses, func(bc BoundCause) bool { return bc.ID == boundCause.ID }); i != -1 {
+		s.publish(ctx, CauseBound{Review: review, BoundCause: review.BoundCauses[i]})
+	}
 
 	return nil
 }
@@ -315,7 +644,7 @@ func (s *Service) GetBoundContributingCause(ctx context.Context, reviewID uuid.U
 		}
 	}
 
-	return BoundCause{}, errors.New("review doesn't have that contributing cause bound: " + boundCauseID.String())
+	return BoundCause{}, failure.New(failure.NotFound, "review doesn't have that contributing cause bound: "+boundCauseID.String())
 }
 
 func (s *Service) UpdateBoundContributingCause(ctx context.Context, reviewID uuid.UUID, update BoundCause) (BoundCause, error) {
@@ -324,30 +653,28 @@ func (s *Service) UpdateBoundContributingCause(ctx context.Context, reviewID uui
 		return BoundCause{}, fmt.Errorf("failed to get review: %w", err)
 	}
 
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return BoundCause{}, err
+	}
+
 	newCause, err := s.causeStore.Get(ctx, update.Cause.ID)
 	if err != nil {
 		return BoundCause{}, fmt.Errorf("failed to get contributing cause: %w", err)
 	}
 	update.Cause = newCause
 
-	doer, err := s.action.Get("UpdateBoundContributingCause")
-	if err != nil {
-		return BoundCause{}, fmt.Errorf("failed to get action for updating bound contributing cause: %w", err)
-	}
-	do, ok := doer.(func(Review, BoundCause) (Review, error))
-	if !ok {
-		return BoundCause{}, fmt.Errorf("failed to cast action for updating bound contributing cause: %w", err)
-	}
+	do := action.Get(s.actions, ActionUpdateBoundContributingCause())
 
 	review, err = do(review, update)
 	if err != nil {
 		return BoundCause{}, fmt.Errorf("action to update bound contributing cause failed: %w", err)
 	}
 
-	updatedReview, err := s.Save(ctx, review)
+	updatedReview, err := s.save(ctx, review)
 	if err != nil {
 		return BoundCause{}, fmt.Errorf("failed to save updated review: %w", err)
 	}
+	s.publish(ctx, ReviewUpdated{Review: updatedReview})
 
 	// Return the updated contributing cause
 	for _, boundCause := range updatedReview.BoundCauses {
@@ -372,7 +699,7 @@ func (s *Service) GetBoundTrigger(ctx context.Context, reviewID uuid.UUID, bound
 		}
 	}
 
-	return BoundTrigger{}, errors.New("review doesn't have that trigger bound: " + boundTriggerID.String())
+	return BoundTrigger{}, failure.New(failure.NotFound, "review doesn't have that trigger bound: "+boundTriggerID.String())
 }
 
 func (s *Service) UpdateBoundTrigger(ctx context.Context, reviewID uuid.UUID, update BoundTrigger) (BoundTrigger, error) {
@@ -381,30 +708,28 @@ func (s *Service) UpdateBoundTrigger(ctx context.Context, reviewID uuid.UUID, up
 		return BoundTrigger{}, fmt.Errorf("failed to get review: %w", err)
 	}
 
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return BoundTrigger{}, err
+	}
+
 	newTrigger, err := s.triggerStore.Get(ctx, update.Trigger.ID)
 	if err != nil {
 		return BoundTrigger{}, fmt.Errorf("failed to get trigger: %w", err)
 	}
 	update.Trigger = newTrigger
 
-	doer, err := s.action.Get("UpdateBoundTrigger")
-	if err != nil {
-		return BoundTrigger{}, fmt.Errorf("failed to get action for updating bound trigger: %w", err)
-	}
-	do, ok := doer.(func(Review, BoundTrigger) (Review, error))
-	if !ok {
-		return BoundTrigger{}, fmt.Errorf("failed to cast action for updating bound trigger: %w", err)
-	}
+	do := action.Get(s.actions, ActionUpdateBoundTrigger())
 
 	review, err = do(review, update)
 	if err != nil {
 		return BoundTrigger{}, fmt.Errorf("action to update bound trigger failed: %w", err)
 	}
 
-	updatedReview, err := s.Save(ctx, review)
+	updatedReview, err := s.save(ctx, review)
 	if err != nil {
 		return BoundTrigger{}, fmt.Errorf("failed to save updated review: %w", err)
 	}
+	s.publish(ctx, ReviewUpdated{Review: updatedReview})
 
 	// Return the updated trigger
 	for _, boundTrigger := range updatedReview.BoundTriggers {
@@ -415,3 +740,319 @@ func (s *Service) UpdateBoundTrigger(ctx context.Context, reviewID uuid.UUID, up
 
 	return BoundTrigger{}, errors.New("unexpected error: updated trigger not found")
 }
+
+// UnbindContributingCause removes the cause from the review and deletes what was attached to it.
+func (s *Service) UnbindContributingCause(ctx context.Context, reviewID uuid.UUID, boundCauseID uuid.UUID) error {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return err
+	}
+
+	attachments := review.AttachmentsOf(boundCauseID)
+	do := action.Get(s.actions, ActionUnbindContributingCause())
+
+	review, err = do(review, boundCauseID)
+	if err != nil {
+		return fmt.Errorf("action to unbind contributing cause failed: %w", err)
+	}
+
+	review, err = s.save(ctx, review)
+	if err != nil {
+		return fmt.Errorf("failed to save review after unbinding contributing cause: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: review})
+	for _, a := range attachments {
+		s.deleteBlobs(ctx, a)
+	}
+
+	return nil
+}
+
+// UnbindTrigger removes the trigger from the review.
+func (s *Service) UnbindTrigger(ctx context.Context, reviewID uuid.UUID, boundTriggerID uuid.UUID) error {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return err
+	}
+
+	do := action.Get(s.actions, ActionUnbindTrigger())
+
+	review, err = do(review, boundTriggerID)
+	if err != nil {
+		return fmt.Errorf("action to unbind trigger failed: %w", err)
+	}
+
+	review, err = s.save(ctx, review)
+	if err != nil {
+		return fmt.Errorf("failed to save review after unbinding trigger: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: review})
+
+	return nil
+}
+
+func (s *Service) VoteOnBoundContributingCause(ctx context.Context, reviewID uuid.UUID, boundCauseID uuid.UUID, vote Vote) (BoundCause, error) {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return BoundCause{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return BoundCause{}, err
+	}
+	// Everyone votes as themselves
+	vote.VoterID = actor.ID(ctx)
+
+	do := action.Get(s.actions, ActionVoteOnBoundContributingCause())
+
+	review, err = do(review, boundCauseID, vote)
+	if err != nil {
+		return BoundCause{}, fmt.Errorf("action to vote on bound contributing cause failed: %w", err)
+	}
+
+	updatedReview, err := s.save(ctx, review)
+	if err != nil {
+		return BoundCause{}, fmt.Errorf("failed to save review after voting: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: updatedReview})
+
+	for _, boundCause := range updatedReview.BoundCauses {
+		if boundCause.ID == boundCauseID {
+			return boundCause, nil
+		}
+	}
+
+	return BoundCause{}, errors.New("unexpected error: voted on contributing cause not found")
+}
+
+func (s *Service) VoteOnBoundTrigger(ctx context.Context, reviewID uuid.UUID, boundTriggerID uuid.UUID, vote Vote) (BoundTrigger, error) {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return BoundTrigger{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return BoundTrigger{}, err
+	}
+	// Everyone votes as themselves
+	vote.VoterID = actor.ID(ctx)
+
+	do := action.Get(s.actions, ActionVoteOnBoundTrigger())
+
+	review, err = do(review, boundTriggerID, vote)
+	if err != nil {
+		return BoundTrigger{}, fmt.Errorf("action to vote on bound trigger failed: %w", err)
+	}
+
+	updatedReview, err := s.save(ctx, review)
+	if err != nil {
+		return BoundTrigger{}, fmt.Errorf("failed to save review after voting: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: updatedReview})
+
+	for _, boundTrigger := range updatedReview.BoundTriggers {
+		if boundTrigger.ID == boundTriggerID {
+			return boundTrigger, nil
+		}
+	}
+
+	return BoundTrigger{}, errors.New("unexpected error: voted on trigger not found")
+}
+
+func (s *Service) AddComment(ctx context.Context, reviewID uuid.UUID, comment Comment) (Comment, error) {
+	// Set the ID here so we can find the comment again after it's been saved.
+	if comment.ID == uuid.Nil {
+		comment.ID = uuid.Must(uuid.NewV7())
+	}
+
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return Comment{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return Comment{}, err
+	}
+	comment.AuthorID = actor.ID(ctx)
+
+	do := action.Get(s.actions, ActionAddComment())
+
+	review, err = do(review, comment)
+	if err != nil {
+		return Comment{}, fmt.Errorf("action to add comment failed: %w", err)
+	}
+
+	updatedReview, err := s.save(ctx, review)
+	if err != nil {
+		return Comment{}, fmt.Errorf("failed to save review after commenting: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: updatedReview})
+
+	for _, c := range updatedReview.Comments {
+		if c.ID == comment.ID {
+			return c, nil
+		}
+	}
+
+	return Comment{}, errors.New("unexpected error: added comment not found")
+}
+
+func (s *Service) EditComment(ctx context.Context, reviewID uuid.UUID, commentID uuid.UUID, body string) (Comment, error) {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return Comment{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return Comment{}, err
+	}
+	if err := review.authorizeComment(ctx, commentID); err != nil {
+		return Comment{}, err
+	}
+
+	do := action.Get(s.actions, ActionEditComment())
+
+	review, err = do(review, commentID, body)
+	if err != nil {
+		return Comment{}, fmt.Errorf("action to edit comment failed: %w", err)
+	}
+
+	updatedReview, err := s.save(ctx, review)
+	if err != nil {
+		return Comment{}, fmt.Errorf("failed to save review after editing comment: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: updatedReview})
+
+	for _, c := range updatedReview.Comments {
+		if c.ID == commentID {
+			return c, nil
+		}
+	}
+
+	return Comment{}, errors.New("unexpected error: edited comment not found")
+}
+
+func (s *Service) DeleteComment(ctx context.Context, reviewID uuid.UUID, commentID uuid.UUID) (Comment, error) {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return Comment{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionContribute); err != nil {
+		return Comment{}, err
+	}
+	if err := review.authorizeComment(ctx, commentID); err != nil {
+		return Comment{}, err
+	}
+
+	do := action.Get(s.actions, ActionDeleteComment())
+
+	review, err = do(review, commentID)
+	if err != nil {
+		return Comment{}, fmt.Errorf("action to delete comment failed: %w", err)
+	}
+
+	updatedReview, err := s.save(ctx, review)
+	if err != nil {
+		return Comment{}, fmt.Errorf("failed to save review after deleting comment: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: updatedReview})
+
+	for _, c := range updatedReview.Comments {
+		if c.ID == commentID {
+			return c, nil
+		}
+	}
+
+	return Comment{}, errors.New("unexpected error: deleted comment not found")
+}
+
+// AddMember makes the user part of the review, or changes how they're part of it.
+func (s *Service) AddMember(ctx context.Context, reviewID uuid.UUID, userID uuid.UUID, kind MemberKind) (Review, error) {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return Review{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionFacilitate); err != nil {
+		return Review{}, err
+	}
+
+	do := action.Get(s.actions, ActionAddMember())
+
+	review, err = do(review, userID, kind)
+	if err != nil {
+		return Review{}, fmt.Errorf("action to add member failed: %w", err)
+	}
+
+	review, err = s.save(ctx, review)
+	if err != nil {
+		return Review{}, fmt.Errorf("failed to save review after adding member: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: review})
+
+	return review, nil
+}
+
+// RemoveMember takes the user off the review, the last facilitator can't be removed.
+func (s *Service) RemoveMember(ctx context.Context, reviewID uuid.UUID, userID uuid.UUID) (Review, error) {
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return Review{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if err := review.authorize(ctx, PermissionFacilitate); err != nil {
+		return Review{}, err
+	}
+
+	do := action.Get(s.actions, ActionRemoveMember())
+
+	review, err = do(review, userID)
+	if err != nil {
+		return Review{}, fmt.Errorf("action to remove member failed: %w", err)
+	}
+
+	review, err = s.save(ctx, review)
+	if err != nil {
+		return Review{}, fmt.Errorf("failed to save review after removing member: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: review})
+
+	return review, nil
+}
+
+// MoveToTeam changes which team the review belongs to, only admins can move reviews since it's across teams.
+func (s *Service) MoveToTeam(ctx context.Context, reviewID uuid.UUID, teamID uuid.UUID) (Review, error) {
+	if err := actor.Require(ctx, actor.Admin); err != nil {
+		return Review{}, fmt.Errorf("only admins can move reviews between teams: %w", err)
+	}
+	ctx = tenant.Unscoped(ctx)
+
+	review, err := s.reviewStore.Get(ctx, reviewID)
+	if err != nil {
+		return Review{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	do := action.Get(s.actions, ActionMoveToTeam())
+
+	review, err = do(review, teamID)
+	if err != nil {
+		return Review{}, fmt.Errorf("action to move to team failed: %w", err)
+	}
+
+	review, err = s.save(ctx, review)
+	if err != nil {
+		return Review{}, fmt.Errorf("failed to save review after moving to team: %w", err)
+	}
+	s.publish(ctx, ReviewUpdated{Review: review})
+
+	return review, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -14,7 +16,12 @@ import (
 	"github.com/gaqzi/incident-reviewer/internal/normalized"
 	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
 	"github.com/gaqzi/incident-reviewer/internal/platform/action"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/blob"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
 	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing/storage"
 	"github.com/gaqzi/incident-reviewer/test/a"
 )
 
@@ -60,15 +67,19 @@ type builderService struct {
 	reviewStorage  *reviewStorageMock
 	causeStorage   *causeStorageMock
 	triggerStorage *triggerStorageMock
-	actionMapper   *action.Mapper
+	actions        *action.Registry
+	opts           []reviewing.Option
 }
 
+// adminCtx is allowed to do everything, so the tests of the collaboration don't have to set up who is part of each review.
+var adminCtx = actor.With(context.Background(), a.Actor().WithRole(actor.RoleAdmin).Build())
+
 func newService() builderService {
 	return builderService{
 		reviewStorage:  new(reviewStorageMock),
 		causeStorage:   new(causeStorageMock),
 		triggerStorage: new(triggerStorageMock),
-		actionMapper:   &action.Mapper{},
+		actions:        action.NewRegistry(),
 	}
 }
 
@@ -80,7 +91,16 @@ func (b builderService) Build(t *testing.T) *reviewing.Service {
 	cs.Test(t)
 	ts := b.triggerStorage
 	ts.Test(t)
-	return reviewing.NewService(rs, cs, ts, reviewing.WithActionMapper(b.actionMapper))
+	service, err := reviewing.NewService(rs, cs, ts, append(b.opts, reviewing.WithActions(b.actions))...)
+	require.NoError(t, err)
+
+	return service
+}
+
+func (b builderService) withOptions(opts ...reviewing.Option) builderService {
+	b.opts = append(b.opts, opts...)
+
+	return b
 }
 
 func (b builderService) getReview(r reviewing.Review) builderService {
@@ -100,7 +120,7 @@ func (b builderService) getReviewFail(err ...error) builderService {
 }
 
 func (b builderService) saveAction(er reviewing.Review) builderService {
-	b.actionMapper.Add("Save", func(_ context.Context, r reviewing.Review) (reviewing.Review, error) {
+	action.Set(b.actions, reviewing.ActionSave(), func(_ context.Context, r reviewing.Review) (reviewing.Review, error) {
 		if !reflect.DeepEqual(er, r) {
 			return reviewing.Review{}, errors.New("the passed in values don't match the expected values")
 		}
@@ -111,12 +131,20 @@ func (b builderService) saveAction(er reviewing.Review) builderService {
 	return b
 }
 
+func (b builderService) moveToTeamAction() builderService {
+	action.Set(b.actions, reviewing.ActionMoveToTeam(), func(r reviewing.Review, teamID uuid.UUID) (reviewing.Review, error) {
+		return r.MoveToTeam(teamID), nil
+	})
+
+	return b
+}
+
 func (b builderService) saveActionFail(err ...error) builderService {
 	if err == nil {
 		err = append(err, errors.New("uh-oh"))
 	}
 
-	b.actionMapper.Add("Save", func(_ context.Context, _ reviewing.Review) (reviewing.Review, error) {
+	action.Set(b.actions, reviewing.ActionSave(), func(_ context.Context, _ reviewing.Review) (reviewing.Review, error) {
 		return reviewing.Review{}, err[0]
 	})
 
@@ -190,7 +218,7 @@ func (b builderService) bindContributingCauseActionFail(err ...error) builderSer
 		err = append(err, errors.New("uh-oh"))
 	}
 
-	b.actionMapper.Add("BindContributingCause", func(_ reviewing.Review, _ contributing.Cause, _ reviewing.BoundCause) (reviewing.Review, error) {
+	action.Set(b.actions, reviewing.ActionBindContributingCause(), func(_ reviewing.Review, _ contributing.Cause, _ reviewing.BoundCause) (reviewing.Review, error) {
 		return reviewing.Review{}, err[0]
 	})
 
@@ -198,7 +226,7 @@ func (b builderService) bindContributingCauseActionFail(err ...error) builderSer
 }
 
 func (b builderService) bindContributingCauseAction(er reviewing.Review, ec contributing.Cause, erc reviewing.BoundCause) builderService {
-	b.actionMapper.Add("BindContributingCause", func(r reviewing.Review, c contributing.Cause, rc reviewing.BoundCause) (reviewing.Review, error) {
+	action.Set(b.actions, reviewing.ActionBindContributingCause(), func(r reviewing.Review, c contributing.Cause, rc reviewing.BoundCause) (reviewing.Review, error) {
 		if !reflect.DeepEqual(er, r) ||
 			!reflect.DeepEqual(ec, c) ||
 			!reflect.DeepEqual(erc, rc) {
@@ -212,7 +240,7 @@ func (b builderService) bindContributingCauseAction(er reviewing.Review, ec cont
 }
 
 func (b builderService) updateBoundContributingCauseActionFail() builderService {
-	b.actionMapper.Add("UpdateBoundContributingCause", func(_ reviewing.Review, _ reviewing.BoundCause) (reviewing.Review, error) {
+	action.Set(b.actions, reviewing.ActionUpdateBoundContributingCause(), func(_ reviewing.Review, _ reviewing.BoundCause) (reviewing.Review, error) {
 		return reviewing.Review{}, errors.New("uh-oh")
 	})
 
@@ -220,7 +248,7 @@ func (b builderService) updateBoundContributingCauseActionFail() builderService
 }
 
 func (b builderService) updateBoundContributingCauseAction(er reviewing.Review, ec reviewing.BoundCause) builderService {
-	b.actionMapper.Add("UpdateBoundContributingCause", func(r reviewing.Review, c reviewing.BoundCause) (reviewing.Review, error) {
+	action.Set(b.actions, reviewing.ActionUpdateBoundContributingCause(), func(r reviewing.Review, c reviewing.BoundCause) (reviewing.Review, error) {
 		if !reflect.DeepEqual(er, r) ||
 			!reflect.DeepEqual(ec, c) {
 			return reviewing.Review{}, errors.New("the passed in values don't match the expected values")
@@ -237,7 +265,7 @@ func (b builderService) bindTriggerActionFail(err ...error) builderService {
 		err = append(err, errors.New("uh-oh"))
 	}
 
-	b.actionMapper.Add("BindTrigger", func(_ reviewing.Review, _ normalized.Trigger, _ reviewing.UnboundTrigger) (reviewing.Review, error) {
+	action.Set(b.actions, reviewing.ActionBindTrigger(), func(_ reviewing.Review, _ normalized.Trigger, _ reviewing.UnboundTrigger) (reviewing.Review, error) {
 		return reviewing.Review{}, err[0]
 	})
 
@@ -245,7 +273,7 @@ func (b builderService) bindTriggerActionFail(err ...error) builderService {
 }
 
 func (b builderService) bindTriggerAction(er reviewing.Review, et normalized.Trigger, eut reviewing.UnboundTrigger) builderService {
-	b.actionMapper.Add("BindTrigger", func(r reviewing.Review, t normalized.Trigger, ut reviewing.UnboundTrigger) (reviewing.Review, error) {
+	action.Set(b.actions, reviewing.ActionBindTrigger(), func(r reviewing.Review, t normalized.Trigger, ut reviewing.UnboundTrigger) (reviewing.Review, error) {
 		if !reflect.DeepEqual(er, r) ||
 			!reflect.DeepEqual(et, t) ||
 			!reflect.DeepEqual(eut, ut) {
@@ -257,7 +285,7 @@ func (b builderService) bindTriggerAction(er reviewing.Review, et normalized.Tri
 }
 
 func (b builderService) updateBoundTriggerActionFail() builderService {
-	b.actionMapper.Add("UpdateBoundTrigger", func(_ reviewing.Review, _ reviewing.BoundTrigger) (reviewing.Review, error) {
+	action.Set(b.actions, reviewing.ActionUpdateBoundTrigger(), func(_ reviewing.Review, _ reviewing.BoundTrigger) (reviewing.Review, error) {
 		return reviewing.Review{}, errors.New("uh-oh")
 	})
 
@@ -265,7 +293,7 @@ func (b builderService) updateBoundTriggerActionFail() builderService {
 }
 
 func (b builderService) updateBoundTriggerAction(er reviewing.Review, et reviewing.BoundTrigger) builderService {
-	b.actionMapper.Add("UpdateBoundTrigger", func(r reviewing.Review, t reviewing.BoundTrigger) (reviewing.Review, error) {
+	action.Set(b.actions, reviewing.ActionUpdateBoundTrigger(), func(r reviewing.Review, t reviewing.BoundTrigger) (reviewing.Review, error) {
 		if !reflect.DeepEqual(er, r) ||
 			!reflect.DeepEqual(et, t) {
 			return reviewing.Review{}, errors.New("the passed in values don't match the expected values")
@@ -277,13 +305,249 @@ func (b builderService) updateBoundTriggerAction(er reviewing.Review, et reviewi
 	return b
 }
 
+func (b builderService) voteOnBoundContributingCauseActionFail() builderService {
+	action.Set(b.actions, reviewing.ActionVoteOnBoundContributingCause(), func(_ reviewing.Review, _ uuid.UUID, _ reviewing.Vote) (reviewing.Review, error) {
+		return reviewing.Review{}, errors.New("uh-oh")
+	})
+
+	return b
+}
+
+func (b builderService) voteOnBoundContributingCauseAction(er reviewing.Review, eid uuid.UUID, ev reviewing.Vote) builderService {
+	action.Set(b.actions, reviewing.ActionVoteOnBoundContributingCause(), func(r reviewing.Review, id uuid.UUID, v reviewing.Vote) (reviewing.Review, error) {
+		if !reflect.DeepEqual(er, r) ||
+			eid != id ||
+			!reflect.DeepEqual(ev, v) {
+			return reviewing.Review{}, errors.New("the passed in values don't match the expected values")
+		}
+
+		return r, nil
+	})
+
+	return b
+}
+
+func (b builderService) voteOnBoundTriggerActionFail() builderService {
+	action.Set(b.actions, reviewing.ActionVoteOnBoundTrigger(), func(_ reviewing.Review, _ uuid.UUID, _ reviewing.Vote) (reviewing.Review, error) {
+		return reviewing.Review{}, errors.New("uh-oh")
+	})
+
+	return b
+}
+
+func (b builderService) voteOnBoundTriggerAction(er reviewing.Review, eid uuid.UUID, ev reviewing.Vote) builderService {
+	action.Set(b.actions, reviewing.ActionVoteOnBoundTrigger(), func(r reviewing.Review, id uuid.UUID, v reviewing.Vote) (reviewing.Review, error) {
+		if !reflect.DeepEqual(er, r) ||
+			eid != id ||
+			!reflect.DeepEqual(ev, v) {
+			return reviewing.Review{}, errors.New("the passed in values don't match the expected values")
+		}
+
+		return r, nil
+	})
+
+	return b
+}
+
+func (b builderService) addCommentActionFail() builderService {
+	action.Set(b.actions, reviewing.ActionAddComment(), func(_ reviewing.Review, _ reviewing.Comment) (reviewing.Review, error) {
+		return reviewing.Review{}, errors.New("uh-oh")
+	})
+
+	return b
+}
+
+func (b builderService) addCommentAction(er reviewing.Review, ec reviewing.Comment) builderService {
+	action.Set(b.actions, reviewing.ActionAddComment(), func(r reviewing.Review, c reviewing.Comment) (reviewing.Review, error) {
+		if !reflect.DeepEqual(er, r) || !reflect.DeepEqual(ec, c) {
+			return reviewing.Review{}, errors.New("the passed in values don't match the expected values")
+		}
+
+		return r, nil
+	})
+
+	return b
+}
+
+func (b builderService) editCommentActionFail() builderService {
+	action.Set(b.actions, reviewing.ActionEditComment(), func(_ reviewing.Review, _ uuid.UUID, _ string) (reviewing.Review, error) {
+		return reviewing.Review{}, errors.New("uh-oh")
+	})
+
+	return b
+}
+
+func (b builderService) editCommentAction(er reviewing.Review, eid uuid.UUID, ebody string) builderService {
+	action.Set(b.actions, reviewing.ActionEditComment(), func(r reviewing.Review, id uuid.UUID, body string) (reviewing.Review, error) {
+		if !reflect.DeepEqual(er, r) || eid != id || ebody != body {
+			return reviewing.Review{}, errors.New("the passed in values don't match the expected values")
+		}
+
+		return r, nil
+	})
+
+	return b
+}
+
+func (b builderService) deleteCommentActionFail() builderService {
+	action.Set(b.actions, reviewing.ActionDeleteComment(), func(_ reviewing.Review, _ uuid.UUID) (reviewing.Review, error) {
+		return reviewing.Review{}, errors.New("uh-oh")
+	})
+
+	return b
+}
+
+func (b builderService) deleteCommentAction(er reviewing.Review, eid uuid.UUID) builderService {
+	action.Set(b.actions, reviewing.ActionDeleteComment(), func(r reviewing.Review, id uuid.UUID) (reviewing.Review, error) {
+		if !reflect.DeepEqual(er, r) || eid != id {
+			return reviewing.Review{}, errors.New("the passed in values don't match the expected values")
+		}
+
+		return r, nil
+	})
+
+	return b
+}
+
+func (b builderService) updateActionFail() builderService {
+	action.Set(b.actions, reviewing.ActionUpdate(), func(_ reviewing.Review, _ reviewing.Review) (reviewing.Review, error) {
+		return reviewing.Review{}, errors.New("uh-oh")
+	})
+
+	return b
+}
+
+func (b builderService) updateAction(er reviewing.Review, eu reviewing.Review) builderService {
+	action.Set(b.actions, reviewing.ActionUpdate(), func(r reviewing.Review, u reviewing.Review) (reviewing.Review, error) {
+		if !reflect.DeepEqual(er, r) || !reflect.DeepEqual(eu, u) {
+			return reviewing.Review{}, errors.New("the passed in values don't match the expected values")
+		}
+
+		return r.Update(u), nil
+	})
+
+	return b
+}
+
+func (b builderService) transitionActionFail() builderService {
+	action.Set(b.actions, reviewing.ActionTransition(), func(_ reviewing.Review, _ reviewing.State, _ reviewing.PublicationRules) (reviewing.Review, error) {
+		return reviewing.Review{}, errors.New("uh-oh")
+	})
+
+	return b
+}
+
+func (b builderService) transitionAction(er reviewing.Review, es reviewing.State) builderService {
+	action.Set(b.actions, reviewing.ActionTransition(), func(r reviewing.Review, to reviewing.State, rules reviewing.PublicationRules) (reviewing.Review, error) {
+		if !reflect.DeepEqual(er, r) || es != to || !reflect.DeepEqual(reviewing.DefaultPublicationRules(), rules) {
+			return reviewing.Review{}, errors.New("the passed in values don't match the expected values")
+		}
+
+		r.State = to
+		return r, nil
+	})
+
+	return b
+}
+
+func (b builderService) addMemberAction(er reviewing.Review, eid uuid.UUID, ek reviewing.MemberKind) builderService {
+	action.Set(b.actions, reviewing.ActionAddMember(), func(r reviewing.Review, id uuid.UUID, kind reviewing.MemberKind) (reviewing.Review, error) {
+		if !reflect.DeepEqual(er, r) || eid != id || ek != kind {
+			return reviewing.Review{}, errors.New("the passed in values don't match the expected values")
+		}
+
+		return r.AddMember(id, kind)
+	})
+
+	return b
+}
+
+func (b builderService) removeMemberActionFail() builderService {
+	action.Set(b.actions, reviewing.ActionRemoveMember(), func(_ reviewing.Review, _ uuid.UUID) (reviewing.Review, error) {
+		return reviewing.Review{}, errors.New("uh-oh")
+	})
+
+	return b
+}
+
+func TestNewService(t *testing.T) {
+	t.Run("fails when an overridden action isn't one the service performs or doesn't match its key", func(t *testing.T) {
+		overrides := action.NewRegistry()
+		action.Set(overrides, action.NewKey[func(reviewing.Review) (reviewing.Review, error)]("Save"), func(r reviewing.Review) (reviewing.Review, error) {
+			return r, nil
+		})
+		action.Set(overrides, action.NewKey[func()]("Sav"), func() {})
+
+		_, err := reviewing.NewService(nil, nil, nil, reviewing.WithActions(overrides))
+
+		require.EqualError(
+			t,
+			err,
+			"invalid actions: action for Save is func(reviewing.Review) (reviewing.Review, error), expected func(context.Context, reviewing.Review) (reviewing.Review, error)\nunknown action: Sav",
+		)
+	})
+}
+
+func TestWithMiddleware(t *testing.T) {
+	t.Run("runs around the action and can stop it from happening", func(t *testing.T) {
+		review := a.Review().IsSaved().Build()
+		cause := a.ContributingCause().Build()
+		var audited []uuid.UUID
+		service := newService().
+			getReview(review).
+			getCause(cause).
+			withOptions(
+				reviewing.WithMiddleware(reviewing.ActionBindContributingCause(), "audit", func(next func(reviewing.Review, contributing.Cause, reviewing.BoundCause) (reviewing.Review, error)) func(reviewing.Review, contributing.Cause, reviewing.BoundCause) (reviewing.Review, error) {
+					return func(r reviewing.Review, c contributing.Cause, bc reviewing.BoundCause) (reviewing.Review, error) {
+						r, err := next(r, c, bc)
+						if err == nil {
+							audited = append(audited, c.ID)
+						}
+						return r, err
+					}
+				}),
+				reviewing.WithMiddleware(reviewing.ActionSave(), "freeze", func(next func(context.Context, reviewing.Review) (reviewing.Review, error)) func(context.Context, reviewing.Review) (reviewing.Review, error) {
+					return func(context.Context, reviewing.Review) (reviewing.Review, error) {
+						return reviewing.Review{}, errors.New("reviews are frozen")
+					}
+				}),
+			).
+			Build(t)
+
+		err := service.BindContributingCause(adminCtx, review.ID, cause.ID, a.BoundCause().Build())
+
+		require.ErrorContains(t, err, "reviews are frozen")
+		require.Equal(t, []uuid.UUID{cause.ID}, audited, "expected the audit to have run before the save was stopped")
+	})
+
+	t.Run("Pipelines reports the middleware of each action", func(t *testing.T) {
+		noop := func(next func(context.Context, reviewing.Review) (reviewing.Review, error)) func(context.Context, reviewing.Review) (reviewing.Review, error) {
+			return next
+		}
+		service, err := reviewing.NewService(nil, nil, nil,
+			reviewing.WithMiddleware(reviewing.ActionSave(), "audit", noop),
+			reviewing.WithMiddleware(reviewing.ActionSave(), "validate", noop),
+		)
+		require.NoError(t, err)
+
+		var save action.Pipeline
+		for _, p := range service.Pipelines() {
+			if p.Action == reviewing.ActionSave().Name() {
+				save = p
+			}
+		}
+
+		require.Equal(t, "audit → validate → Save", save.String())
+	})
+}
+
 func TestService_Save(t *testing.T) {
 	t.Run("wraps any error from collaborating with action mapper", func(t *testing.T) {
 		service := newService().
 			saveActionFail().
 			Build(t)
 
-		_, actual := service.Save(context.Background(), reviewing.Review{})
+		_, actual := service.Save(adminCtx, reviewing.Review{})
 
 		require.ErrorContains(t, actual, "pre-save action failed:")
 	})
@@ -291,13 +555,14 @@ func TestService_Save(t *testing.T) {
 	t.Run("returns the error from the underlying storage when it errors", func(t *testing.T) {
 		review := a.Review().IsValid().Build()
 		service := newService().
+			getReview(review).
 			saveAction((func(r reviewing.Review) reviewing.Review {
 				return r
 			})(review)).
 			saveReviewFail().
 			Build(t)
 
-		_, actual := service.Save(context.Background(), review)
+		_, actual := service.Save(adminCtx, review)
 
 		require.Error(t, actual, "expected an error since the mock storage always fails")
 		require.ErrorContains(t, actual, "failed to save review in storage:")
@@ -310,7 +575,7 @@ func TestService_Save(t *testing.T) {
 			saveReview(a.Review().Build()).
 			Build(t)
 
-		actual, err := service.Save(context.Background(), review)
+		actual, err := service.Save(adminCtx, review)
 		require.NoError(t, err)
 
 		require.Equal(
@@ -320,6 +585,54 @@ func TestService_Save(t *testing.T) {
 			"expected the returned version from storage to be returned",
 		)
 	})
+
+	t.Run("a new review always starts as a draft", func(t *testing.T) {
+		service, err := reviewing.NewService(storage.NewMemoryStore(), nil, nil)
+		require.NoError(t, err)
+
+		actual, err := service.Save(adminCtx, a.Review().IsNotSaved().WithState(reviewing.StatePublished).Build())
+
+		require.NoError(t, err)
+		require.Equal(t, reviewing.StateDraft, actual.State)
+	})
+
+	t.Run("saving an existing review keeps its state and what's been added to it", func(t *testing.T) {
+		service, err := reviewing.NewService(storage.NewMemoryStore(), nil, nil)
+		require.NoError(t, err)
+		review, err := service.Save(adminCtx, a.Review().IsNotSaved().Build())
+		require.NoError(t, err)
+		comment, err := service.AddComment(adminCtx, review.ID, a.Comment().Build())
+		require.NoError(t, err)
+		review, err = service.Transition(adminCtx, review.ID, reviewing.StateInReview)
+		require.NoError(t, err)
+
+		changed := review
+		changed.Title = "Changed"
+		changed.State = reviewing.StatePublished
+		changed.Transitions = nil
+		changed.Comments = nil
+		changed.BoundCauses = []reviewing.BoundCause{a.BoundCause().Build()}
+		actual, err := service.Save(adminCtx, changed)
+
+		require.NoError(t, err)
+		require.Equal(t, "Changed", actual.Title)
+		require.Equal(t, reviewing.StateInReview, actual.State, "expected the state to only change through Transition")
+		require.Equal(t, review.Transitions, actual.Transitions)
+		require.Len(t, actual.Comments, 1)
+		require.Equal(t, comment.ID, actual.Comments[0].ID)
+		require.Empty(t, actual.BoundCauses, "expected causes to only be bound through BindContributingCause")
+	})
+
+	t.Run("a read-only review can't be saved", func(t *testing.T) {
+		published := a.Review().WithState(reviewing.StatePublished).Build()
+		service := newService().
+			getReview(published).
+			Build(t)
+
+		_, err := service.Save(adminCtx, published)
+
+		require.ErrorIs(t, err, reviewing.ErrReadOnly)
+	})
 }
 
 func TestService_Get(t *testing.T) {
@@ -328,7 +641,7 @@ func TestService_Get(t *testing.T) {
 			getReviewFail(errors.New("uh-oh")).
 			Build(t)
 
-		_, actual := service.Get(context.Background(), a.UUID())
+		_, actual := service.Get(adminCtx, a.UUID())
 
 		require.Error(t, actual, "expected an error since we haven't stored any reviews")
 		require.ErrorContainsf(t, actual, "failed to get review:", "so we know we got the correct error")
@@ -340,7 +653,7 @@ func TestService_Get(t *testing.T) {
 			getReview(expected).
 			Build(t)
 
-		actual, err := service.Get(context.Background(), expected.ID)
+		actual, err := service.Get(adminCtx, expected.ID)
 		require.NoError(t, err)
 
 		require.Equal(
@@ -358,7 +671,7 @@ func TestService_All(t *testing.T) {
 			allReviews([]reviewing.Review(nil)).
 			Build(t)
 
-		actual, err := service.All(context.Background())
+		actual, err := service.All(adminCtx)
 
 		require.NoError(t, err)
 		require.Empty(t, actual)
@@ -369,19 +682,138 @@ func TestService_All(t *testing.T) {
 			allReviewsFail().
 			Build(t)
 
-		actual, err := service.All(context.Background())
+		actual, err := service.All(adminCtx)
 
 		require.ErrorContains(t, err, "failed to get all reviews:")
 		require.Nil(t, actual, "expected an empty slice returned")
 	})
 }
 
+func TestService_Find(t *testing.T) {
+	t.Run("returns only the reviews matching the filter", func(t *testing.T) {
+		draft := a.Review().Build()
+		published := a.Review().WithID(a.UUID()).WithState(reviewing.StatePublished).Build()
+		service := newService().
+			allReviews([]reviewing.Review{draft, published}).
+			Build(t)
+
+		actual, err := service.Find(adminCtx, reviewing.Filter{State: reviewing.StatePublished})
+
+		require.NoError(t, err)
+		require.Equal(t, []reviewing.Review{published}, actual)
+	})
+
+	t.Run("with an empty filter all reviews are returned", func(t *testing.T) {
+		draft := a.Review().Build()
+		published := a.Review().WithID(a.UUID()).WithState(reviewing.StatePublished).Build()
+		service := newService().
+			allReviews([]reviewing.Review{draft, published}).
+			Build(t)
+
+		actual, err := service.Find(adminCtx, reviewing.Filter{})
+
+		require.NoError(t, err)
+		require.Equal(t, []reviewing.Review{draft, published}, actual)
+	})
+
+	t.Run("with an error when fetching it's wrapped and returned", func(t *testing.T) {
+		service := newService().
+			allReviewsFail().
+			Build(t)
+
+		actual, err := service.Find(adminCtx, reviewing.Filter{})
+
+		require.ErrorContains(t, err, "failed to get reviews to filter:")
+		require.Nil(t, actual)
+	})
+}
+
+func TestService_Update(t *testing.T) {
+	t.Run("when the review doesn't exist it returns an error", func(t *testing.T) {
+		service := newService().
+			getReviewFail().
+			Build(t)
+
+		_, err := service.Update(adminCtx, a.UUID(), a.Review().Build())
+
+		require.ErrorContains(t, err, "failed to get review:")
+	})
+
+	t.Run("when the review can't be updated it returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+		service := newService().
+			getReview(review).
+			updateActionFail().
+			Build(t)
+
+		_, err := service.Update(adminCtx, review.ID, review)
+
+		require.ErrorContains(t, err, "action to update review failed:")
+	})
+
+	t.Run("when the review is updated it returns it as saved", func(t *testing.T) {
+		review := a.Review().Build()
+		update := a.Review().WithURL("https://example.com/other").Build()
+		service := newService().
+			getReview(review).
+			updateAction(review, update).
+			saveAction(update).
+			saveReview(update).
+			Build(t)
+
+		actual, err := service.Update(adminCtx, review.ID, update)
+
+		require.NoError(t, err)
+		require.Equal(t, update, actual)
+	})
+}
+
+func TestService_Transition(t *testing.T) {
+	t.Run("when the review doesn't exist it returns an error", func(t *testing.T) {
+		service := newService().
+			getReviewFail().
+			Build(t)
+
+		_, err := service.Transition(adminCtx, a.UUID(), reviewing.StateInReview)
+
+		require.ErrorContains(t, err, "failed to get review:")
+	})
+
+	t.Run("when the review can't transition it returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+		service := newService().
+			getReview(review).
+			transitionActionFail().
+			Build(t)
+
+		_, err := service.Transition(adminCtx, review.ID, reviewing.StatePublished)
+
+		require.ErrorContains(t, err, "action to transition review failed:")
+	})
+
+	t.Run("when the review transitions it returns it as saved", func(t *testing.T) {
+		review := a.Review().Build()
+		expected := a.Review().WithState(reviewing.StateInReview).Build()
+		service := newService().
+			getReview(review).
+			transitionAction(review, reviewing.StateInReview).
+			saveAction(expected).
+			saveReview(expected).
+			Build(t)
+
+		actual, err := service.Transition(adminCtx, review.ID, reviewing.StateInReview)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+}
+
 func TestService_AddContributingCause(t *testing.T) {
 	t.Run("when review doesn't exist it returns the error from the storage", func(t *testing.T) {
 		service := newService().
 			getReviewFail().
 			Build(t)
-		ctx := context.Background()
+		ctx := adminCtx
 
 		actual := service.BindContributingCause(ctx, uuid.Nil, uuid.Nil, a.BoundCause().Build())
 
@@ -397,7 +829,7 @@ func TestService_AddContributingCause(t *testing.T) {
 			Build(t)
 
 		actual := service.BindContributingCause(
-			context.Background(),
+			adminCtx,
 			review.ID,
 			uuid.Nil,
 			a.BoundCause().Build(),
@@ -415,7 +847,7 @@ func TestService_AddContributingCause(t *testing.T) {
 			bindContributingCauseActionFail().
 			Build(t)
 
-		actual := service.BindContributingCause(context.Background(), review.ID, boundCause.Cause.ID, boundCause)
+		actual := service.BindContributingCause(adminCtx, review.ID, boundCause.Cause.ID, boundCause)
 
 		require.ErrorContains(t, actual, "failed to add contributing cause to review:")
 	})
@@ -431,7 +863,7 @@ func TestService_AddContributingCause(t *testing.T) {
 			bindContributingCauseAction(review, cause, boundCause).
 			saveReview(review).
 			Build(t)
-		ctx := context.Background()
+		ctx := adminCtx
 
 		actual := service.BindContributingCause(ctx, review.ID, cause.ID, boundCause)
 		require.NoError(t, actual, "expected to have bound the cause to the review successfully")
@@ -443,7 +875,7 @@ func TestService_BindTrigger(t *testing.T) {
 		service := newService().
 			getReviewFail().
 			Build(t)
-		ctx := context.Background()
+		ctx := adminCtx
 
 		actual := service.BindTrigger(ctx, uuid.Nil, uuid.Nil, a.UnboundTrigger().Build())
 
@@ -459,7 +891,7 @@ func TestService_BindTrigger(t *testing.T) {
 			Build(t)
 
 		actual := service.BindTrigger(
-			context.Background(),
+			adminCtx,
 			review.ID,
 			uuid.Nil,
 			a.UnboundTrigger().Build(),
@@ -478,7 +910,7 @@ func TestService_BindTrigger(t *testing.T) {
 			bindTriggerActionFail().
 			Build(t)
 
-		actual := service.BindTrigger(context.Background(), review.ID, normalizedTrigger.ID, unboundTrigger)
+		actual := service.BindTrigger(adminCtx, review.ID, normalizedTrigger.ID, unboundTrigger)
 
 		require.ErrorContains(t, actual, "failed binding trigger to review:")
 	})
@@ -494,7 +926,7 @@ func TestService_BindTrigger(t *testing.T) {
 			saveAction(review).
 			saveReview(review).
 			Build(t)
-		ctx := context.Background()
+		ctx := adminCtx
 
 		actual := service.BindTrigger(ctx, review.ID, normalizedTrigger.ID, unboundTrigger)
 		require.NoError(t, actual, "expected to have bound the cause to the review successfully")
@@ -507,7 +939,7 @@ func TestService_GetBoundContributingCause(t *testing.T) {
 			getReviewFail().
 			Build(t)
 
-		_, actual := service.GetBoundContributingCause(context.Background(), uuid.Nil, uuid.Nil)
+		_, actual := service.GetBoundContributingCause(adminCtx, uuid.Nil, uuid.Nil)
 
 		require.ErrorContains(t, actual, "review with that id not found to relate bound contributing cause:")
 	})
@@ -518,7 +950,7 @@ func TestService_GetBoundContributingCause(t *testing.T) {
 			getReview(review).
 			Build(t)
 
-		_, actual := service.GetBoundContributingCause(context.Background(), review.ID, a.UUID())
+		_, actual := service.GetBoundContributingCause(adminCtx, review.ID, a.UUID())
 
 		require.ErrorContains(t, actual, "review doesn't have that contributing cause bound:")
 	})
@@ -532,7 +964,7 @@ func TestService_GetBoundContributingCause(t *testing.T) {
 			getReview(review).
 			Build(t)
 
-		actual, err := service.GetBoundContributingCause(context.Background(), review.ID, boundCause.ID)
+		actual, err := service.GetBoundContributingCause(adminCtx, review.ID, boundCause.ID)
 
 		require.NoError(t, err)
 		require.Equal(t, boundCause, actual, "expected the matching cause added into the reviewing.Review to be returned")
@@ -545,7 +977,7 @@ func TestService_UpdateBoundContributingCause(t *testing.T) {
 			getReviewFail().
 			Build(t)
 
-		_, err := service.UpdateBoundContributingCause(context.Background(), a.UUID(), reviewing.BoundCause{})
+		_, err := service.UpdateBoundContributingCause(adminCtx, a.UUID(), reviewing.BoundCause{})
 
 		require.ErrorContains(t, err, "failed to get review:")
 	})
@@ -558,7 +990,7 @@ func TestService_UpdateBoundContributingCause(t *testing.T) {
 			getCauseFail().
 			Build(t)
 
-		_, err := service.UpdateBoundContributingCause(context.Background(), review.ID, boundCause)
+		_, err := service.UpdateBoundContributingCause(adminCtx, review.ID, boundCause)
 
 		require.ErrorContains(t, err, "failed to get contributing cause:")
 	})
@@ -574,7 +1006,7 @@ func TestService_UpdateBoundContributingCause(t *testing.T) {
 			updateBoundContributingCauseActionFail().
 			Build(t)
 
-		_, err := service.UpdateBoundContributingCause(context.Background(), review.ID, updatedCause)
+		_, err := service.UpdateBoundContributingCause(adminCtx, review.ID, updatedCause)
 
 		require.ErrorContains(t, err, "action to update bound contributing cause failed:")
 	})
@@ -596,7 +1028,7 @@ func TestService_UpdateBoundContributingCause(t *testing.T) {
 			})(review)).
 			Build(t)
 
-		actual, err := service.UpdateBoundContributingCause(context.Background(), review.ID, updatedCause)
+		actual, err := service.UpdateBoundContributingCause(adminCtx, review.ID, updatedCause)
 
 		require.NoError(t, err)
 		require.Equal(t, updatedCause, actual)
@@ -609,7 +1041,7 @@ func TestService_GetBoundTrigger(t *testing.T) {
 			getReviewFail().
 			Build(t)
 
-		_, actual := service.GetBoundTrigger(context.Background(), uuid.Nil, uuid.Nil)
+		_, actual := service.GetBoundTrigger(adminCtx, uuid.Nil, uuid.Nil)
 
 		require.ErrorContains(t, actual, "review with that id not found to relate bound trigger:")
 	})
@@ -620,7 +1052,7 @@ func TestService_GetBoundTrigger(t *testing.T) {
 			getReview(review).
 			Build(t)
 
-		_, actual := service.GetBoundTrigger(context.Background(), review.ID, a.UUID())
+		_, actual := service.GetBoundTrigger(adminCtx, review.ID, a.UUID())
 
 		require.ErrorContains(t, actual, "review doesn't have that trigger bound:")
 	})
@@ -634,7 +1066,7 @@ func TestService_GetBoundTrigger(t *testing.T) {
 			getReview(review).
 			Build(t)
 
-		actual, err := service.GetBoundTrigger(context.Background(), review.ID, boundTrigger.ID)
+		actual, err := service.GetBoundTrigger(adminCtx, review.ID, boundTrigger.ID)
 
 		require.NoError(t, err)
 		require.Equal(t, boundTrigger, actual, "expected the matching trigger added into the reviewing.Review to be returned")
@@ -647,7 +1079,7 @@ func TestService_UpdateBoundTrigger(t *testing.T) {
 			getReviewFail().
 			Build(t)
 
-		_, err := service.UpdateBoundTrigger(context.Background(), a.UUID(), reviewing.BoundTrigger{})
+		_, err := service.UpdateBoundTrigger(adminCtx, a.UUID(), reviewing.BoundTrigger{})
 
 		require.ErrorContains(t, err, "failed to get review:")
 	})
@@ -660,7 +1092,7 @@ func TestService_UpdateBoundTrigger(t *testing.T) {
 			getTriggerFail().
 			Build(t)
 
-		_, err := service.UpdateBoundTrigger(context.Background(), review.ID, boundTrigger)
+		_, err := service.UpdateBoundTrigger(adminCtx, review.ID, boundTrigger)
 
 		require.ErrorContains(t, err, "failed to get trigger:")
 	})
@@ -676,7 +1108,7 @@ func TestService_UpdateBoundTrigger(t *testing.T) {
 			updateBoundTriggerActionFail().
 			Build(t)
 
-		_, err := service.UpdateBoundTrigger(context.Background(), review.ID, updatedTrigger)
+		_, err := service.UpdateBoundTrigger(adminCtx, review.ID, updatedTrigger)
 
 		require.ErrorContains(t, err, "action to update bound trigger failed:")
 	})
@@ -696,14 +1128,517 @@ func TestService_UpdateBoundTrigger(t *testing.T) {
 			})(review)).
 			Build(t)
 
-		actual, err := service.UpdateBoundTrigger(context.Background(), review.ID, updatedTrigger)
+		actual, err := service.UpdateBoundTrigger(adminCtx, review.ID, updatedTrigger)
 
 		require.NoError(t, err)
 		require.Equal(t, updatedTrigger, actual)
 	})
 }
 
-func TestReview_Update(t *testing.T) {
+func TestService_UnbindContributingCause(t *testing.T) {
+	t.Run("the discussion about the cause is kept and what was attached is deleted", func(t *testing.T) {
+		blobs := blob.NewMemoryStore()
+		service, err := reviewing.NewService(storage.NewMemoryStore(), nil, nil, reviewing.WithAttachments(blobs, reviewing.DefaultAttachmentLimits()))
+		require.NoError(t, err)
+		review, err := service.Save(adminCtx, a.Review().IsNotSaved().WithContributingCause().Build())
+		require.NoError(t, err)
+		boundCauseID := review.BoundCauses[0].ID
+		comment, err := service.AddComment(adminCtx, review.ID, a.Comment().IsNotSaved().WithSubject(reviewing.CommentOnBoundCause, boundCauseID).Build())
+		require.NoError(t, err)
+		attachment, err := service.Attach(adminCtx, review.ID, reviewing.Attachment{Name: "log.txt", BoundCauseID: boundCauseID}, strings.NewReader("an error happened"))
+		require.NoError(t, err)
+
+		require.NoError(t, service.UnbindContributingCause(adminCtx, review.ID, boundCauseID))
+
+		actual, err := service.Get(adminCtx, review.ID)
+		require.NoError(t, err)
+		require.Empty(t, actual.BoundCauses)
+		require.Empty(t, actual.Attachments)
+		require.Equal(t, []reviewing.CommentThread{{Comment: comment}}, actual.CommentThreadsOnRemovedItems())
+		_, err = blobs.Open(adminCtx, attachment.Key())
+		require.ErrorIs(t, err, blob.ErrNotFound)
+	})
+
+	t.Run("a cause that isn't bound can't be unbound", func(t *testing.T) {
+		service, err := reviewing.NewService(storage.NewMemoryStore(), nil, nil)
+		require.NoError(t, err)
+		review, err := service.Save(adminCtx, a.Review().IsNotSaved().Build())
+		require.NoError(t, err)
+
+		err = service.UnbindContributingCause(adminCtx, review.ID, a.UUID())
+
+		require.ErrorIs(t, err, failure.NotFound)
+	})
+
+	t.Run("viewers can't unbind causes", func(t *testing.T) {
+		service, err := reviewing.NewService(storage.NewMemoryStore(), nil, nil)
+		require.NoError(t, err)
+		review, err := service.Save(adminCtx, a.Review().IsNotSaved().WithContributingCause().Build())
+		require.NoError(t, err)
+		viewerCtx := actor.With(context.Background(), a.Actor().WithRole(actor.RoleViewer).Build())
+
+		err = service.UnbindContributingCause(viewerCtx, review.ID, review.BoundCauses[0].ID)
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+}
+
+func TestService_UnbindTrigger(t *testing.T) {
+	t.Run("the discussion about the trigger is kept", func(t *testing.T) {
+		service, err := reviewing.NewService(storage.NewMemoryStore(), nil, nil)
+		require.NoError(t, err)
+		review, err := service.Save(adminCtx, a.Review().IsNotSaved().WithBoundTrigger(a.BoundTrigger().Build()).Build())
+		require.NoError(t, err)
+		boundTriggerID := review.BoundTriggers[0].ID
+		comment, err := service.AddComment(adminCtx, review.ID, a.Comment().IsNotSaved().WithSubject(reviewing.CommentOnBoundTrigger, boundTriggerID).Build())
+		require.NoError(t, err)
+
+		require.NoError(t, service.UnbindTrigger(adminCtx, review.ID, boundTriggerID))
+
+		actual, err := service.Get(adminCtx, review.ID)
+		require.NoError(t, err)
+		require.Empty(t, actual.BoundTriggers)
+		require.Equal(t, []reviewing.CommentThread{{Comment: comment}}, actual.CommentThreadsOnRemovedItems())
+	})
+}
+
+func TestService_VoteOnBoundContributingCause(t *testing.T) {
+	t.Run("when the review doesn't exist it returns an error", func(t *testing.T) {
+		service := newService().
+			getReviewFail().
+			Build(t)
+
+		_, err := service.VoteOnBoundContributingCause(adminCtx, a.UUID(), a.UUID(), a.Vote().Build())
+
+		require.ErrorContains(t, err, "failed to get review:")
+	})
+
+	t.Run("when the vote can't be recorded it returns an error", func(t *testing.T) {
+		review := a.Review().WithContributingCause().Build()
+		service := newService().
+			getReview(review).
+			voteOnBoundContributingCauseActionFail().
+			Build(t)
+
+		_, err := service.VoteOnBoundContributingCause(adminCtx, review.ID, review.BoundCauses[0].ID, a.Vote().Build())
+
+		require.ErrorContains(t, err, "action to vote on bound contributing cause failed:")
+	})
+
+	t.Run("when the vote is recorded it returns the reviewing.BoundCause as saved", func(t *testing.T) {
+		review := a.Review().WithContributingCause().Build()
+		vote := a.Vote().Build()
+		expected := a.BoundCause().WithVote(vote).Build()
+		service := newService().
+			getReview(review).
+			voteOnBoundContributingCauseAction(review, expected.ID, vote).
+			saveAction(review).
+			saveReview(a.Review().WithContributingCause(expected).Build()).
+			Build(t)
+
+		actual, err := service.VoteOnBoundContributingCause(adminCtx, review.ID, expected.ID, vote)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("everyone votes as themselves and voting twice counts once", func(t *testing.T) {
+		service, err := reviewing.NewService(storage.NewMemoryStore(), nil, nil)
+		require.NoError(t, err)
+		review, err := service.Save(adminCtx, a.Review().IsNotSaved().WithContributingCause().Build())
+		require.NoError(t, err)
+		boundCauseID := review.BoundCauses[0].ID
+		otherCtx := actor.With(context.Background(), a.Actor().WithID(a.UUID()).WithRole(actor.RoleAdmin).Build())
+
+		_, err = service.VoteOnBoundContributingCause(adminCtx, review.ID, boundCauseID, a.Vote().IsNotSaved().WithVoter(a.UUID()).Build())
+		require.NoError(t, err)
+		_, err = service.VoteOnBoundContributingCause(otherCtx, review.ID, boundCauseID, a.Vote().IsNotSaved().Build())
+		require.NoError(t, err)
+		actual, err := service.VoteOnBoundContributingCause(adminCtx, review.ID, boundCauseID, a.Vote().IsNotSaved().WithVoter(a.UUID()).Build())
+		require.NoError(t, err)
+
+		require.Equal(t, 2, actual.Votes.Tally().For, "expected the admin's second vote to replace their first")
+		require.Equal(t, actor.ID(adminCtx), actual.Votes[1].VoterID, "expected the voter to be who is signed in, not who the vote says")
+	})
+}
+
+func TestService_VoteOnBoundTrigger(t *testing.T) {
+	t.Run("when the review doesn't exist it returns an error", func(t *testing.T) {
+		service := newService().
+			getReviewFail().
+			Build(t)
+
+		_, err := service.VoteOnBoundTrigger(adminCtx, a.UUID(), a.UUID(), a.Vote().Build())
+
+		require.ErrorContains(t, err, "failed to get review:")
+	})
+
+	t.Run("when the vote can't be recorded it returns an error", func(t *testing.T) {
+		review := a.Review().WithBoundTrigger(a.BoundTrigger().Build()).Build()
+		service := newService().
+			getReview(review).
+			voteOnBoundTriggerActionFail().
+			Build(t)
+
+		_, err := service.VoteOnBoundTrigger(adminCtx, review.ID, review.BoundTriggers[0].ID, a.Vote().Build())
+
+		require.ErrorContains(t, err, "action to vote on bound trigger failed:")
+	})
+
+	t.Run("when the vote is recorded it returns the reviewing.BoundTrigger as saved", func(t *testing.T) {
+		review := a.Review().WithBoundTrigger(a.BoundTrigger().Build()).Build()
+		vote := a.Vote().IsAgainst().Build()
+		expected := a.BoundTrigger().WithVote(vote).Build()
+		service := newService().
+			getReview(review).
+			voteOnBoundTriggerAction(review, expected.ID, vote).
+			saveAction(review).
+			saveReview(a.Review().WithBoundTrigger(expected).Build()).
+			Build(t)
+
+		actual, err := service.VoteOnBoundTrigger(adminCtx, review.ID, expected.ID, vote)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+}
+
+func TestService_AddComment(t *testing.T) {
+	t.Run("when the review doesn't exist it returns an error", func(t *testing.T) {
+		service := newService().
+			getReviewFail().
+			Build(t)
+
+		_, err := service.AddComment(adminCtx, a.UUID(), a.Comment().Build())
+
+		require.ErrorContains(t, err, "failed to get review:")
+	})
+
+	t.Run("when the comment can't be added it returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+		service := newService().
+			getReview(review).
+			addCommentActionFail().
+			Build(t)
+
+		_, err := service.AddComment(adminCtx, review.ID, a.Comment().Build())
+
+		require.ErrorContains(t, err, "action to add comment failed:")
+	})
+
+	t.Run("when the comment is added it returns it as saved", func(t *testing.T) {
+		review := a.Review().Build()
+		comment := a.Comment().Build()
+		service := newService().
+			getReview(review).
+			addCommentAction(review, comment).
+			saveAction(review).
+			saveReview(a.Review().WithComment(comment).Build()).
+			Build(t)
+
+		actual, err := service.AddComment(adminCtx, review.ID, comment)
+
+		require.NoError(t, err)
+		require.Equal(t, comment, actual)
+	})
+}
+
+func TestService_EditComment(t *testing.T) {
+	t.Run("when the review doesn't exist it returns an error", func(t *testing.T) {
+		service := newService().
+			getReviewFail().
+			Build(t)
+
+		_, err := service.EditComment(adminCtx, a.UUID(), a.UUID(), "hello")
+
+		require.ErrorContains(t, err, "failed to get review:")
+	})
+
+	t.Run("when the comment can't be edited it returns an error", func(t *testing.T) {
+		review := a.Review().WithComment(a.Comment().Build()).Build()
+		service := newService().
+			getReview(review).
+			editCommentActionFail().
+			Build(t)
+
+		_, err := service.EditComment(adminCtx, review.ID, a.Comment().Build().ID, "hello")
+
+		require.ErrorContains(t, err, "action to edit comment failed:")
+	})
+
+	t.Run("when the comment is edited it returns it as saved", func(t *testing.T) {
+		review := a.Review().WithComment(a.Comment().Build()).Build()
+		expected := a.Comment().WithBody("hello").Build()
+		service := newService().
+			getReview(review).
+			editCommentAction(review, expected.ID, "hello").
+			saveAction(review).
+			saveReview(a.Review().WithComment(expected).Build()).
+			Build(t)
+
+		actual, err := service.EditComment(adminCtx, review.ID, expected.ID, "hello")
+
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+}
+
+func TestService_DeleteComment(t *testing.T) {
+	t.Run("when the review doesn't exist it returns an error", func(t *testing.T) {
+		service := newService().
+			getReviewFail().
+			Build(t)
+
+		_, err := service.DeleteComment(adminCtx, a.UUID(), a.UUID())
+
+		require.ErrorContains(t, err, "failed to get review:")
+	})
+
+	t.Run("when the comment can't be deleted it returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+		service := newService().
+			getReview(review).
+			deleteCommentActionFail().
+			Build(t)
+
+		_, err := service.DeleteComment(adminCtx, review.ID, a.UUID())
+
+		require.ErrorContains(t, err, "action to delete comment failed:")
+	})
+
+	t.Run("when the comment is deleted it returns it as saved", func(t *testing.T) {
+		review := a.Review().WithComment(a.Comment().Build()).Build()
+		expected := a.Comment().WithBody("").Build()
+		expected.IsDeleted = true
+		service := newService().
+			getReview(review).
+			deleteCommentAction(review, expected.ID).
+			saveAction(review).
+			saveReview(a.Review().WithComment(expected).Build()).
+			Build(t)
+
+		actual, err := service.DeleteComment(adminCtx, review.ID, expected.ID)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+}
+
+func TestService_ChangeComment(t *testing.T) {
+	author, other := a.UUID(), a.UUID()
+	authorCtx := actor.With(context.Background(), a.Actor().WithID(author).WithRole(actor.RoleContributor).Build())
+	otherCtx := actor.With(context.Background(), a.Actor().WithID(other).WithRole(actor.RoleContributor).Build())
+	newReview := func(t *testing.T) (*reviewing.Service, reviewing.Comment) {
+		t.Helper()
+		service, err := reviewing.NewService(storage.NewMemoryStore(), nil, nil)
+		require.NoError(t, err)
+		review, err := service.Save(adminCtx, a.Review().IsNotSaved().WithParticipant(author, other).Build())
+		require.NoError(t, err)
+		comment, err := service.AddComment(authorCtx, review.ID, a.Comment().IsNotSaved().WithAuthor(a.UUID()).WithSubject(reviewing.CommentOnReview, review.ID).Build())
+		require.NoError(t, err)
+
+		return service, comment
+	}
+
+	t.Run("the comment is written by who is signed in", func(t *testing.T) {
+		_, comment := newReview(t)
+
+		require.Equal(t, author, comment.AuthorID)
+	})
+
+	t.Run("someone else taking part in the review can't edit or delete it", func(t *testing.T) {
+		service, comment := newReview(t)
+
+		_, err := service.EditComment(otherCtx, comment.Subject.ID, comment.ID, "hello")
+		require.ErrorIs(t, err, actor.ErrForbidden)
+		_, err = service.DeleteComment(otherCtx, comment.Subject.ID, comment.ID)
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
+	t.Run("the author can edit and delete it", func(t *testing.T) {
+		service, comment := newReview(t)
+
+		edited, err := service.EditComment(authorCtx, comment.Subject.ID, comment.ID, "hello")
+		require.NoError(t, err)
+		require.Equal(t, "hello", edited.Body)
+		deleted, err := service.DeleteComment(authorCtx, comment.Subject.ID, comment.ID)
+		require.NoError(t, err)
+		require.True(t, deleted.IsDeleted)
+	})
+
+	t.Run("an admin can delete it", func(t *testing.T) {
+		service, comment := newReview(t)
+
+		deleted, err := service.DeleteComment(adminCtx, comment.Subject.ID, comment.ID)
+
+		require.NoError(t, err)
+		require.True(t, deleted.IsDeleted)
+	})
+}
+
+func TestService_AddMember(t *testing.T) {
+	t.Run("a facilitator of the review can add someone to it", func(t *testing.T) {
+		facilitator, participant := a.UUID(), a.UUID()
+		review := a.Review().WithFacilitator(facilitator).Build()
+		expected := a.Review().WithFacilitator(facilitator).WithParticipant(participant).Build()
+		service := newService().
+			getReview(review).
+			addMemberAction(review, participant, reviewing.MemberParticipant).
+			saveAction(expected).
+			saveReview(expected).
+			Build(t)
+		ctx := actor.With(context.Background(), a.Actor().WithID(facilitator).WithRole(actor.RoleContributor).Build())
+
+		actual, err := service.AddMember(ctx, review.ID, participant, reviewing.MemberParticipant)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("a participant isn't allowed to add someone", func(t *testing.T) {
+		participant := a.UUID()
+		review := a.Review().WithFacilitator(a.UUID()).WithParticipant(participant).Build()
+		service := newService().
+			getReview(review).
+			Build(t)
+		ctx := actor.With(context.Background(), a.Actor().WithID(participant).WithRole(actor.RoleContributor).Build())
+
+		_, err := service.AddMember(ctx, review.ID, a.UUID(), reviewing.MemberParticipant)
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+}
+
+func TestService_RemoveMember(t *testing.T) {
+	t.Run("wraps the error from the action", func(t *testing.T) {
+		review := a.Review().Build()
+		service := newService().
+			getReview(review).
+			removeMemberActionFail().
+			Build(t)
+
+		_, err := service.RemoveMember(adminCtx, review.ID, a.UUID())
+
+		require.ErrorContains(t, err, "action to remove member failed:")
+	})
+}
+
+func TestService_MoveToTeam(t *testing.T) {
+	t.Run("only admins can move reviews between teams", func(t *testing.T) {
+		review := a.Review().WithFacilitator(a.UUID()).Build()
+		service := newService().Build(t)
+		ctx := actor.With(context.Background(), a.Actor().WithID(review.Facilitators[0]).Build())
+
+		_, err := service.MoveToTeam(ctx, review.ID, a.UUID())
+
+		require.ErrorIs(t, err, actor.ErrForbidden, "expected even the facilitator of the review to not be allowed")
+	})
+
+	t.Run("gets the review from any team and saves it with the new team", func(t *testing.T) {
+		team := a.UUID()
+		review := a.Review().WithTeam(a.UUID()).Build()
+		expected := a.Review().WithTeam(team).Build()
+		b := newService().
+			moveToTeamAction().
+			saveAction(expected)
+		b.reviewStorage.On("Get", mock.MatchedBy(tenant.IsUnscoped), review.ID).Return(review, nil)
+		b.reviewStorage.On("Save", mock.MatchedBy(tenant.IsUnscoped), expected).Return(expected, nil)
+		service := b.Build(t)
+
+		actual, err := service.MoveToTeam(adminCtx, review.ID, team)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+}
+
+func TestService_authorization(t *testing.T) {
+	facilitator, participant, outsider := a.UUID(), a.UUID(), a.UUID()
+	review := a.Review().WithFacilitator(facilitator).WithParticipant(participant).Build()
+	as := func(id uuid.UUID, role actor.Role) context.Context {
+		return actor.With(context.Background(), a.Actor().WithID(id).WithRole(role).Build())
+	}
+
+	t.Run("nobody signed in can't change a review", func(t *testing.T) {
+		service := newService().getReview(review).Build(t)
+
+		_, err := service.Update(context.Background(), review.ID, review)
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
+	for name, ctx := range map[string]context.Context{
+		"a contributor who isn't part of the review": as(outsider, actor.RoleContributor),
+		"a viewer even when part of the review":      as(participant, actor.RoleViewer),
+	} {
+		t.Run(name+" can't contribute to it", func(t *testing.T) {
+			service := newService().getReview(review).Build(t)
+
+			_, err := service.AddComment(ctx, review.ID, a.Comment().Build())
+
+			require.ErrorIs(t, err, actor.ErrForbidden)
+		})
+	}
+
+	t.Run("a participant can't move the review through its lifecycle", func(t *testing.T) {
+		service := newService().getReview(review).Build(t)
+
+		_, err := service.Transition(as(participant, actor.RoleContributor), review.ID, reviewing.StateInReview)
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
+	t.Run("only facilitators and admins can start a review", func(t *testing.T) {
+		newReview := a.Review().IsNotSaved().Build()
+		service := newService().Build(t)
+
+		_, err := service.Save(as(outsider, actor.RoleContributor), newReview)
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
+	t.Run("a new review belongs to the team it's started in", func(t *testing.T) {
+		team := a.UUID()
+		newReview := a.Review().IsNotSaved().Build()
+		service := newService().
+			saveAction(a.Review().IsNotSaved().WithTeam(team).Build()).
+			saveReview(newReview).
+			Build(t)
+
+		_, err := service.Save(tenant.With(adminCtx, team), newReview)
+
+		require.NoError(t, err, "expected the save action to get the review with the team")
+	})
+
+	t.Run("saving an existing review can't change which team it belongs to", func(t *testing.T) {
+		team := a.UUID()
+		stored := a.Review().WithTeam(team).Build()
+		service := newService().
+			getReview(stored).
+			saveAction(stored).
+			saveReview(stored).
+			Build(t)
+
+		_, err := service.Save(adminCtx, a.Review().WithTeam(a.UUID()).Build())
+
+		require.NoError(t, err, "expected the save action to get the review with the stored team")
+	})
+
+	t.Run("saving an existing review can't change who is part of it", func(t *testing.T) {
+		changed := a.Review().WithFacilitator(facilitator, outsider).WithParticipant(participant).Build()
+		service := newService().
+			getReview(review).
+			saveAction(review).
+			saveReview(review).
+			Build(t)
+
+		_, err := service.Save(as(participant, actor.RoleContributor), changed)
+
+		require.NoError(t, err, "expected the save action to get the review with the stored members")
+	})
+}
+
+func TestReview_Update(t *testing.T) {
 	t.Run("an update with no changes doesn't modify the object", func(t *testing.T) {
 		orig := a.Review().Build()
 		upd := a.Review().Build()
@@ -876,6 +1811,17 @@ func TestReview_UpdateBoundContributingCause(t *testing.T) {
 			"expected the proximal cause to have been removed from the second cause",
 		)
 	})
+
+	t.Run("keeps the votes cast on the bound cause when the update doesn't have any", func(t *testing.T) {
+		vote := a.Vote().Build()
+		review := a.Review().WithContributingCause(a.BoundCause().WithVote(vote).Build()).Build()
+		updatedCause := a.BoundCause().WithWhy("updated cause").Build()
+
+		actual, err := review.UpdateBoundContributingCause(updatedCause)
+
+		require.NoError(t, err)
+		require.Equal(t, reviewing.Votes{vote}, actual.BoundCauses[0].Votes)
+	})
 }
 
 func TestReview_BindTrigger(t *testing.T) {
@@ -888,8 +1834,9 @@ func TestReview_BindTrigger(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotEqual(t, bt.ID, actual.BoundTriggers[0].ID)
 		require.NotEmpty(t, actual.BoundTriggers[0].ID, "expected to have set the ID when binding, overwriting any existing IDs")
+		require.WithinDuration(t, time.Now(), actual.BoundTriggers[0].BoundAt, time.Second, "expected to have recorded when it was bound")
 
-		require.Equal(t, actual, a.Review().WithBoundTrigger(a.BoundTrigger().WithID(actual.BoundTriggers[0].ID).Build()).Build())
+		require.Equal(t, actual, a.Review().WithBoundTrigger(a.BoundTrigger().WithID(actual.BoundTriggers[0].ID).WithBoundAt(actual.BoundTriggers[0].BoundAt).Build()).Build())
 		// when saving a valid trigger that hasn't been saved (i.e. it doesn't have an ID yet) it sets an id and then adds it to the list of bound triggers
 	})
 }
@@ -931,4 +1878,16 @@ func TestReview_UpdateBoundTrigger(t *testing.T) {
 			"expected the first trigger to have been replaced with the updated one",
 		)
 	})
+
+	t.Run("keeps the votes cast on the bound trigger when the update doesn't have any", func(t *testing.T) {
+		vote := a.Vote().Build()
+		review := a.Review().WithBoundTrigger(a.BoundTrigger().WithVote(vote).Build()).Build()
+		updatedTrigger := a.BoundTrigger().Build()
+		updatedTrigger.Why = "updated trigger"
+
+		actual, err := review.UpdateBoundTrigger(updatedTrigger)
+
+		require.NoError(t, err)
+		require.Equal(t, reviewing.Votes{vote}, actual.BoundTriggers[0].Votes)
+	})
 }
@@ -4,43 +4,213 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
+
 	"github.com/gaqzi/incident-reviewer/internal/normalized"
 	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
 	"github.com/gaqzi/incident-reviewer/internal/platform/action"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
 	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
 )
 
-// reviewServiceActions provides actions to be taken as pre-hooks in my reviewing.Service.
-// It's a way for me to keep certain logic private (so it doesn't leak in my public interface) while
-// also keeping the service clean in that it only does collaboration.
-// I'm not 100% about this pattern, but I'm making pieces that are either about collaboration or logic, so it's
-// something.
-func reviewServiceActions() *action.Mapper {
-	m := &action.Mapper{}
+// The actions reviewing.Service performs, they can be replaced with WithActions and run around with WithMiddleware.
+// The keys are returned by functions so they can't be changed from outside the package.
+
+func ActionBindContributingCause() action.Key[func(Review, contributing.Cause, BoundCause) (Review, error)] {
+	return action.NewKey[func(Review, contributing.Cause, BoundCause) (Review, error)]("BindContributingCause")
+}
+
+func ActionUpdateBoundContributingCause() action.Key[func(Review, BoundCause) (Review, error)] {
+	return action.NewKey[func(Review, BoundCause) (Review, error)]("UpdateBoundContributingCause")
+}
+
+func ActionUpdate() action.Key[func(Review, Review) (Review, error)] {
+	return action.NewKey[func(Review, Review) (Review, error)]("Update")
+}
+
+func ActionTransition() action.Key[func(Review, State, PublicationRules) (Review, error)] {
+	return action.NewKey[func(Review, State, PublicationRules) (Review, error)]("Transition")
+}
+
+func ActionSave() action.Key[func(context.Context, Review) (Review, error)] {
+	return action.NewKey[func(context.Context, Review) (Review, error)]("Save")
+}
+
+func ActionBindTrigger() action.Key[func(Review, normalized.Trigger, UnboundTrigger) (Review, error)] {
+	return action.NewKey[func(Review, normalized.Trigger, UnboundTrigger) (Review, error)]("BindTrigger")
+}
+
+func ActionUpdateBoundTrigger() action.Key[func(Review, BoundTrigger) (Review, error)] {
+	return action.NewKey[func(Review, BoundTrigger) (Review, error)]("UpdateBoundTrigger")
+}
+
+func ActionUnbindContributingCause() action.Key[func(Review, uuid.UUID) (Review, error)] {
+	return action.NewKey[func(Review, uuid.UUID) (Review, error)]("UnbindContributingCause")
+}
+
+func ActionUnbindTrigger() action.Key[func(Review, uuid.UUID) (Review, error)] {
+	return action.NewKey[func(Review, uuid.UUID) (Review, error)]("UnbindTrigger")
+}
+
+func ActionVoteOnBoundContributingCause() action.Key[func(Review, uuid.UUID, Vote) (Review, error)] {
+	return action.NewKey[func(Review, uuid.UUID, Vote) (Review, error)]("VoteOnBoundContributingCause")
+}
+
+func ActionVoteOnBoundTrigger() action.Key[func(Review, uuid.UUID, Vote) (Review, error)] {
+	return action.NewKey[func(Review, uuid.UUID, Vote) (Review, error)]("VoteOnBoundTrigger")
+}
+
+func ActionAddComment() action.Key[func(Review, Comment) (Review, error)] {
+	return action.NewKey[func(Review, Comment) (Review, error)]("AddComment")
+}
+
+func ActionEditComment() action.Key[func(Review, uuid.UUID, string) (Review, error)] {
+	return action.NewKey[func(Review, uuid.UUID, string) (Review, error)]("EditComment")
+}
+
+func ActionDeleteComment() action.Key[func(Review, uuid.UUID) (Review, error)] {
+	return action.NewKey[func(Review, uuid.UUID) (Review, error)]("DeleteComment")
+}
+
+func ActionAddAttachment() action.Key[func(Review, Attachment) (Review, error)] {
+	return action.NewKey[func(Review, Attachment) (Review, error)]("AddAttachment")
+}
+
+func ActionRemoveAttachment() action.Key[func(Review, uuid.UUID) (Review, error)] {
+	return action.NewKey[func(Review, uuid.UUID) (Review, error)]("RemoveAttachment")
+}
+
+func ActionAddMember() action.Key[func(Review, uuid.UUID, MemberKind) (Review, error)] {
+	return action.NewKey[func(Review, uuid.UUID, MemberKind) (Review, error)]("AddMember")
+}
+
+func ActionRemoveMember() action.Key[func(Review, uuid.UUID) (Review, error)] {
+	return action.NewKey[func(Review, uuid.UUID) (Review, error)]("RemoveMember")
+}
+
+func ActionMoveToTeam() action.Key[func(Review, uuid.UUID) (Review, error)] {
+	return action.NewKey[func(Review, uuid.UUID) (Review, error)]("MoveToTeam")
+}
+
+// actionKeys are all the actions the service needs, NewService checks they're all there.
+func actionKeys() []action.AnyKey {
+	return []action.AnyKey{
+		ActionBindContributingCause(),
+		ActionUpdateBoundContributingCause(),
+		ActionUpdate(),
+		ActionTransition(),
+		ActionSave(),
+		ActionBindTrigger(),
+		ActionUpdateBoundTrigger(),
+		ActionUnbindContributingCause(),
+		ActionUnbindTrigger(),
+		ActionVoteOnBoundContributingCause(),
+		ActionVoteOnBoundTrigger(),
+		ActionAddComment(),
+		ActionEditComment(),
+		ActionDeleteComment(),
+		ActionAddAttachment(),
+		ActionRemoveAttachment(),
+		ActionAddMember(),
+		ActionRemoveMember(),
+		ActionMoveToTeam(),
+	}
+}
+
+// reviewServiceActions registers what the service does to the review for each of its actions,
+// so the service only loads, authorizes and saves while the rules stay on Review.
+func reviewServiceActions() *action.Registry {
+	m := action.NewRegistry()
 
-	m.Add("BindContributingCause", func(r Review, c contributing.Cause, rc BoundCause) (Review, error) {
+	action.Set(m, ActionBindContributingCause(), func(r Review, c contributing.Cause, rc BoundCause) (Review, error) {
 		rc.Cause = c
 		return r.BindContributingCause(rc)
 	})
 
-	m.Add("UpdateBoundContributingCause", func(r Review, o BoundCause) (Review, error) {
+	action.Set(m, ActionUpdateBoundContributingCause(), func(r Review, o BoundCause) (Review, error) {
 		return r.UpdateBoundContributingCause(o)
 	})
 
-	m.Add("Save", func(ctx context.Context, r Review) (Review, error) {
+	action.Set(m, ActionUpdate(), func(r Review, o Review) (Review, error) {
+		if err := r.ensureEditable(); err != nil {
+			return r, err
+		}
+
+		return r.Update(o), nil
+	})
+
+	action.Set(m, ActionTransition(), func(r Review, to State, rules PublicationRules) (Review, error) {
+		if to == StatePublished {
+			if err := rules.Publishable(r); err != nil {
+				return r, err
+			}
+		}
+
+		return r.TransitionTo(to)
+	})
+
+	action.Set(m, ActionSave(), func(ctx context.Context, r Review) (Review, error) {
 		if err := validate.Struct(ctx, r); err != nil {
 			return r, fmt.Errorf("failed to validate review: %w", err)
 		}
 
-		return r.updateTimestamps(), nil
+		return r.updateChangedBy(actor.ID(ctx)).updateTimestamps(), nil
 	})
-	m.Add("BindTrigger", func(r Review, t normalized.Trigger, ubt UnboundTrigger) (Review, error) {
+	action.Set(m, ActionBindTrigger(), func(r Review, t normalized.Trigger, ubt UnboundTrigger) (Review, error) {
 		return r.BindTrigger(t, ubt)
 	})
 
-	m.Add("UpdateBoundTrigger", func(r Review, o BoundTrigger) (Review, error) {
+	action.Set(m, ActionUpdateBoundTrigger(), func(r Review, o BoundTrigger) (Review, error) {
 		return r.UpdateBoundTrigger(o)
 	})
 
+	action.Set(m, ActionUnbindContributingCause(), func(r Review, boundCauseID uuid.UUID) (Review, error) {
+		return r.UnbindContributingCause(boundCauseID)
+	})
+
+	action.Set(m, ActionUnbindTrigger(), func(r Review, boundTriggerID uuid.UUID) (Review, error) {
+		return r.UnbindTrigger(boundTriggerID)
+	})
+
+	action.Set(m, ActionVoteOnBoundContributingCause(), func(r Review, boundCauseID uuid.UUID, v Vote) (Review, error) {
+		return r.VoteOnBoundContributingCause(boundCauseID, v)
+	})
+
+	action.Set(m, ActionVoteOnBoundTrigger(), func(r Review, boundTriggerID uuid.UUID, v Vote) (Review, error) {
+		return r.VoteOnBoundTrigger(boundTriggerID, v)
+	})
+
+	action.Set(m, ActionAddComment(), func(r Review, c Comment) (Review, error) {
+		return r.AddComment(c)
+	})
+
+	action.Set(m, ActionEditComment(), func(r Review, commentID uuid.UUID, body string) (Review, error) {
+		return r.EditComment(commentID, body)
+	})
+
+	action.Set(m, ActionDeleteComment(), func(r Review, commentID uuid.UUID) (Review, error) {
+		return r.DeleteComment(commentID)
+	})
+
+	action.Set(m, ActionAddAttachment(), func(r Review, a Attachment) (Review, error) {
+		return r.AddAttachment(a)
+	})
+
+	action.Set(m, ActionRemoveAttachment(), func(r Review, attachmentID uuid.UUID) (Review, error) {
+		return r.RemoveAttachment(attachmentID)
+	})
+
+	action.Set(m, ActionAddMember(), func(r Review, userID uuid.UUID, kind MemberKind) (Review, error) {
+		return r.AddMember(userID, kind)
+	})
+
+	action.Set(m, ActionRemoveMember(), func(r Review, userID uuid.UUID) (Review, error) {
+		return r.RemoveMember(userID)
+	})
+
+	action.Set(m, ActionMoveToTeam(), func(r Review, teamID uuid.UUID) (Review, error) {
+		return r.MoveToTeam(teamID), nil
+	})
+
 	return m
 }
@@ -11,33 +11,56 @@ import (
 
 	"github.com/gaqzi/incident-reviewer/internal/normalized"
 	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
+	"github.com/gaqzi/incident-reviewer/internal/platform/action"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
 )
 
-func TestActionMapper(t *testing.T) {
-	t.Run("the default mapper covers all actions with custom behavior on the Review", func(t *testing.T) {
-		mapper := reviewServiceActions()
+func actionNames(pipelines []action.Pipeline) []string {
+	ret := make([]string, 0, len(pipelines))
+	for _, p := range pipelines {
+		ret = append(ret, p.Action)
+	}
+
+	return ret
+}
+
+func TestReviewServiceActions(t *testing.T) {
+	t.Run("the default actions cover all actions with custom behavior on the Review", func(t *testing.T) {
+		actions := reviewServiceActions()
 
 		require.ElementsMatch(
 			t,
 			[]string{
 				"BindContributingCause",
 				"UpdateBoundContributingCause",
+				"Update",
+				"Transition",
 				"Save",
 				"BindTrigger",
 				"UpdateBoundTrigger",
+				"VoteOnBoundContributingCause",
+				"VoteOnBoundTrigger",
+				"AddComment",
+				"EditComment",
+				"DeleteComment",
+				"AddAttachment",
+				"RemoveAttachment",
+				"AddMember",
+				"RemoveMember",
+				"MoveToTeam",
+				"UnbindContributingCause",
+				"UnbindTrigger",
 			},
-			mapper.All(),
+			actionNames(actions.All()),
 			"expected all causes to be listed here so we catch when we add new or remove one",
 		)
+		require.NoError(t, actions.Check(actionKeys()...), "expected every action to have a function of the key's type")
 	})
 
-	t.Run("BindContributingCause sets the contributing.Cause on the BoundCause before adding it to the Review and sets a valid ID if not provided", func(t *testing.T) {
-		mapper := reviewServiceActions()
+	t.Run("BindContributingCause sets the contributing.Cause on the BoundCause before adding it to the Review and sets a valid ID and when it was bound if not provided", func(t *testing.T) {
+		actions := reviewServiceActions()
 
-		doer, err := mapper.Get("BindContributingCause")
-		require.NoError(t, err)
-		do, ok := doer.(func(Review, contributing.Cause, BoundCause) (Review, error))
-		require.True(t, ok)
+		do := action.Get(actions, ActionBindContributingCause())
 
 		cause := contributing.Cause{Name: "Something"}
 		review, err := do(Review{}, cause, BoundCause{})
@@ -45,19 +68,16 @@ func TestActionMapper(t *testing.T) {
 
 		require.Equal(
 			t,
-			Review{BoundCauses: []BoundCause{{ID: review.BoundCauses[0].ID, Cause: cause}}},
+			Review{BoundCauses: []BoundCause{{ID: review.BoundCauses[0].ID, Cause: cause, BoundAt: review.BoundCauses[0].BoundAt}}},
 			review,
 			"expected the contributing cause to have been set on the BoundCause and then added to the Review",
 		)
 	})
 
-	t.Run("BindTrigger sets the normalized.Trigger on the BoundTrigger before adding it to the Review and sets a valid ID if not provided", func(t *testing.T) {
-		mapper := reviewServiceActions()
+	t.Run("BindTrigger sets the normalized.Trigger on the BoundTrigger before adding it to the Review and sets a valid ID and when it was bound if not provided", func(t *testing.T) {
+		actions := reviewServiceActions()
 
-		doer, err := mapper.Get("BindTrigger")
-		require.NoError(t, err)
-		do, ok := doer.(func(Review, normalized.Trigger, UnboundTrigger) (Review, error))
-		require.True(t, ok)
+		do := action.Get(actions, ActionBindTrigger())
 
 		trigger := normalized.Trigger{Name: "Something"}
 		review, err := do(Review{}, trigger, UnboundTrigger{Why: "a good reason"})
@@ -65,19 +85,16 @@ func TestActionMapper(t *testing.T) {
 
 		require.Equal(
 			t,
-			Review{BoundTriggers: []BoundTrigger{{ID: review.BoundTriggers[0].ID, Trigger: trigger, UnboundTrigger: UnboundTrigger{Why: "a good reason"}}}},
+			Review{BoundTriggers: []BoundTrigger{{ID: review.BoundTriggers[0].ID, Trigger: trigger, UnboundTrigger: UnboundTrigger{Why: "a good reason"}, BoundAt: review.BoundTriggers[0].BoundAt}}},
 			review,
 			"expected the contributing trigger to have been set on the BoundTrigger and then added to the Review",
 		)
 	})
 
 	t.Run("UpdateBoundTrigger updates the BoundTrigger in the Review", func(t *testing.T) {
-		mapper := reviewServiceActions()
+		actions := reviewServiceActions()
 
-		doer, err := mapper.Get("UpdateBoundTrigger")
-		require.NoError(t, err)
-		do, ok := doer.(func(Review, BoundTrigger) (Review, error))
-		require.True(t, ok)
+		do := action.Get(actions, ActionUpdateBoundTrigger())
 
 		// Create a review with a bound trigger
 		triggerID := uuid.Must(uuid.NewV7())
@@ -115,15 +132,33 @@ func TestActionMapper(t *testing.T) {
 		)
 	})
 
+	t.Run("Transition checks the publication rules before publishing", func(t *testing.T) {
+		actions := reviewServiceActions()
+
+		do := action.Get(actions, ActionTransition())
+
+		review := Review{State: StateAwaitingApproval}
+		rules := PublicationRules{{Kind: RuleBoundTrigger}}
+
+		_, err := do(review, StatePublished, rules)
+		var pubErr *PublicationError
+		require.ErrorAs(t, err, &pubErr, "expected to not publish without a bound trigger")
+
+		review.BoundTriggers = []BoundTrigger{{ID: uuid.Must(uuid.NewV7())}}
+		review, err = do(review, StatePublished, rules)
+		require.NoError(t, err)
+		require.Equal(t, StatePublished, review.State)
+
+		_, err = do(Review{State: StateDraft}, StateInReview, rules)
+		require.NoError(t, err, "expected the rules to only be checked when publishing")
+	})
+
 	t.Run("Save validates and returns an error when it fails to validate", func(t *testing.T) {
-		mapper := reviewServiceActions()
+		actions := reviewServiceActions()
 
-		doer, actual := mapper.Get("Save")
-		require.NoError(t, actual)
-		do, ok := doer.(func(context.Context, Review) (Review, error))
-		require.True(t, ok)
+		do := action.Get(actions, ActionSave())
 
-		_, actual = do(context.Background(), Review{})
+		_, actual := do(context.Background(), Review{})
 
 		var errs validator.ValidationErrors
 		require.ErrorAs(t, actual, &errs, "expected to have gotten back validation errors")
@@ -132,11 +167,8 @@ func TestActionMapper(t *testing.T) {
 	})
 
 	t.Run("Save updates the timestamps after successfully validating", func(t *testing.T) {
-		mapper := reviewServiceActions()
-		doer, actual := mapper.Get("Save")
-		require.NoError(t, actual)
-		do, ok := doer.(func(context.Context, Review) (Review, error))
-		require.True(t, ok)
+		actions := reviewServiceActions()
+		do := action.Get(actions, ActionSave())
 
 		validReview := func() Review {
 			return Review{
@@ -148,6 +180,7 @@ func TestActionMapper(t *testing.T) {
 				Where:               "example",
 				ReportProximalCause: "example",
 				ReportTrigger:       "example",
+				State:               StateDraft,
 			}
 		}
 
@@ -161,6 +194,19 @@ func TestActionMapper(t *testing.T) {
 			require.Equal(t, r.CreatedAt, r.UpdatedAt, "expected to have been set to the same when both are blank")
 		})
 
+		t.Run("records who created it on the first save and who changed it on every save", func(t *testing.T) {
+			creator, changer := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+
+			r, err := do(actor.With(context.Background(), actor.Actor{ID: creator, Role: actor.RoleFacilitator}), validReview())
+			require.NoError(t, err)
+			r, err = do(actor.With(context.Background(), actor.Actor{ID: changer, Role: actor.RoleFacilitator}), r)
+			require.NoError(t, err)
+
+			require.Equal(t, creator, r.CreatedBy)
+			require.Equal(t, changer, r.UpdatedBy)
+			require.Equal(t, []uuid.UUID{creator}, r.Facilitators, "expected whoever created it to be facilitating it")
+		})
+
 		t.Run("when created at already is set then only updated at is updated", func(t *testing.T) {
 			r := validReview()
 			now := time.Now()
@@ -0,0 +1,97 @@
+package reviewing
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+// State is where in its lifecycle a Review is.
+type State string
+
+const (
+	StateDraft            State = "draft"
+	StateInReview         State = "in_review"
+	StateAwaitingApproval State = "awaiting_approval"
+	StatePublished        State = "published"
+	StateArchived         State = "archived"
+)
+
+// States are all the states a Review can be in, in the order they're usually moved through.
+var States = []State{StateDraft, StateInReview, StateAwaitingApproval, StatePublished, StateArchived}
+
+// transitions are the states a Review is allowed to move to from each state.
+// Moving from published back to in review is how a published review is reopened for changes.
+var transitions = map[State][]State{
+	StateDraft:            {StateInReview, StateArchived},
+	StateInReview:         {StateDraft, StateAwaitingApproval, StateArchived},
+	StateAwaitingApproval: {StateInReview, StatePublished},
+	StatePublished:        {StateInReview, StateArchived},
+	StateArchived:         {StateDraft},
+}
+
+// ErrReadOnly is returned when trying to change a Review that has to be reopened first.
+var ErrReadOnly = failure.New(failure.Conflict, "review is read-only in its current state")
+
+// Transition records when a Review moved between two states.
+type Transition struct {
+	From State
+	To   State
+	At   time.Time
+}
+
+// NextStates returns the states the Review can transition to from its current state.
+func (r Review) NextStates() []State {
+	return slices.Clone(transitions[r.State])
+}
+
+// TransitionTo moves the Review to the state and records the transition.
+func (r Review) TransitionTo(to State) (Review, error) {
+	if !slices.Contains(transitions[r.State], to) {
+		return r, failure.New(failure.Conflict, "cannot transition review from "+string(r.State)+" to "+string(to))
+	}
+
+	r.Transitions = append(slices.Clone(r.Transitions), Transition{From: r.State, To: to, At: time.Now()})
+	r.State = to
+
+	return r, nil
+}
+
+// IsReadOnly is true when the Review can't be changed without transitioning it first.
+func (r Review) IsReadOnly() bool {
+	return r.State == StatePublished || r.State == StateArchived
+}
+
+// ensureEditable is a guard for all the changes made to a Review.
+func (r Review) ensureEditable() error {
+	if r.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	return nil
+}
+
+// Filter narrows down which reviews to return, the zero value matches all reviews.
+type Filter struct {
+	State State
+	// Custom are the values the custom fields have to have, by the ID of the field.
+	Custom map[uuid.UUID]string
+}
+
+// Matches checks whether the review should be included.
+func (f Filter) Matches(r Review) bool {
+	if f.State != "" && f.State != r.State {
+		return false
+	}
+	for id, want := range f.Custom {
+		if !strings.EqualFold(strings.TrimSpace(r.CustomValues[id]), strings.TrimSpace(want)) {
+			return false
+		}
+	}
+
+	return true
+}
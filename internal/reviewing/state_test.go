@@ -0,0 +1,132 @@
+package reviewing_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestReview_TransitionTo(t *testing.T) {
+	t.Run("a new review starts as a draft", func(t *testing.T) {
+		require.Equal(t, reviewing.StateDraft, reviewing.NewReview().State)
+	})
+
+	t.Run("moving to an allowed state records the transition", func(t *testing.T) {
+		review := a.Review().Build()
+
+		actual, err := review.TransitionTo(reviewing.StateInReview)
+
+		require.NoError(t, err)
+		require.Equal(t, reviewing.StateInReview, actual.State)
+		require.Len(t, actual.Transitions, 1)
+		require.Equal(t, reviewing.StateDraft, actual.Transitions[0].From)
+		require.Equal(t, reviewing.StateInReview, actual.Transitions[0].To)
+		require.NotZero(t, actual.Transitions[0].At, "expected to know when the transition happened")
+		require.Empty(t, review.Transitions, "expected the original review to not have changed")
+	})
+
+	t.Run("moving to a state that isn't allowed from the current one returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+
+		actual, err := review.TransitionTo(reviewing.StatePublished)
+
+		require.ErrorContains(t, err, "cannot transition review from draft to published")
+		require.Equal(t, review, actual, "expected the review to not have changed")
+	})
+
+	t.Run("a published review can be reopened for changes", func(t *testing.T) {
+		review := a.Review().WithState(reviewing.StatePublished).Build()
+		require.True(t, review.IsReadOnly())
+
+		actual, err := review.TransitionTo(reviewing.StateInReview)
+
+		require.NoError(t, err)
+		require.False(t, actual.IsReadOnly())
+	})
+
+	t.Run("the whole way from draft to archived is recorded in order", func(t *testing.T) {
+		review := a.Review().Build()
+
+		var err error
+		for _, to := range []reviewing.State{reviewing.StateInReview, reviewing.StateAwaitingApproval, reviewing.StatePublished, reviewing.StateArchived} {
+			review, err = review.TransitionTo(to)
+			require.NoError(t, err)
+		}
+
+		var path []reviewing.State
+		for _, tr := range review.Transitions {
+			path = append(path, tr.To)
+		}
+		require.Equal(
+			t,
+			[]reviewing.State{reviewing.StateInReview, reviewing.StateAwaitingApproval, reviewing.StatePublished, reviewing.StateArchived},
+			path,
+		)
+	})
+}
+
+func TestReview_NextStates(t *testing.T) {
+	require.Equal(
+		t,
+		[]reviewing.State{reviewing.StateInReview, reviewing.StatePublished},
+		a.Review().WithState(reviewing.StateAwaitingApproval).Build().NextStates(),
+	)
+}
+
+func TestReview_readOnly(t *testing.T) {
+	for _, state := range []reviewing.State{reviewing.StatePublished, reviewing.StateArchived} {
+		review := a.Review().
+			WithContributingCause(a.BoundCause().Build()).
+			WithBoundTrigger(a.BoundTrigger().Build()).
+			WithState(state).
+			Build()
+
+		for name, change := range map[string]func() (reviewing.Review, error){
+			"BindContributingCause": func() (reviewing.Review, error) {
+				return review.BindContributingCause(a.BoundCause().WithWhy("another reason").Build())
+			},
+			"UpdateBoundContributingCause": func() (reviewing.Review, error) {
+				return review.UpdateBoundContributingCause(review.BoundCauses[0])
+			},
+			"BindTrigger": func() (reviewing.Review, error) {
+				return review.BindTrigger(a.NormalizedTrigger().Build(), reviewing.UnboundTrigger{Why: "because"})
+			},
+			"UpdateBoundTrigger": func() (reviewing.Review, error) {
+				return review.UpdateBoundTrigger(review.BoundTriggers[0])
+			},
+			"VoteOnBoundContributingCause": func() (reviewing.Review, error) {
+				return review.VoteOnBoundContributingCause(review.BoundCauses[0].ID, a.Vote().Build())
+			},
+			"VoteOnBoundTrigger": func() (reviewing.Review, error) {
+				return review.VoteOnBoundTrigger(review.BoundTriggers[0].ID, a.Vote().Build())
+			},
+			"AddComment": func() (reviewing.Review, error) {
+				return review.AddComment(a.Comment().Build())
+			},
+		} {
+			t.Run(name+" is rejected when "+string(state), func(t *testing.T) {
+				_, err := change()
+
+				require.ErrorIs(t, err, reviewing.ErrReadOnly)
+			})
+		}
+	}
+}
+
+func TestFilter_Matches(t *testing.T) {
+	review := a.Review().WithState(reviewing.StateInReview).Build()
+
+	require.True(t, reviewing.Filter{}.Matches(review), "expected the empty filter to match everything")
+	require.True(t, reviewing.Filter{State: reviewing.StateInReview}.Matches(review))
+	require.False(t, reviewing.Filter{State: reviewing.StateDraft}.Matches(review))
+
+	region := a.UUID()
+	review.CustomValues = map[uuid.UUID]string{region: "EU"}
+	require.True(t, reviewing.Filter{Custom: map[uuid.UUID]string{region: "eu"}}.Matches(review), "expected custom values to match regardless of case")
+	require.False(t, reviewing.Filter{Custom: map[uuid.UUID]string{region: "US"}}.Matches(review))
+	require.False(t, reviewing.Filter{Custom: map[uuid.UUID]string{a.UUID(): "EU"}}.Matches(review), "expected a value for another field to not match")
+}
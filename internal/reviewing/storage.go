@@ -10,9 +10,30 @@ type Storage interface {
 	// Save saves the review or if it fails validation return an error with all failures.
 	Save(ctx context.Context, review Review) (Review, error)
 
-	// Get finds the review or returns NotFoundError.
+	// Get finds the review or returns NotFoundError, also when it belongs to another team.
 	Get(ctx context.Context, ID uuid.UUID) (Review, error)
 
-	// All returns all the stored reviews with the most recent first.
+	// All returns all the stored reviews of the current team with the most recent first.
 	All(ctx context.Context) ([]Review, error)
 }
+
+type TemplateStorage interface {
+	Save(ctx context.Context, t Template) (Template, error)
+
+	// Get finds the template or returns an error when it doesn't exist.
+	Get(ctx context.Context, id uuid.UUID) (Template, error)
+
+	// All returns all the templates sorted by name.
+	All(ctx context.Context) ([]Template, error)
+}
+
+type CustomFieldStorage interface {
+	// Save stores the custom field, only fields of the current team can be saved.
+	Save(ctx context.Context, f CustomField) (CustomField, error)
+
+	// Get finds the custom field or returns an error when it doesn't exist, also when it belongs to another team.
+	Get(ctx context.Context, id uuid.UUID) (CustomField, error)
+
+	// All returns the custom fields of the current team in the order they were created.
+	All(ctx context.Context) ([]CustomField, error)
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+type CustomFieldMemoryStore struct {
+	data map[uuid.UUID]reviewing.CustomField
+}
+
+func NewCustomFieldMemoryStore() *CustomFieldMemoryStore {
+	return &CustomFieldMemoryStore{
+		data: make(map[uuid.UUID]reviewing.CustomField),
+	}
+}
+
+// Save stores the custom field, only fields belonging to the current team can be saved, see tenant.Owns.
+func (s *CustomFieldMemoryStore) Save(ctx context.Context, f reviewing.CustomField) (reviewing.CustomField, error) {
+	if f.ID == uuid.Nil {
+		return reviewing.CustomField{}, ErrNoID
+	}
+	if !tenant.Owns(ctx, f.TeamID) {
+		return reviewing.CustomField{}, ErrOtherTeam
+	}
+	if stored, ok := s.data[f.ID]; ok && !tenant.Owns(ctx, stored.TeamID) {
+		return reviewing.CustomField{}, &NoCustomFieldError{ID: f.ID}
+	}
+
+	s.data[f.ID] = f
+
+	return f, nil
+}
+
+func (s *CustomFieldMemoryStore) Get(ctx context.Context, id uuid.UUID) (reviewing.CustomField, error) {
+	f, ok := s.data[id]
+	if !ok || !tenant.Owns(ctx, f.TeamID) {
+		return reviewing.CustomField{}, &NoCustomFieldError{ID: id}
+	}
+
+	return f, nil
+}
+
+func (s *CustomFieldMemoryStore) All(ctx context.Context) ([]reviewing.CustomField, error) {
+	ret := make([]reviewing.CustomField, 0, len(s.data))
+
+	// The IDs are UUIDv7, so sorting them is the order they were created in
+	for _, id := range slices.SortedFunc(maps.Keys(s.data), func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) }) {
+		if tenant.Owns(ctx, s.data[id].TeamID) {
+			ret = append(ret, s.data[id])
+		}
+	}
+
+	return ret, nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
 )
 
 type NoReviewError struct {
@@ -12,8 +14,42 @@ type NoReviewError struct {
 }
 
 func (e *NoReviewError) Error() string {
-	return fmt.Sprintf("review not found by id: %d", e.ID)
+	return fmt.Sprintf("review not found by id: %s", e.ID)
+}
+
+// Is makes it a failure.NotFound.
+func (e *NoReviewError) Is(target error) bool {
+	return target == failure.NotFound
 }
 
 // ErrNoID indicates that the passed in ID is blank/uninitialized.
 var ErrNoID = errors.New("can't store review because ID is not set")
+
+// ErrOtherTeam indicates that the review belongs to another team than the one being worked as.
+var ErrOtherTeam = failure.New(failure.Forbidden, "can't store review for another team")
+
+type NoTemplateError struct {
+	ID uuid.UUID
+}
+
+func (e *NoTemplateError) Error() string {
+	return fmt.Sprintf("review template not found by id: %s", e.ID)
+}
+
+// Is makes it a failure.NotFound.
+func (e *NoTemplateError) Is(target error) bool {
+	return target == failure.NotFound
+}
+
+type NoCustomFieldError struct {
+	ID uuid.UUID
+}
+
+func (e *NoCustomFieldError) Error() string {
+	return fmt.Sprintf("custom field not found by id: %s", e.ID)
+}
+
+// Is makes it a failure.NotFound.
+func (e *NoCustomFieldError) Is(target error) bool {
+	return target == failure.NotFound
+}
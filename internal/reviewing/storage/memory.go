@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
 	"github.com/gaqzi/incident-reviewer/internal/reviewing"
 )
 
@@ -20,26 +21,33 @@ func NewMemoryStore() *MemoryStore {
 	}
 }
 
-func (s *MemoryStore) Save(_ context.Context, inc reviewing.Review) (reviewing.Review, error) {
+// Save stores the review, only reviews belonging to the current team can be saved, see tenant.Owns.
+func (s *MemoryStore) Save(ctx context.Context, inc reviewing.Review) (reviewing.Review, error) {
 	if inc.ID == uuid.Nil {
 		return reviewing.Review{}, ErrNoID
 	}
+	if !tenant.Owns(ctx, inc.TeamID) {
+		return reviewing.Review{}, ErrOtherTeam
+	}
+	if stored, ok := s.data[inc.ID]; ok && !tenant.Owns(ctx, stored.TeamID) {
+		return reviewing.Review{}, &NoReviewError{ID: inc.ID}
+	}
 
 	s.data[inc.ID] = inc
 
 	return inc, nil
 }
 
-func (s *MemoryStore) Get(_ context.Context, id uuid.UUID) (reviewing.Review, error) {
+func (s *MemoryStore) Get(ctx context.Context, id uuid.UUID) (reviewing.Review, error) {
 	review, ok := s.data[id]
-	if !ok {
+	if !ok || !tenant.Owns(ctx, review.TeamID) {
 		return reviewing.Review{}, &NoReviewError{ID: id}
 	}
 
 	return review, nil
 }
 
-func (s *MemoryStore) All(_ context.Context) ([]reviewing.Review, error) {
+func (s *MemoryStore) All(ctx context.Context) ([]reviewing.Review, error) {
 	ret := make([]reviewing.Review, 0, len(s.data))
 
 	// Sort all the keys for the store, which returns keys in a non-deterministic order,
@@ -59,7 +67,9 @@ func (s *MemoryStore) All(_ context.Context) ([]reviewing.Review, error) {
 	slices.Reverse(keys)
 
 	for _, r := range keys {
-		ret = append(ret, s.data[r])
+		if tenant.Owns(ctx, s.data[r].TeamID) {
+			ret = append(ret, s.data[r])
+		}
 	}
 
 	return ret, nil
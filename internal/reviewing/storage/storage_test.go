@@ -7,6 +7,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
 	"github.com/gaqzi/incident-reviewer/internal/reviewing"
 	"github.com/gaqzi/incident-reviewer/internal/reviewing/storage"
 	"github.com/gaqzi/incident-reviewer/test/a"
@@ -31,7 +33,7 @@ func StorageTest(t *testing.T, ctx context.Context, storeFactory func() reviewin
 			require.ErrorIs(t, err, storage.ErrNoID)
 		})
 
-		t.Run("a review with an ID but nothing else set is stored", func(t *testing.T) {
+		t.Run("a new review with only an ID and the draft state set is stored", func(t *testing.T) {
 			review := reviewing.NewReview()
 			store := storeFactory()
 
@@ -41,7 +43,8 @@ func StorageTest(t *testing.T, ctx context.Context, storeFactory func() reviewin
 			require.Equal(
 				t,
 				reviewing.Review{
-					ID: actual.ID,
+					ID:    actual.ID,
+					State: reviewing.StateDraft,
 				},
 				actual,
 				"expected to not have modified the review and saved it",
@@ -58,6 +61,7 @@ func StorageTest(t *testing.T, ctx context.Context, storeFactory func() reviewin
 
 			var actualErr *storage.NoReviewError
 			require.ErrorAs(t, err, &actualErr, "expected the specific error for not found")
+			require.ErrorIs(t, err, failure.NotFound, "expected it to be a not found failure")
 		})
 
 		t.Run("after saving, gets back the same object as save when asking by ID", func(t *testing.T) {
@@ -129,4 +133,147 @@ func StorageTest(t *testing.T, ctx context.Context, storeFactory func() reviewin
 			)
 		})
 	})
+	t.Run("only sees the reviews of the current team", func(t *testing.T) {
+		store := storeFactory()
+		team, other := a.UUID(), a.UUID()
+		teamCtx := tenant.With(ctx, team)
+		ours, err := store.Save(teamCtx, a.Review().WithID(a.UUID()).Modify(func(r *reviewing.Review) { r.TeamID = team }).Build())
+		require.NoError(t, err)
+		theirs, err := store.Save(tenant.With(ctx, other), a.Review().WithID(a.UUID()).Modify(func(r *reviewing.Review) { r.TeamID = other }).Build())
+		require.NoError(t, err)
+
+		actual, err := store.All(teamCtx)
+		require.NoError(t, err)
+		require.Equal(t, []reviewing.Review{ours}, actual)
+
+		_, err = store.Get(teamCtx, theirs.ID)
+		var notFound *storage.NoReviewError
+		require.ErrorAs(t, err, &notFound, "expected another team's review to not be found")
+
+		_, err = store.Save(teamCtx, theirs)
+		require.ErrorIs(t, err, storage.ErrOtherTeam, "expected to not be able to save another team's review")
+
+		_, err = store.Save(teamCtx, a.Review().WithID(theirs.ID).Modify(func(r *reviewing.Review) { r.TeamID = team }).Build())
+		require.ErrorAs(t, err, &notFound, "expected to not be able to take over another team's review")
+
+		all, err := store.All(tenant.Unscoped(teamCtx))
+		require.NoError(t, err)
+		require.Len(t, all, 2, "expected unscoped to see every team's reviews")
+	})
+}
+
+func TestTemplateMemoryStore(t *testing.T) {
+	TemplateStorageTest(t, context.Background(), func() reviewing.TemplateStorage { return storage.NewTemplateMemoryStore() })
+}
+
+// TemplateStorageTest is the base suite for the implementations of reviewing.TemplateStorage.
+func TemplateStorageTest(t *testing.T, ctx context.Context, storeFactory func() reviewing.TemplateStorage) {
+	t.Run("Save returns an error when the ID isn't set", func(t *testing.T) {
+		store := storeFactory()
+
+		_, err := store.Save(ctx, reviewing.Template{})
+
+		require.ErrorIs(t, err, storage.ErrNoID)
+	})
+
+	t.Run("Get returns a not found error when it's not stored", func(t *testing.T) {
+		store := storeFactory()
+
+		_, err := store.Get(ctx, a.UUID())
+
+		var actualErr *storage.NoTemplateError
+		require.ErrorAs(t, err, &actualErr)
+		require.ErrorIs(t, err, failure.NotFound)
+	})
+
+	t.Run("Get returns what was saved", func(t *testing.T) {
+		store := storeFactory()
+		expected, err := store.Save(ctx, a.Template().Build())
+		require.NoError(t, err)
+
+		actual, err := store.Get(ctx, expected.ID)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("All returns them sorted by name", func(t *testing.T) {
+		store := storeFactory()
+		security, err := store.Save(ctx, a.Template().WithName("Security").Build())
+		require.NoError(t, err)
+		availability, err := store.Save(ctx, a.Template().WithID(a.UUID()).WithName("Availability").Build())
+		require.NoError(t, err)
+
+		actual, err := store.All(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, []reviewing.Template{availability, security}, actual)
+	})
+
+	t.Run("another team's templates are neither listed nor found", func(t *testing.T) {
+		store := storeFactory()
+		ours, err := store.Save(ctx, a.Template().Build())
+		require.NoError(t, err)
+		team := a.UUID()
+		theirs := a.Template().WithID(a.UUID()).Build()
+		theirs.TeamID = team
+		_, err = store.Save(tenant.With(ctx, team), theirs)
+		require.NoError(t, err)
+
+		actual, err := store.All(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, []reviewing.Template{ours}, actual)
+		_, err = store.Get(ctx, theirs.ID)
+		require.ErrorIs(t, err, failure.NotFound, "expected another team's template to not be found")
+	})
+}
+
+func TestCustomFieldMemoryStore(t *testing.T) {
+	CustomFieldStorageTest(t, context.Background(), func() reviewing.CustomFieldStorage { return storage.NewCustomFieldMemoryStore() })
+}
+
+// CustomFieldStorageTest is the base suite for the implementations of reviewing.CustomFieldStorage.
+func CustomFieldStorageTest(t *testing.T, ctx context.Context, storeFactory func() reviewing.CustomFieldStorage) {
+	t.Run("Save returns an error when the ID isn't set", func(t *testing.T) {
+		store := storeFactory()
+
+		_, err := store.Save(ctx, reviewing.CustomField{})
+
+		require.ErrorIs(t, err, storage.ErrNoID)
+	})
+
+	t.Run("Get returns a not found error when it's not stored", func(t *testing.T) {
+		store := storeFactory()
+
+		_, err := store.Get(ctx, a.UUID())
+
+		var actualErr *storage.NoCustomFieldError
+		require.ErrorAs(t, err, &actualErr)
+		require.ErrorIs(t, err, failure.NotFound)
+	})
+
+	t.Run("All returns them in the order they were created, only for the current team", func(t *testing.T) {
+		store := storeFactory()
+		team := a.UUID()
+		first := reviewing.NewCustomField()
+		first.Name = "Region"
+		second := reviewing.NewCustomField()
+		second.Name = "Customer tier affected"
+		_, err := store.Save(ctx, second)
+		require.NoError(t, err)
+		_, err = store.Save(ctx, first)
+		require.NoError(t, err)
+		theirs := reviewing.NewCustomField()
+		theirs.TeamID = team
+		_, err = store.Save(tenant.With(ctx, team), theirs)
+		require.NoError(t, err)
+
+		actual, err := store.All(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, []reviewing.CustomField{first, second}, actual)
+		_, err = store.Get(ctx, theirs.ID)
+		require.ErrorIs(t, err, failure.NotFound, "expected another team's field to not be found")
+	})
 }
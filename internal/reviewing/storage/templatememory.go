@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+type TemplateMemoryStore struct {
+	data map[uuid.UUID]reviewing.Template
+}
+
+func NewTemplateMemoryStore() *TemplateMemoryStore {
+	return &TemplateMemoryStore{
+		data: make(map[uuid.UUID]reviewing.Template),
+	}
+}
+
+// Save stores the template, only templates belonging to the current team can be saved, see tenant.Owns.
+func (s *TemplateMemoryStore) Save(ctx context.Context, t reviewing.Template) (reviewing.Template, error) {
+	if t.ID == uuid.Nil {
+		return reviewing.Template{}, ErrNoID
+	}
+	if !tenant.Owns(ctx, t.TeamID) {
+		return reviewing.Template{}, ErrOtherTeam
+	}
+	if stored, ok := s.data[t.ID]; ok && !tenant.Owns(ctx, stored.TeamID) {
+		return reviewing.Template{}, &NoTemplateError{ID: t.ID}
+	}
+
+	s.data[t.ID] = t
+
+	return t, nil
+}
+
+func (s *TemplateMemoryStore) Get(ctx context.Context, id uuid.UUID) (reviewing.Template, error) {
+	t, ok := s.data[id]
+	if !ok || !tenant.Owns(ctx, t.TeamID) {
+		return reviewing.Template{}, &NoTemplateError{ID: id}
+	}
+
+	return t, nil
+}
+
+func (s *TemplateMemoryStore) All(ctx context.Context) ([]reviewing.Template, error) {
+	ret := make([]reviewing.Template, 0, len(s.data))
+	for _, t := range s.data {
+		if tenant.Owns(ctx, t.TeamID) {
+			ret = append(ret, t)
+		}
+	}
+
+	slices.SortFunc(ret, func(a, b reviewing.Template) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return ret, nil
+}
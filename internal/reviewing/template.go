@@ -0,0 +1,135 @@
+package reviewing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
+)
+
+// Template is what a review of a kind of incident starts from, like security, data or availability incidents,
+// since they each need different prompts for what to look into.
+type Template struct {
+	ID uuid.UUID `validate:"required"`
+	// TeamID is the team the template is offered to, it's set to the team being worked as when it's created.
+	TeamID uuid.UUID
+	Name   string `validate:"required"`
+	// Guidance is copied onto the reviews created from the template, as the prompts for what to look into.
+	Guidance string
+	// Fields have to be answered on the reviews created from the template.
+	Fields []TemplateField `validate:"dive"`
+	// SuggestedCauseIDs and SuggestedTriggerIDs are offered first when binding on the reviews created from the template.
+	SuggestedCauseIDs   []uuid.UUID
+	SuggestedTriggerIDs []uuid.UUID
+
+	CreatedBy uuid.UUID
+	UpdatedBy uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TemplateField is an extra question the reviews created from the template answer.
+type TemplateField struct {
+	Name   string `validate:"required"`
+	Prompt string
+}
+
+// Answer is what a review answered for one of the fields of the template it was created from.
+type Answer struct {
+	Field  string
+	Prompt string
+	Text   string `validate:"required"`
+}
+
+func NewTemplate() Template {
+	return Template{ID: uuid.Must(uuid.NewV7())}
+}
+
+// Apply has the review be created from the template, the answers it already has are kept for the fields with the same
+// name. The guidance and fields are copied so changing the template afterward doesn't change the review.
+func (t Template) Apply(r Review) Review {
+	r.TemplateID = t.ID
+	r.Guidance = t.Guidance
+
+	answers := make([]Answer, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		answers = append(answers, Answer{Field: f.Name, Prompt: f.Prompt, Text: r.Answer(f.Name)})
+	}
+	r.Answers = answers
+
+	return r
+}
+
+// updateChangedBy records who is saving, and if it's the first save who created it.
+func (t Template) updateChangedBy(by uuid.UUID) Template {
+	if t.CreatedAt.IsZero() {
+		t.CreatedBy = by
+	}
+	t.UpdatedBy = by
+
+	return t
+}
+
+func (t Template) updateTimestamps() Template {
+	now := time.Now()
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = now
+	}
+	t.UpdatedAt = now
+
+	return t
+}
+
+type TemplateService struct {
+	store TemplateStorage
+}
+
+func NewTemplateService(store TemplateStorage) *TemplateService {
+	return &TemplateService{store: store}
+}
+
+// Save validates and stores the template, only admins can manage templates.
+func (s *TemplateService) Save(ctx context.Context, t Template) (Template, error) {
+	if err := actor.Require(ctx, actor.Admin); err != nil {
+		return t, fmt.Errorf("only admins can manage review templates: %w", err)
+	}
+	if t.CreatedAt.IsZero() {
+		t.TeamID = tenant.ID(ctx)
+	}
+
+	if err := validate.Struct(ctx, t); err != nil {
+		return t, fmt.Errorf("failed to validate review template: %w", err)
+	}
+
+	t = t.updateChangedBy(actor.ID(ctx)).updateTimestamps()
+
+	t, err := s.store.Save(ctx, t)
+	if err != nil {
+		return t, fmt.Errorf("failed to store review template: %w", err)
+	}
+
+	return t, nil
+}
+
+func (s *TemplateService) Get(ctx context.Context, id uuid.UUID) (Template, error) {
+	t, err := s.store.Get(ctx, id)
+	if err != nil {
+		return Template{}, fmt.Errorf("failed to get review template: %w", err)
+	}
+
+	return t, nil
+}
+
+func (s *TemplateService) All(ctx context.Context) ([]Template, error) {
+	ret, err := s.store.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all review templates: %w", err)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,103 @@
+package reviewing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing/storage"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestTemplate_Apply(t *testing.T) {
+	t.Run("copies the guidance and asks every field of the template", func(t *testing.T) {
+		tpl := a.Template().WithFields(
+			reviewing.TemplateField{Name: "Data exposed", Prompt: "What data could have been accessed?"},
+			reviewing.TemplateField{Name: "Detection", Prompt: "How was it found?"},
+		).Build()
+
+		review := tpl.Apply(a.Review().Build())
+
+		require.Equal(t, tpl.ID, review.TemplateID)
+		require.Equal(t, tpl.Guidance, review.Guidance)
+		require.Equal(
+			t,
+			[]reviewing.Answer{
+				{Field: "Data exposed", Prompt: "What data could have been accessed?"},
+				{Field: "Detection", Prompt: "How was it found?"},
+			},
+			review.Answers,
+		)
+	})
+
+	t.Run("keeps what the review already answered for the fields", func(t *testing.T) {
+		tpl := a.Template().Build()
+		review := a.Review().Modify(func(r *reviewing.Review) {
+			r.Answers = []reviewing.Answer{{Field: "Data exposed", Text: "Email addresses"}, {Field: "Not asked", Text: "Dropped"}}
+		}).Build()
+
+		review = tpl.Apply(review)
+
+		require.Equal(t, []reviewing.Answer{{Field: "Data exposed", Prompt: tpl.Fields[0].Prompt, Text: "Email addresses"}}, review.Answers)
+	})
+}
+
+func TestReview_Update_answers(t *testing.T) {
+	review := a.Template().Build().Apply(a.Review().Build())
+	update := a.Review().Modify(func(r *reviewing.Review) {
+		r.Answers = []reviewing.Answer{{Field: "Data exposed", Text: "Email addresses"}, {Field: "Not asked", Text: "Ignored"}}
+	}).Build()
+
+	updated := review.Update(update)
+
+	require.Equal(t, "Email addresses", updated.Answer("Data exposed"))
+	require.Len(t, updated.Answers, 1, "expected only the fields of the template to be answered")
+	require.Empty(t, review.Answer("Data exposed"), "expected the original review to not be changed")
+	require.Equal(t, "Email addresses", updated.Update(a.Review().Build()).Answer("Data exposed"), "expected to keep the answers when updating without them")
+}
+
+func TestTemplateService_Save(t *testing.T) {
+	t.Run("only admins can manage templates", func(t *testing.T) {
+		service := reviewing.NewTemplateService(storage.NewTemplateMemoryStore())
+
+		_, err := service.Save(actor.With(context.Background(), a.Actor().Build()), a.Template().IsNotSaved().Build())
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
+	t.Run("a field needs a name", func(t *testing.T) {
+		service := reviewing.NewTemplateService(storage.NewTemplateMemoryStore())
+
+		_, err := service.Save(adminCtx, a.Template().IsNotSaved().WithFields(reviewing.TemplateField{Prompt: "What?"}).Build())
+
+		require.ErrorContains(t, err, "failed to validate review template:")
+	})
+
+	t.Run("records when and by whom it was created", func(t *testing.T) {
+		admin := a.Actor().WithRole(actor.RoleAdmin).Build()
+		service := reviewing.NewTemplateService(storage.NewTemplateMemoryStore())
+
+		tpl, err := service.Save(actor.With(context.Background(), admin), a.Template().IsNotSaved().Build())
+
+		require.NoError(t, err)
+		require.Equal(t, admin.ID, tpl.CreatedBy)
+		require.NotZero(t, tpl.CreatedAt)
+	})
+
+	t.Run("belongs to the team being worked as", func(t *testing.T) {
+		team := a.UUID()
+		service := reviewing.NewTemplateService(storage.NewTemplateMemoryStore())
+
+		saved, err := service.Save(tenant.With(adminCtx, team), a.Template().IsNotSaved().Build())
+
+		require.NoError(t, err)
+		require.Equal(t, team, saved.TeamID)
+		all, err := service.All(adminCtx)
+		require.NoError(t, err)
+		require.Empty(t, all, "expected another team to not see it")
+	})
+}
@@ -0,0 +1,72 @@
+package reviewing
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+
+	// The other kinds of images attached that thumbnails can be made of
+	_ "image/gif"
+	_ "image/jpeg"
+)
+
+const (
+	// thumbnailSize is the most pixels a thumbnail is wide or high.
+	thumbnailSize = 256
+	// maxThumbnailPixels skips images so large decoding them would take a lot of memory.
+	maxThumbnailPixels = 25_000_000
+)
+
+// thumbnail scales the image down to fit within thumbnailSize as a PNG,
+// it's false for images that can't be decoded, webp among them, or are too large to.
+func thumbnail(data []byte) ([]byte, bool) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxThumbnailPixels {
+		return nil, false
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, scaleDown(src, thumbnailSize)); err != nil {
+		return nil, false
+	}
+
+	return buf.Bytes(), true
+}
+
+// scaleDown keeps the proportions of the image, every pixel is the average of the pixels it covers.
+// Images already smaller than size are kept as they are.
+func scaleDown(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return src
+	}
+	dw, dh := size, max(1, h*size/w)
+	if h > w {
+		dw, dh = max(1, w*size/h), size
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := b.Min.Y+y*h/dh, b.Min.Y+max((y+1)*h/dh, y*h/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := b.Min.X+x*w/dw, b.Min.X+max((x+1)*w/dw, x*w/dw+1)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a, n = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,121 @@
+package reviewing
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Use is a catalog entry bound on a review, with why it applies to that incident.
+type Use struct {
+	ReviewID    uuid.UUID
+	ReviewTitle string
+	Why         string
+	// IsProximalCause is only ever set for contributing causes.
+	IsProximalCause bool
+	BoundAt         time.Time
+}
+
+// Usage is how a catalog entry has been used on the reviews, it's what tells if the entry is well-defined or if
+// it's used for too many different things and needs splitting.
+type Usage struct {
+	// Uses are ordered with the first bound first.
+	Uses []Use
+}
+
+// Reviews is how many reviews it's bound on, it's bound more than once on a review when there's more than one why.
+func (u Usage) Reviews() int {
+	seen := make(map[uuid.UUID]bool, len(u.Uses))
+	for _, use := range u.Uses {
+		seen[use.ReviewID] = true
+	}
+
+	return len(seen)
+}
+
+// FirstUsed is when it was first bound, it's zero when it's never been used.
+func (u Usage) FirstUsed() time.Time {
+	if len(u.Uses) == 0 {
+		return time.Time{}
+	}
+
+	return u.Uses[0].BoundAt
+}
+
+// LastUsed is when it was most recently bound, it's zero when it's never been used.
+func (u Usage) LastUsed() time.Time {
+	if len(u.Uses) == 0 {
+		return time.Time{}
+	}
+
+	return u.Uses[len(u.Uses)-1].BoundAt
+}
+
+// Proximal is how many of the uses it was the proximal cause.
+func (u Usage) Proximal() int {
+	var n int
+	for _, use := range u.Uses {
+		if use.IsProximalCause {
+			n++
+		}
+	}
+
+	return n
+}
+
+func newUsage(uses []Use) Usage {
+	slices.SortStableFunc(uses, func(a, b Use) int { return a.BoundAt.Compare(b.BoundAt) })
+
+	return Usage{Uses: uses}
+}
+
+// CauseUsage is how the contributing cause has been used on the reviews.
+func CauseUsage(reviews []Review, causeID uuid.UUID) Usage {
+	var uses []Use
+	for _, r := range reviews {
+		for _, bc := range r.BoundCauses {
+			if bc.Cause.ID == causeID {
+				uses = append(uses, Use{ReviewID: r.ID, ReviewTitle: r.Title, Why: bc.Why, IsProximalCause: bc.IsProximalCause, BoundAt: bc.BoundAt})
+			}
+		}
+	}
+
+	return newUsage(uses)
+}
+
+// TriggerUsage is how the trigger has been used on the reviews.
+func TriggerUsage(reviews []Review, triggerID uuid.UUID) Usage {
+	var uses []Use
+	for _, r := range reviews {
+		for _, bt := range r.BoundTriggers {
+			if bt.Trigger.ID == triggerID {
+				uses = append(uses, Use{ReviewID: r.ID, ReviewTitle: r.Title, Why: bt.Why, BoundAt: bt.BoundAt})
+			}
+		}
+	}
+
+	return newUsage(uses)
+}
+
+// CauseUsage is how the contributing cause has been used on the reviews of the current team.
+func (s *Service) CauseUsage(ctx context.Context, causeID uuid.UUID) (Usage, error) {
+	reviews, err := s.reviewStore.All(ctx)
+	if err != nil {
+		return Usage{}, fmt.Errorf("failed to get reviews for the usage of contributing cause: %w", err)
+	}
+
+	return CauseUsage(reviews, causeID), nil
+}
+
+// TriggerUsage is how the trigger has been used on the reviews of the current team.
+func (s *Service) TriggerUsage(ctx context.Context, triggerID uuid.UUID) (Usage, error) {
+	reviews, err := s.reviewStore.All(ctx)
+	if err != nil {
+		return Usage{}, fmt.Errorf("failed to get reviews for the usage of trigger: %w", err)
+	}
+
+	return TriggerUsage(reviews, triggerID), nil
+}
@@ -0,0 +1,96 @@
+package reviewing_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestCauseUsage(t *testing.T) {
+	t.Run("when the cause isn't bound anywhere there is no usage", func(t *testing.T) {
+		usage := reviewing.CauseUsage([]reviewing.Review{a.Review().WithContributingCause().Build()}, a.UUID())
+
+		require.Empty(t, usage.Uses)
+		require.Zero(t, usage.Reviews())
+		require.True(t, usage.FirstUsed().IsZero())
+		require.True(t, usage.LastUsed().IsZero())
+	})
+
+	t.Run("lists every why it's bound with on the reviews, first bound first", func(t *testing.T) {
+		cause := a.ContributingCause().Build()
+		first := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+		last := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+		latest := a.Review().WithID(a.UUID()).Modify(func(r *reviewing.Review) { r.Title = "Latest" }).WithContributingCause(
+			a.BoundCause().WithID(a.UUID()).WithCause(cause).WithWhy("Again").WithBoundAt(last).Build(),
+			a.BoundCause().WithID(a.UUID()).WithCause(cause).WithWhy("And again").WithIsProximalCause(true).WithBoundAt(last).Build(),
+			a.BoundCause().WithID(a.UUID()).WithCause(a.ContributingCause().WithID(a.UUID()).Build()).WithBoundAt(first).Build(),
+		).Build()
+		earliest := a.Review().WithID(a.UUID()).Modify(func(r *reviewing.Review) { r.Title = "Earliest" }).WithContributingCause(
+			a.BoundCause().WithCause(cause).WithWhy("First").WithBoundAt(first).Build(),
+		).Build()
+
+		usage := reviewing.CauseUsage([]reviewing.Review{latest, earliest}, cause.ID)
+
+		require.Equal(
+			t,
+			[]reviewing.Use{
+				{ReviewID: earliest.ID, ReviewTitle: "Earliest", Why: "First", BoundAt: first},
+				{ReviewID: latest.ID, ReviewTitle: "Latest", Why: "Again", BoundAt: last},
+				{ReviewID: latest.ID, ReviewTitle: "Latest", Why: "And again", IsProximalCause: true, BoundAt: last},
+			},
+			usage.Uses,
+		)
+		require.Equal(t, 2, usage.Reviews(), "expected a review to only count once when bound with more than one why")
+		require.Equal(t, first, usage.FirstUsed())
+		require.Equal(t, last, usage.LastUsed())
+		require.Equal(t, 1, usage.Proximal())
+	})
+}
+
+func TestTriggerUsage(t *testing.T) {
+	trigger := a.NormalizedTrigger().Build()
+	review := a.Review().
+		WithBoundTrigger(a.BoundTrigger().WithTrigger(trigger).WithWhy("A campaign").Build()).
+		WithBoundTrigger(a.BoundTrigger().WithID(a.UUID()).WithTrigger(a.NormalizedTrigger().WithID(a.UUID()).Build()).Build()).
+		Build()
+
+	usage := reviewing.TriggerUsage([]reviewing.Review{review}, trigger.ID)
+
+	require.Equal(
+		t,
+		[]reviewing.Use{{ReviewID: review.ID, ReviewTitle: review.Title, Why: "A campaign", BoundAt: review.BoundTriggers[0].BoundAt}},
+		usage.Uses,
+	)
+	require.Zero(t, usage.Proximal())
+}
+
+func TestService_CauseUsage(t *testing.T) {
+	t.Run("when the reviews can't be fetched it returns an error", func(t *testing.T) {
+		b := newService()
+		b.reviewStorage.On("All", mock.Anything).Return([]reviewing.Review(nil), errors.New("uh-oh"))
+		service := b.Build(t)
+
+		_, err := service.CauseUsage(adminCtx, a.UUID())
+
+		require.ErrorContains(t, err, "failed to get reviews for the usage of contributing cause: uh-oh")
+	})
+
+	t.Run("it's the usage on the reviews of the current team", func(t *testing.T) {
+		bc := a.BoundCause().Build()
+		review := a.Review().WithContributingCause(bc).Build()
+		b := newService()
+		b.reviewStorage.On("All", mock.Anything).Return([]reviewing.Review{review}, nil)
+		service := b.Build(t)
+
+		usage, err := service.CauseUsage(adminCtx, bc.Cause.ID)
+
+		require.NoError(t, err)
+		require.Equal(t, reviewing.CauseUsage([]reviewing.Review{review}, bc.Cause.ID), usage)
+	})
+}
@@ -0,0 +1,165 @@
+package reviewing
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+type VoteDirection string
+
+const (
+	VoteFor     VoteDirection = "for"
+	VoteAgainst VoteDirection = "against"
+)
+
+// Vote is one participant's stance on a BoundCause or BoundTrigger, with an optional reason for it.
+// Every participant has one vote on each bound item, voting again replaces it.
+type Vote struct {
+	ID uuid.UUID
+	// VoterID is the user who voted.
+	VoterID   uuid.UUID
+	Direction VoteDirection `validate:"required,oneof=for against"`
+	Reason    string
+
+	CreatedAt time.Time
+}
+
+// Votes are all the votes cast on a single bound item.
+type Votes []Vote
+
+// ReasonCount is a reason given for a vote and how many times it was given.
+type ReasonCount struct {
+	Reason string
+	Count  int
+}
+
+// Tally summarizes Votes so the group can see where it stands.
+type Tally struct {
+	For            int
+	Against        int
+	ReasonsFor     []ReasonCount
+	ReasonsAgainst []ReasonCount
+}
+
+// Consensus returns the direction everyone has voted in, or an empty direction if there are no votes or people disagree.
+func (t Tally) Consensus() VoteDirection {
+	switch {
+	case t.For > 0 && t.Against == 0:
+		return VoteFor
+	case t.Against > 0 && t.For == 0:
+		return VoteAgainst
+	default:
+		return ""
+	}
+}
+
+// Tally counts the votes by direction and groups the reasons given,
+// reasons are grouped case-insensitively and ignoring surrounding whitespace
+// and keeps the spelling of the first time it was given.
+func (vs Votes) Tally() Tally {
+	var t Tally
+
+	addReason := func(reasons []ReasonCount, reason string) []ReasonCount {
+		reason = strings.TrimSpace(reason)
+		if reason == "" {
+			return reasons
+		}
+
+		i := slices.IndexFunc(reasons, func(rc ReasonCount) bool { return strings.EqualFold(rc.Reason, reason) })
+		if i == -1 {
+			return append(reasons, ReasonCount{Reason: reason, Count: 1})
+		}
+		reasons[i].Count++
+
+		return reasons
+	}
+
+	for _, v := range vs {
+		switch v.Direction {
+		case VoteFor:
+			t.For++
+			t.ReasonsFor = addReason(t.ReasonsFor, v.Reason)
+		case VoteAgainst:
+			t.Against++
+			t.ReasonsAgainst = addReason(t.ReasonsAgainst, v.Reason)
+		}
+	}
+
+	return t
+}
+
+// prepareVote ensures the vote has a known direction, a voter, an ID, and a timestamp before it's recorded.
+func prepareVote(v Vote) (Vote, error) {
+	if v.Direction != VoteFor && v.Direction != VoteAgainst {
+		return v, failure.New(failure.Invalid, "unknown vote direction: "+string(v.Direction))
+	}
+	if v.VoterID == uuid.Nil {
+		return v, failure.New(failure.Invalid, "cannot vote without knowing who is voting")
+	}
+
+	if v.ID == uuid.Nil {
+		v.ID = uuid.Must(uuid.NewV7())
+	}
+	if v.CreatedAt.IsZero() {
+		v.CreatedAt = time.Now()
+	}
+
+	return v, nil
+}
+
+// cast replaces the voter's earlier vote with v, or adds it when they haven't voted yet.
+func (vs Votes) cast(v Vote) Votes {
+	ret := slices.DeleteFunc(slices.Clone(vs), func(o Vote) bool { return o.VoterID == v.VoterID })
+
+	return append(ret, v)
+}
+
+// VoteOnBoundContributingCause records the vote on the BoundCause with boundCauseID, replacing the voter's earlier vote.
+func (r Review) VoteOnBoundContributingCause(boundCauseID uuid.UUID, v Vote) (Review, error) {
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
+	i := slices.IndexFunc(r.BoundCauses, func(bc BoundCause) bool { return bc.ID == boundCauseID })
+	if i == -1 {
+		return r, failure.New(failure.NotFound, "cannot vote on contributing cause that isn't bound: "+boundCauseID.String())
+	}
+
+	v, err := prepareVote(v)
+	if err != nil {
+		return r, err
+	}
+
+	// Clone so we don't change the review we were called on through the shared backing array.
+	r.BoundCauses = slices.Clone(r.BoundCauses)
+	r.BoundCauses[i].Votes = r.BoundCauses[i].Votes.cast(v)
+
+	return r, nil
+}
+
+// VoteOnBoundTrigger records the vote on the BoundTrigger with boundTriggerID, replacing the voter's earlier vote.
+func (r Review) VoteOnBoundTrigger(boundTriggerID uuid.UUID, v Vote) (Review, error) {
+	if err := r.ensureEditable(); err != nil {
+		return r, err
+	}
+
+	i := slices.IndexFunc(r.BoundTriggers, func(bt BoundTrigger) bool { return bt.ID == boundTriggerID })
+	if i == -1 {
+		return r, failure.New(failure.NotFound, "cannot vote on trigger that isn't bound: "+boundTriggerID.String())
+	}
+
+	v, err := prepareVote(v)
+	if err != nil {
+		return r, err
+	}
+
+	r.BoundTriggers = slices.Clone(r.BoundTriggers)
+	r.BoundTriggers[i].Votes = r.BoundTriggers[i].Votes.cast(v)
+
+	return r, nil
+}
@@ -0,0 +1,138 @@
+package reviewing_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestVotes_Tally(t *testing.T) {
+	t.Run("with no votes everything is zero and there is no consensus", func(t *testing.T) {
+		actual := reviewing.Votes(nil).Tally()
+
+		require.Equal(t, reviewing.Tally{}, actual)
+		require.Empty(t, actual.Consensus())
+	})
+
+	t.Run("counts the votes per direction and groups the reasons given", func(t *testing.T) {
+		votes := reviewing.Votes{
+			a.Vote().WithReason("The graphs").Build(),
+			a.Vote().WithReason("  the GRAPHS \n").Build(),
+			a.Vote().WithReason("").Build(),
+			a.Vote().IsAgainst().WithReason("It was the DNS").Build(),
+		}
+
+		actual := votes.Tally()
+
+		require.Equal(
+			t,
+			reviewing.Tally{
+				For:            3,
+				Against:        1,
+				ReasonsFor:     []reviewing.ReasonCount{{Reason: "The graphs", Count: 2}},
+				ReasonsAgainst: []reviewing.ReasonCount{{Reason: "It was the DNS", Count: 1}},
+			},
+			actual,
+			"expected reasons to be grouped ignoring case and whitespace, and blank reasons to only be counted as votes",
+		)
+	})
+
+	for _, tc := range []struct {
+		name     string
+		votes    reviewing.Votes
+		expected reviewing.VoteDirection
+	}{
+		{"everyone for", reviewing.Votes{a.Vote().Build(), a.Vote().Build()}, reviewing.VoteFor},
+		{"everyone against", reviewing.Votes{a.Vote().IsAgainst().Build()}, reviewing.VoteAgainst},
+		{"split votes", reviewing.Votes{a.Vote().Build(), a.Vote().IsAgainst().Build()}, ""},
+	} {
+		t.Run("consensus with "+tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.votes.Tally().Consensus())
+		})
+	}
+}
+
+func TestReview_VoteOnBoundContributingCause(t *testing.T) {
+	t.Run("when the cause isn't bound it returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+
+		_, err := review.VoteOnBoundContributingCause(a.UUID(), a.Vote().Build())
+
+		require.ErrorContains(t, err, "cannot vote on contributing cause that isn't bound:")
+	})
+
+	t.Run("with an unknown direction it returns an error", func(t *testing.T) {
+		review := a.Review().WithContributingCause().Build()
+
+		_, err := review.VoteOnBoundContributingCause(
+			review.BoundCauses[0].ID,
+			reviewing.Vote{Direction: "sideways"},
+		)
+
+		require.ErrorContains(t, err, "unknown vote direction: sideways")
+	})
+
+	t.Run("adds the vote to the bound cause and sets an ID and timestamp when missing", func(t *testing.T) {
+		review := a.Review().WithContributingCause().Build()
+		vote := a.Vote().IsNotSaved().Build()
+
+		actual, err := review.VoteOnBoundContributingCause(review.BoundCauses[0].ID, vote)
+
+		require.NoError(t, err)
+		require.Len(t, actual.BoundCauses[0].Votes, 1)
+		recorded := actual.BoundCauses[0].Votes[0]
+		require.NotEqual(t, uuid.Nil, recorded.ID, "expected an ID to have been set")
+		require.False(t, recorded.CreatedAt.IsZero(), "expected the time of the vote to have been set")
+		require.Empty(t, review.BoundCauses[0].Votes, "expected the original review to not have been changed")
+	})
+
+	t.Run("without a voter it returns an error", func(t *testing.T) {
+		review := a.Review().WithContributingCause().Build()
+
+		_, err := review.VoteOnBoundContributingCause(review.BoundCauses[0].ID, a.Vote().WithVoter(uuid.Nil).Build())
+
+		require.ErrorIs(t, err, failure.Invalid)
+	})
+
+	t.Run("voting again replaces the voter's earlier vote", func(t *testing.T) {
+		voter, other := a.UUID(), a.UUID()
+		review := a.Review().WithContributingCause().Build()
+		boundCauseID := review.BoundCauses[0].ID
+
+		review, err := review.VoteOnBoundContributingCause(boundCauseID, a.Vote().IsNotSaved().WithVoter(voter).Build())
+		require.NoError(t, err)
+		review, err = review.VoteOnBoundContributingCause(boundCauseID, a.Vote().IsNotSaved().WithVoter(other).Build())
+		require.NoError(t, err)
+		review, err = review.VoteOnBoundContributingCause(boundCauseID, a.Vote().IsNotSaved().WithVoter(voter).IsAgainst().Build())
+		require.NoError(t, err)
+
+		tally := review.BoundCauses[0].Votes.Tally()
+		require.Equal(t, 1, tally.For)
+		require.Equal(t, 1, tally.Against, "expected the first vote to have been replaced")
+	})
+}
+
+func TestReview_VoteOnBoundTrigger(t *testing.T) {
+	t.Run("when the trigger isn't bound it returns an error", func(t *testing.T) {
+		review := a.Review().Build()
+
+		_, err := review.VoteOnBoundTrigger(a.UUID(), a.Vote().Build())
+
+		require.ErrorContains(t, err, "cannot vote on trigger that isn't bound:")
+	})
+
+	t.Run("adds the vote to the bound trigger", func(t *testing.T) {
+		review := a.Review().WithBoundTrigger(a.BoundTrigger().Build()).Build()
+		vote := a.Vote().IsAgainst().Build()
+
+		actual, err := review.VoteOnBoundTrigger(review.BoundTriggers[0].ID, vote)
+
+		require.NoError(t, err)
+		require.Equal(t, reviewing.Votes{vote}, actual.BoundTriggers[0].Votes)
+	})
+}
@@ -0,0 +1,17 @@
+package teams
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Storage interface {
+	Save(ctx context.Context, team Team) (Team, error)
+
+	// Get finds the team or returns an error when it doesn't exist.
+	Get(ctx context.Context, id uuid.UUID) (Team, error)
+
+	// All returns all the teams sorted by name.
+	All(ctx context.Context) ([]Team, error)
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+type NoTeamError struct {
+	ID uuid.UUID
+}
+
+func (e *NoTeamError) Error() string {
+	return fmt.Sprintf("team not found by id: %s", e.ID)
+}
+
+// Is makes it a failure.NotFound.
+func (e *NoTeamError) Is(target error) bool {
+	return target == failure.NotFound
+}
+
+// ErrNoID indicates that the passed in ID is blank/uninitialized.
+var ErrNoID = errors.New("can't store team because ID is not set")
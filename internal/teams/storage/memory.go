@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/teams"
+)
+
+type MemoryStore struct {
+	mu   sync.RWMutex
+	data map[uuid.UUID]teams.Team
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		data: make(map[uuid.UUID]teams.Team),
+	}
+}
+
+func (s *MemoryStore) Save(_ context.Context, t teams.Team) (teams.Team, error) {
+	if t.ID == uuid.Nil {
+		return teams.Team{}, ErrNoID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[t.ID] = t
+
+	return t, nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, id uuid.UUID) (teams.Team, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.data[id]
+	if !ok {
+		return teams.Team{}, &NoTeamError{ID: id}
+	}
+
+	return t, nil
+}
+
+func (s *MemoryStore) All(_ context.Context) ([]teams.Team, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.SortedFunc(maps.Values(s.data), func(a, b teams.Team) int {
+		return cmp.Or(
+			strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)),
+			strings.Compare(a.ID.String(), b.ID.String()),
+		)
+	}), nil
+}
@@ -0,0 +1,62 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/teams"
+	"github.com/gaqzi/incident-reviewer/internal/teams/storage"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestMemoryStore(t *testing.T) {
+	StorageTest(t, context.Background(), func() teams.Storage { return storage.NewMemoryStore() })
+}
+
+// StorageTest is a base suite used to test across the implementations of teams.Storage.
+func StorageTest(t *testing.T, ctx context.Context, storeFactory func() teams.Storage) {
+	t.Run("Save", func(t *testing.T) {
+		t.Run("returns an error when the ID isn't set", func(t *testing.T) {
+			_, err := storeFactory().Save(ctx, teams.Team{})
+
+			require.ErrorIs(t, err, storage.ErrNoID)
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("returns an error when the team doesn't exist", func(t *testing.T) {
+			_, err := storeFactory().Get(ctx, a.UUID())
+
+			var actualErr *storage.NoTeamError
+			require.ErrorAs(t, err, &actualErr)
+			require.ErrorIs(t, err, failure.NotFound, "expected it to be a not found failure")
+		})
+
+		t.Run("after saving, gets back the same team", func(t *testing.T) {
+			store := storeFactory()
+			expected, err := store.Save(ctx, a.Team().Build())
+			require.NoError(t, err)
+
+			actual, err := store.Get(ctx, expected.ID)
+
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		})
+	})
+
+	t.Run("All returns the teams sorted by name", func(t *testing.T) {
+		store := storeFactory()
+		sre, err := store.Save(ctx, a.Team().WithID(a.UUID()).WithName("sre").Build())
+		require.NoError(t, err)
+		payments, err := store.Save(ctx, a.Team().WithID(a.UUID()).WithName("Payments").Build())
+		require.NoError(t, err)
+
+		actual, err := store.All(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, []teams.Team{payments, sre}, actual)
+	})
+}
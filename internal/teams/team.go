@@ -0,0 +1,157 @@
+// Package teams lets several teams share one deployment, each with its own reviews and part of the catalogs.
+package teams
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
+)
+
+type Team struct {
+	ID      uuid.UUID `validate:"required"`
+	Name    string    `validate:"required"`
+	Members []uuid.UUID
+
+	CreatedBy uuid.UUID
+	UpdatedBy uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewTeam() Team {
+	return Team{ID: uuid.Must(uuid.NewV7())}
+}
+
+// HasMember is true when the user is part of the team.
+func (t Team) HasMember(userID uuid.UUID) bool {
+	return slices.Contains(t.Members, userID)
+}
+
+// AddMember makes the user part of the team, it's not an error if they already are.
+func (t Team) AddMember(userID uuid.UUID) (Team, error) {
+	if userID == uuid.Nil {
+		return t, failure.New(failure.Invalid, "can't add a member without an id")
+	}
+	if t.HasMember(userID) {
+		return t, nil
+	}
+
+	t.Members = append(slices.Clone(t.Members), userID)
+
+	return t, nil
+}
+
+// RemoveMember takes the user off the team, it's not an error if they weren't part of it.
+func (t Team) RemoveMember(userID uuid.UUID) Team {
+	t.Members = slices.DeleteFunc(slices.Clone(t.Members), func(m uuid.UUID) bool { return m == userID })
+
+	return t
+}
+
+// updateChangedBy records who is saving, and if it's the first save who created it.
+func (t Team) updateChangedBy(by uuid.UUID) Team {
+	if t.CreatedAt.IsZero() {
+		t.CreatedBy = by
+	}
+	t.UpdatedBy = by
+
+	return t
+}
+
+func (t Team) updateTimestamps() Team {
+	now := time.Now()
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = now
+	}
+	t.UpdatedAt = now
+
+	return t
+}
+
+type Service struct {
+	store Storage
+}
+
+func NewService(store Storage) *Service {
+	return &Service{store: store}
+}
+
+// Save validates and stores the team, only admins can manage teams.
+func (s *Service) Save(ctx context.Context, t Team) (Team, error) {
+	if err := actor.Require(ctx, actor.Admin); err != nil {
+		return t, fmt.Errorf("only admins can manage teams: %w", err)
+	}
+
+	if err := validate.Struct(ctx, t); err != nil {
+		return t, fmt.Errorf("failed to validate team: %w", err)
+	}
+
+	t = t.updateChangedBy(actor.ID(ctx)).updateTimestamps()
+
+	t, err := s.store.Save(ctx, t)
+	if err != nil {
+		return t, fmt.Errorf("failed to store team: %w", err)
+	}
+
+	return t, nil
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (Team, error) {
+	t, err := s.store.Get(ctx, id)
+	if err != nil {
+		return Team{}, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	return t, nil
+}
+
+func (s *Service) All(ctx context.Context) ([]Team, error) {
+	ret, err := s.store.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all teams: %w", err)
+	}
+
+	return ret, nil
+}
+
+// ForMember returns the teams the user is part of.
+func (s *Service) ForMember(ctx context.Context, userID uuid.UUID) ([]Team, error) {
+	all, err := s.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(all, func(t Team) bool { return !t.HasMember(userID) }), nil
+}
+
+// AddMember makes the user part of the team.
+func (s *Service) AddMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID) (Team, error) {
+	t, err := s.Get(ctx, teamID)
+	if err != nil {
+		return Team{}, err
+	}
+
+	t, err = t.AddMember(userID)
+	if err != nil {
+		return Team{}, fmt.Errorf("failed to add member: %w", err)
+	}
+
+	return s.Save(ctx, t)
+}
+
+// RemoveMember takes the user off the team.
+func (s *Service) RemoveMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID) (Team, error) {
+	t, err := s.Get(ctx, teamID)
+	if err != nil {
+		return Team{}, err
+	}
+
+	return s.Save(ctx, t.RemoveMember(userID))
+}
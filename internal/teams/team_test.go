@@ -0,0 +1,79 @@
+package teams_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/teams"
+	"github.com/gaqzi/incident-reviewer/internal/teams/storage"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+var adminCtx = actor.With(context.Background(), a.Actor().WithRole(actor.RoleAdmin).Build())
+
+func TestService_Save(t *testing.T) {
+	t.Run("only admins can manage teams", func(t *testing.T) {
+		service := teams.NewService(storage.NewMemoryStore())
+
+		_, err := service.Save(actor.With(context.Background(), a.Actor().Build()), a.Team().IsNotSaved().Build())
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
+	t.Run("a team needs a name", func(t *testing.T) {
+		service := teams.NewService(storage.NewMemoryStore())
+
+		_, err := service.Save(adminCtx, a.Team().IsNotSaved().WithName("").Build())
+
+		require.ErrorContains(t, err, "failed to validate team:")
+	})
+
+	t.Run("records when and by whom it was created", func(t *testing.T) {
+		admin := a.Actor().WithRole(actor.RoleAdmin).Build()
+		service := teams.NewService(storage.NewMemoryStore())
+
+		team, err := service.Save(actor.With(context.Background(), admin), a.Team().IsNotSaved().Build())
+
+		require.NoError(t, err)
+		require.Equal(t, admin.ID, team.CreatedBy)
+		require.NotZero(t, team.CreatedAt)
+	})
+}
+
+func TestService_ForMember(t *testing.T) {
+	member, other := a.UUID(), a.UUID()
+	service := teams.NewService(storage.NewMemoryStore())
+	sre, err := service.Save(adminCtx, a.Team().IsNotSaved().WithID(a.UUID()).WithName("SRE").WithMember(member).Build())
+	require.NoError(t, err)
+	_, err = service.Save(adminCtx, a.Team().IsNotSaved().WithID(a.UUID()).WithName("Payments").WithMember(other).Build())
+	require.NoError(t, err)
+
+	actual, err := service.ForMember(context.Background(), member)
+
+	require.NoError(t, err)
+	require.Equal(t, []teams.Team{sre}, actual, "expected only the teams the user is part of")
+}
+
+func TestService_members(t *testing.T) {
+	member := a.UUID()
+	service := teams.NewService(storage.NewMemoryStore())
+	team, err := service.Save(adminCtx, a.Team().IsNotSaved().Build())
+	require.NoError(t, err)
+
+	team, err = service.AddMember(adminCtx, team.ID, member)
+	require.NoError(t, err)
+	team, err = service.AddMember(adminCtx, team.ID, member)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{member}, team.Members, "expected adding someone twice to only add them once")
+
+	team, err = service.RemoveMember(adminCtx, team.ID, member)
+	require.NoError(t, err)
+	require.Empty(t, team.Members)
+
+	_, err = service.AddMember(actor.With(context.Background(), a.Actor().Build()), team.ID, member)
+	require.ErrorIs(t, err, actor.ErrForbidden, "expected only admins to change who is part of a team")
+}
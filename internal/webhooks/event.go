@@ -0,0 +1,159 @@
+// Package webhooks sends what happens to reviews and the catalogs to other systems as signed JSON.
+package webhooks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	"github.com/gaqzi/incident-reviewer/internal/platform/markdown"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+type EventType string
+
+const (
+	EventReviewCreated EventType = "review.created"
+	EventReviewUpdated EventType = "review.updated"
+	// EventReviewTransitioned is sent for every change of state except for publishing, which is EventReviewPublished.
+	EventReviewTransitioned EventType = "review.transitioned"
+	EventReviewPublished    EventType = "review.published"
+	EventReviewCauseBound   EventType = "review.cause_bound"
+	EventReviewTriggerBound EventType = "review.trigger_bound"
+	EventCauseChanged       EventType = "contributing_cause.changed"
+	EventTriggerChanged     EventType = "trigger.changed"
+)
+
+var EventTypes = []EventType{
+	EventReviewCreated,
+	EventReviewUpdated,
+	EventReviewTransitioned,
+	EventReviewPublished,
+	EventReviewCauseBound,
+	EventReviewTriggerBound,
+	EventCauseChanged,
+	EventTriggerChanged,
+}
+
+// IsCatalog is true for the events about the catalogs, which can be shared by all teams.
+func (t EventType) IsCatalog() bool {
+	return t == EventCauseChanged || t == EventTriggerChanged
+}
+
+// Event is the body of every webhook request.
+type Event struct {
+	ID         uuid.UUID `json:"id"`
+	Type       EventType `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Data       any       `json:"data"`
+
+	// TeamID is the team the event is about, uuid.Nil for no team or what's shared by all teams.
+	TeamID uuid.UUID `json:"-"`
+}
+
+// ReviewData is the review an event is about, get it from the API for everything else.
+type ReviewData struct {
+	ID     uuid.UUID       `json:"id"`
+	Title  string          `json:"title"`
+	URL    string          `json:"url"`
+	State  reviewing.State `json:"state"`
+	TeamID *uuid.UUID      `json:"teamID,omitempty"`
+}
+
+type TransitionData struct {
+	Review ReviewData      `json:"review"`
+	From   reviewing.State `json:"from"`
+}
+
+type BoundCauseData struct {
+	Review     ReviewData `json:"review"`
+	BoundCause struct {
+		ID              uuid.UUID `json:"id"`
+		CauseID         uuid.UUID `json:"contributingCauseID"`
+		Name            string    `json:"name"`
+		Why             string    `json:"why"`
+		WhyText         string    `json:"whyText"`
+		IsProximalCause bool      `json:"isProximalCause"`
+	} `json:"boundCause"`
+}
+
+type BoundTriggerData struct {
+	Review       ReviewData `json:"review"`
+	BoundTrigger struct {
+		ID        uuid.UUID `json:"id"`
+		TriggerID uuid.UUID `json:"triggerID"`
+		Name      string    `json:"name"`
+		Why       string    `json:"why"`
+		WhyText   string    `json:"whyText"`
+	} `json:"boundTrigger"`
+}
+
+// CatalogData is a contributing cause or trigger, only causes have a category.
+type CatalogData struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Category    string     `json:"category,omitempty"`
+	TeamID      *uuid.UUID `json:"teamID,omitempty"`
+}
+
+func teamPtr(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+
+	return &id
+}
+
+func reviewData(r reviewing.Review) ReviewData {
+	return ReviewData{ID: r.ID, Title: r.Title, URL: r.URL, State: r.State, TeamID: teamPtr(r.TeamID)}
+}
+
+// eventFor is the webhook event for a domain event, publishing is its own event so it's easy to only get that.
+// The webhook event has the same ID as the domain event, so receivers can tell when they get the same event twice.
+func eventFor(env event.Envelope) (Event, bool) {
+	ret := Event{ID: env.ID, OccurredAt: env.OccurredAt}
+
+	switch e := env.Event.(type) {
+	case reviewing.ReviewCreated:
+		ret.Type, ret.TeamID, ret.Data = EventReviewCreated, e.Review.TeamID, reviewData(e.Review)
+	case reviewing.ReviewUpdated:
+		ret.Type, ret.TeamID, ret.Data = EventReviewUpdated, e.Review.TeamID, reviewData(e.Review)
+	case reviewing.ReviewTransitioned:
+		ret.Type = EventReviewTransitioned
+		if e.Review.State == reviewing.StatePublished {
+			ret.Type = EventReviewPublished
+		}
+		ret.TeamID, ret.Data = e.Review.TeamID, TransitionData{Review: reviewData(e.Review), From: e.From}
+	case reviewing.CauseBound:
+		data := BoundCauseData{Review: reviewData(e.Review)}
+		data.BoundCause.ID = e.BoundCause.ID
+		data.BoundCause.CauseID = e.BoundCause.Cause.ID
+		data.BoundCause.Name = e.BoundCause.Cause.Name
+		data.BoundCause.Why = e.BoundCause.Why
+		data.BoundCause.WhyText = markdown.Text(e.BoundCause.Why)
+		data.BoundCause.IsProximalCause = e.BoundCause.IsProximalCause
+		ret.Type, ret.TeamID, ret.Data = EventReviewCauseBound, e.Review.TeamID, data
+	case reviewing.TriggerBound:
+		data := BoundTriggerData{Review: reviewData(e.Review)}
+		data.BoundTrigger.ID = e.BoundTrigger.ID
+		data.BoundTrigger.TriggerID = e.BoundTrigger.Trigger.ID
+		data.BoundTrigger.Name = e.BoundTrigger.Trigger.Name
+		data.BoundTrigger.Why = e.BoundTrigger.Why
+		data.BoundTrigger.WhyText = markdown.Text(e.BoundTrigger.Why)
+		ret.Type, ret.TeamID, ret.Data = EventReviewTriggerBound, e.Review.TeamID, data
+	case normalized.CatalogEntryChanged:
+		ret.Type = EventTriggerChanged
+		if e.Catalog == normalized.CatalogContributingCauses {
+			ret.Type = EventCauseChanged
+		}
+		ret.TeamID = e.TeamID
+		ret.Data = CatalogData{ID: e.ID, Name: e.Name, Description: e.Description, Category: e.Category, TeamID: teamPtr(e.TeamID)}
+	default:
+		return Event{}, false
+	}
+
+	return ret, true
+}
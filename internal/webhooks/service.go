@@ -0,0 +1,261 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/platform/validate"
+)
+
+const (
+	// SignatureHeader has the signature of the body, see Subscription.Sign.
+	SignatureHeader = "X-Signature-256"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+type Service struct {
+	subscriptions SubscriptionStorage
+	deliveries    DeliveryStorage
+	events        event.Publisher
+	client        *http.Client
+}
+
+type Option func(s *Service)
+
+// WithHTTPClient sends the requests with hc instead of a client with a 10 second timeout.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(s *Service) {
+		s.client = hc
+	}
+}
+
+// NewService sends the webhooks through events, which has to hand them back to Handle,
+// so every subscription is retried by the outbox on its own until it's received.
+func NewService(subscriptions SubscriptionStorage, deliveries DeliveryStorage, events event.Publisher, opts ...Option) *Service {
+	s := &Service{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		events:        events,
+		client:        &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Subscribe sends the events to the URL for the team being worked as, every event when none are picked.
+// Only admins manage the subscriptions since they're sent everything the team can see.
+func (s *Service) Subscribe(ctx context.Context, url string, events []EventType) (Subscription, error) {
+	if err := actor.Require(ctx, actor.Admin); err != nil {
+		return Subscription{}, fmt.Errorf("only admins can manage webhooks: %w", err)
+	}
+
+	sub := newSubscription(url, events)
+	sub.TeamID = tenant.ID(ctx)
+	sub.CreatedBy = actor.ID(ctx)
+	if err := validate.Struct(ctx, sub); err != nil {
+		return Subscription{}, fmt.Errorf("failed to validate webhook subscription: %w", err)
+	}
+
+	sub, err := s.subscriptions.Save(ctx, sub)
+	if err != nil {
+		return Subscription{}, fmt.Errorf("failed to store webhook subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (s *Service) Subscriptions(ctx context.Context) ([]Subscription, error) {
+	if err := actor.Require(ctx, actor.Admin); err != nil {
+		return nil, fmt.Errorf("only admins can manage webhooks: %w", err)
+	}
+
+	subs, err := s.subscriptions.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (s *Service) Subscription(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	if err := actor.Require(ctx, actor.Admin); err != nil {
+		return Subscription{}, fmt.Errorf("only admins can manage webhooks: %w", err)
+	}
+
+	sub, err := s.subscriptions.Get(ctx, id)
+	if err != nil {
+		return Subscription{}, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+// Unsubscribe stops sending events to the subscription, retries of what was already sent are dropped.
+func (s *Service) Unsubscribe(ctx context.Context, id uuid.UUID) error {
+	if err := actor.Require(ctx, actor.Admin); err != nil {
+		return fmt.Errorf("only admins can manage webhooks: %w", err)
+	}
+
+	if err := s.subscriptions.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// Deliveries is the log of what was sent to the subscription, with the most recent first.
+func (s *Service) Deliveries(ctx context.Context, subscriptionID uuid.UUID) ([]Delivery, error) {
+	if _, err := s.Subscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	ret, err := s.deliveries.ForSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (s *Service) Name() string {
+	return "webhooks"
+}
+
+// deliveryRequested is an event waiting to be sent to one subscription,
+// it's published for every subscription wanting the event so a failing one doesn't hold back the others.
+type deliveryRequested struct {
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	Type           EventType
+	Body           json.RawMessage
+}
+
+func (deliveryRequested) EventName() string { return "webhooks.DeliveryRequested" }
+
+func init() {
+	event.Register[deliveryRequested]()
+}
+
+// Handle sends the events about reviews and the catalogs to the subscriptions wanting them, see Publish.
+// An error is returned when a subscription couldn't be sent the event, so the outbox retries it.
+func (s *Service) Handle(ctx context.Context, env event.Envelope) error {
+	// Events are sent to every team's subscriptions
+	ctx = tenant.Unscoped(ctx)
+
+	if d, ok := env.Event.(deliveryRequested); ok {
+		return s.deliver(ctx, d, env.Attempts+1)
+	}
+
+	e, ok := eventFor(env)
+	if !ok {
+		return nil
+	}
+
+	return s.Publish(ctx, e)
+}
+
+// Publish requests the event to be sent to every subscription that wants it, Handle does the sending.
+func (s *Service) Publish(ctx context.Context, e Event) error {
+	subs, err := s.subscriptions.All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook subscriptions to publish to: %w", err)
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	var requests []event.Event
+	for _, sub := range subs {
+		if sub.Wants(e) {
+			requests = append(requests, deliveryRequested{SubscriptionID: sub.ID, EventID: e.ID, Type: e.Type, Body: body})
+		}
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	if err := s.events.Publish(ctx, requests...); err != nil {
+		return fmt.Errorf("failed to request webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// deliver makes one attempt at sending the event and logs it, the error is why the attempt failed.
+func (s *Service) deliver(ctx context.Context, dr deliveryRequested, attempt int) error {
+	sub, err := s.subscriptions.Get(ctx, dr.SubscriptionID)
+	if errors.Is(err, failure.NotFound) {
+		// Don't keep sending to a subscription that's been removed since the event happened
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get webhook subscription to deliver to: %w", err)
+	}
+
+	d := s.attempt(ctx, sub, dr)
+	d.Attempt = attempt
+	if _, err := s.deliveries.Save(ctx, d); err != nil {
+		slog.Error("failed to store webhook delivery", "subscriptionID", sub.ID, "eventID", dr.EventID, "error", err)
+	}
+	if !d.Succeeded() {
+		return fmt.Errorf("failed to deliver webhook event %s to %s: %s", dr.EventID, sub.ID, d.Error)
+	}
+
+	return nil
+}
+
+func (s *Service) attempt(ctx context.Context, sub Subscription, dr deliveryRequested) (d Delivery) {
+	d = Delivery{
+		ID:             uuid.Must(uuid.NewV7()),
+		SubscriptionID: sub.ID,
+		EventID:        dr.EventID,
+		EventType:      dr.Type,
+		AttemptedAt:    time.Now(),
+	}
+	defer func() { d.Duration = time.Since(d.AttemptedAt) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(dr.Body))
+	if err != nil {
+		d.Error = fmt.Sprintf("failed to create request: %s", err)
+		return d
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "incident-reviewer-webhooks")
+	req.Header.Set(SignatureHeader, sub.Sign(dr.Body))
+	req.Header.Set(EventHeader, string(dr.Type))
+	req.Header.Set(DeliveryHeader, dr.EventID.String())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		d.Error = fmt.Sprintf("failed to send: %s", err)
+		return d
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	d.StatusCode = resp.StatusCode
+	if !d.Succeeded() {
+		d.Error = "unexpected status: " + resp.Status
+	}
+
+	return d
+}
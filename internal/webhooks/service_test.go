@@ -0,0 +1,230 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/platform/event"
+	eventstorage "github.com/gaqzi/incident-reviewer/internal/platform/event/storage"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	"github.com/gaqzi/incident-reviewer/internal/webhooks"
+	"github.com/gaqzi/incident-reviewer/internal/webhooks/storage"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver answers with the statuses in order, and then 200, sending every request it gets on the channel.
+func receiver(t *testing.T, statuses ...int) (*httptest.Server, <-chan received) {
+	t.Helper()
+	ch := make(chan received, 10)
+	var count atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ch <- received{header: r.Header, body: body}
+		if i := int(count.Add(1)) - 1; i < len(statuses) {
+			w.WriteHeader(statuses[i])
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, ch
+}
+
+func next(t *testing.T, ch <-chan received) received {
+	t.Helper()
+	select {
+	case r := <-ch:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the webhook")
+		return received{}
+	}
+}
+
+func envelope(e event.Event) event.Envelope {
+	return event.Envelope{ID: a.UUID(), OccurredAt: time.Now(), Event: e}
+}
+
+func catalogEntry(catalog normalized.Catalog, teamID uuid.UUID) normalized.CatalogEntryChanged {
+	return normalized.CatalogEntryChanged{Catalog: catalog, ID: a.UUID(), Name: "Deploy", Description: "A deploy", TeamID: teamID}
+}
+
+func TestService(t *testing.T) {
+	adminCtx := actor.With(context.Background(), a.Actor().WithRole(actor.RoleAdmin).Build())
+	setup := func() *webhooks.Service {
+		bus := event.NewBus(eventstorage.NewOutboxMemoryStore(), event.WithRetries(3, 0, 0), event.WithPollInterval(time.Millisecond))
+		service := webhooks.NewService(storage.NewSubscriptionMemoryStore(), storage.NewDeliveryMemoryStore(), bus)
+		bus.Subscribe(service)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go (func() { _ = bus.Run(ctx) })()
+
+		return service
+	}
+
+	t.Run("only admins can subscribe", func(t *testing.T) {
+		ctx := actor.With(context.Background(), a.Actor().Build())
+
+		_, err := setup().Subscribe(ctx, "https://example.com/hook", nil)
+
+		require.ErrorIs(t, err, actor.ErrForbidden)
+	})
+
+	t.Run("subscribing validates the URL and events", func(t *testing.T) {
+		_, err := setup().Subscribe(adminCtx, "not a url", []webhooks.EventType{"review.deleted"})
+
+		var validationErrs validator.ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		require.Len(t, validationErrs, 2)
+	})
+
+	t.Run("a published review is sent signed to the subscriptions wanting it", func(t *testing.T) {
+		service := setup()
+		server, ch := receiver(t)
+		sub, err := service.Subscribe(adminCtx, server.URL, []webhooks.EventType{webhooks.EventReviewPublished})
+		require.NoError(t, err)
+		_, err = service.Subscribe(adminCtx, server.URL, []webhooks.EventType{webhooks.EventCauseChanged})
+		require.NoError(t, err)
+		review := a.Review().IsSaved().WithState(reviewing.StatePublished).Build()
+
+		env := envelope(reviewing.ReviewTransitioned{Review: review, From: reviewing.StateAwaitingApproval})
+		require.NoError(t, service.Handle(adminCtx, env))
+
+		got := next(t, ch)
+		require.Equal(t, env.ID.String(), got.header.Get(webhooks.DeliveryHeader), "expected the domain event's ID so handling it again is recognized")
+		require.Equal(t, sub.Sign(got.body), got.header.Get(webhooks.SignatureHeader))
+		require.Equal(t, string(webhooks.EventReviewPublished), got.header.Get(webhooks.EventHeader))
+		var event struct {
+			Type webhooks.EventType
+			Data webhooks.TransitionData
+		}
+		require.NoError(t, json.Unmarshal(got.body, &event))
+		require.Equal(t, webhooks.EventReviewPublished, event.Type)
+		require.Equal(t, review.ID, event.Data.Review.ID)
+		require.Equal(t, reviewing.StateAwaitingApproval, event.Data.From)
+		require.Eventually(t, func() bool {
+			deliveries, err := service.Deliveries(adminCtx, sub.ID)
+			return err == nil && len(deliveries) == 1 && deliveries[0].Succeeded()
+		}, 5*time.Second, time.Millisecond)
+		select {
+		case <-ch:
+			t.Fatal("expected the subscription for causes to not get the review")
+		case <-time.After(20 * time.Millisecond):
+		}
+	})
+
+	t.Run("failed deliveries are retried and logged until they succeed", func(t *testing.T) {
+		service := setup()
+		server, ch := receiver(t, http.StatusInternalServerError, http.StatusBadGateway)
+		sub, err := service.Subscribe(adminCtx, server.URL, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Handle(adminCtx, envelope(catalogEntry(normalized.CatalogContributingCauses, uuid.Nil))))
+
+		first, second, third := next(t, ch), next(t, ch), next(t, ch)
+		require.Equal(t, first.body, third.body, "expected the same event to be retried")
+		require.Equal(t, first.header.Get(webhooks.DeliveryHeader), second.header.Get(webhooks.DeliveryHeader))
+		require.Eventually(t, func() bool {
+			deliveries, err := service.Deliveries(adminCtx, sub.ID)
+			return err == nil && len(deliveries) == 3
+		}, 5*time.Second, time.Millisecond)
+		deliveries, err := service.Deliveries(adminCtx, sub.ID)
+		require.NoError(t, err)
+		require.Equal(t, 3, deliveries[0].Attempt)
+		require.True(t, deliveries[0].Succeeded())
+		require.Equal(t, http.StatusBadGateway, deliveries[1].StatusCode)
+		require.NotEmpty(t, deliveries[1].Error)
+	})
+
+	t.Run("only the subscription that failed is sent the event again", func(t *testing.T) {
+		service := setup()
+		failing, failingCh := receiver(t, http.StatusInternalServerError)
+		working, workingCh := receiver(t)
+		_, err := service.Subscribe(adminCtx, failing.URL, nil)
+		require.NoError(t, err)
+		_, err = service.Subscribe(adminCtx, working.URL, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Handle(adminCtx, envelope(catalogEntry(normalized.CatalogContributingCauses, uuid.Nil))))
+
+		next(t, failingCh)
+		next(t, failingCh)
+		next(t, workingCh)
+		select {
+		case r := <-workingCh:
+			t.Fatalf("expected the working subscription to only get the event once, got: %s", r.body)
+		case <-time.After(20 * time.Millisecond):
+		}
+	})
+
+	t.Run("gives up after the attempts", func(t *testing.T) {
+		service := setup()
+		server, ch := receiver(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+		sub, err := service.Subscribe(adminCtx, server.URL, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Handle(adminCtx, envelope(catalogEntry(normalized.CatalogTriggers, uuid.Nil))))
+
+		require.Eventually(t, func() bool {
+			deliveries, err := service.Deliveries(adminCtx, sub.ID)
+			return err == nil && len(deliveries) == 3
+		}, 5*time.Second, time.Millisecond)
+		require.Len(t, ch, 3, "expected no more than the three attempts")
+	})
+
+	t.Run("teams are only sent their own reviews and the shared catalog", func(t *testing.T) {
+		service := setup()
+		server, ch := receiver(t)
+		teamID := a.UUID()
+		teamCtx := tenant.With(adminCtx, teamID)
+		_, err := service.Subscribe(teamCtx, server.URL, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Handle(adminCtx, envelope(reviewing.ReviewCreated{Review: a.Review().IsSaved().Build()})))
+		require.NoError(t, service.Handle(adminCtx, envelope(reviewing.ReviewCreated{Review: a.Review().IsSaved().WithTeam(a.UUID()).Build()})))
+		require.NoError(t, service.Handle(adminCtx, envelope(catalogEntry(normalized.CatalogContributingCauses, a.UUID()))))
+		require.NoError(t, service.Handle(adminCtx, envelope(catalogEntry(normalized.CatalogContributingCauses, uuid.Nil))))
+		require.NoError(t, service.Handle(adminCtx, envelope(reviewing.ReviewUpdated{Review: a.Review().IsSaved().WithTeam(teamID).Build()})))
+
+		types := []string{next(t, ch).header.Get(webhooks.EventHeader), next(t, ch).header.Get(webhooks.EventHeader)}
+		require.ElementsMatch(t, []string{string(webhooks.EventCauseChanged), string(webhooks.EventReviewUpdated)}, types)
+		select {
+		case r := <-ch:
+			t.Fatalf("expected no other events, got: %s", r.body)
+		case <-time.After(20 * time.Millisecond):
+		}
+	})
+
+	t.Run("an unsubscribed subscription isn't sent anything", func(t *testing.T) {
+		service := setup()
+		server, ch := receiver(t)
+		sub, err := service.Subscribe(adminCtx, server.URL, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Unsubscribe(adminCtx, sub.ID))
+		require.NoError(t, service.Handle(adminCtx, envelope(catalogEntry(normalized.CatalogContributingCauses, uuid.Nil))))
+
+		select {
+		case r := <-ch:
+			t.Fatalf("expected nothing to be sent, got: %s", r.body)
+		case <-time.After(20 * time.Millisecond):
+		}
+	})
+}
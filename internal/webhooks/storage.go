@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Delivery is one attempt at sending an event to a subscription.
+type Delivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      EventType
+	// Attempt starts at 1 and goes up for every retry of the event.
+	Attempt int
+	// StatusCode is what the subscription answered, 0 when it couldn't be reached.
+	StatusCode int
+	// Error is why the attempt failed, empty when it succeeded.
+	Error       string
+	AttemptedAt time.Time
+	Duration    time.Duration
+}
+
+func (d Delivery) Succeeded() bool {
+	return d.Error == "" && d.StatusCode >= http.StatusOK && d.StatusCode < http.StatusMultipleChoices
+}
+
+type SubscriptionStorage interface {
+	// Save stores the subscription, only subscriptions for the current team can be saved, see tenant.Owns.
+	Save(ctx context.Context, s Subscription) (Subscription, error)
+
+	// Get returns the subscription when it's for the current team.
+	Get(ctx context.Context, id uuid.UUID) (Subscription, error)
+
+	// All returns the current team's subscriptions with the most recent first.
+	All(ctx context.Context) ([]Subscription, error)
+
+	// Delete removes the subscription when it's for the current team.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type DeliveryStorage interface {
+	Save(ctx context.Context, d Delivery) (Delivery, error)
+
+	// ForSubscription returns the deliveries to the subscription with the most recent first,
+	// implementations can choose to only keep the most recent ones.
+	ForSubscription(ctx context.Context, subscriptionID uuid.UUID) ([]Delivery, error)
+}
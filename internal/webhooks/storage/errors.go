@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+)
+
+type NoSubscriptionError struct {
+	ID uuid.UUID
+}
+
+func (e *NoSubscriptionError) Error() string {
+	return fmt.Sprintf("webhook subscription not found by id: %s", e.ID)
+}
+
+// Is makes it a failure.NotFound.
+func (e *NoSubscriptionError) Is(target error) bool {
+	return target == failure.NotFound
+}
+
+// ErrNoID indicates that the passed in ID is blank/uninitialized.
+var ErrNoID = errors.New("can't store because ID is not set")
+
+// ErrOtherTeam indicates that the subscription is for another team than the one being worked as.
+var ErrOtherTeam = failure.New(failure.Forbidden, "can't store webhook subscription for another team")
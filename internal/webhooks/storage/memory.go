@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/webhooks"
+)
+
+type SubscriptionMemoryStore struct {
+	mu   sync.RWMutex
+	data map[uuid.UUID]webhooks.Subscription
+}
+
+func NewSubscriptionMemoryStore() *SubscriptionMemoryStore {
+	return &SubscriptionMemoryStore{
+		data: make(map[uuid.UUID]webhooks.Subscription),
+	}
+}
+
+func (s *SubscriptionMemoryStore) Save(ctx context.Context, sub webhooks.Subscription) (webhooks.Subscription, error) {
+	if sub.ID == uuid.Nil {
+		return webhooks.Subscription{}, ErrNoID
+	}
+	if !tenant.Owns(ctx, sub.TeamID) {
+		return webhooks.Subscription{}, ErrOtherTeam
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stored, ok := s.data[sub.ID]; ok && !tenant.Owns(ctx, stored.TeamID) {
+		return webhooks.Subscription{}, &NoSubscriptionError{ID: sub.ID}
+	}
+	s.data[sub.ID] = sub
+
+	return sub, nil
+}
+
+func (s *SubscriptionMemoryStore) Get(ctx context.Context, id uuid.UUID) (webhooks.Subscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sub, ok := s.data[id]
+	if !ok || !tenant.Owns(ctx, sub.TeamID) {
+		return webhooks.Subscription{}, &NoSubscriptionError{ID: id}
+	}
+
+	return sub, nil
+}
+
+func (s *SubscriptionMemoryStore) All(ctx context.Context) ([]webhooks.Subscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// The IDs are UUIDv7 and sort by when they were created, reverse for the most recent first
+	keys := slices.SortedFunc(maps.Keys(s.data), func(u uuid.UUID, u2 uuid.UUID) int {
+		return bytes.Compare(u[:], u2[:])
+	})
+	slices.Reverse(keys)
+
+	ret := make([]webhooks.Subscription, 0, len(keys))
+	for _, k := range keys {
+		if tenant.Owns(ctx, s.data[k].TeamID) {
+			ret = append(ret, s.data[k])
+		}
+	}
+
+	return ret, nil
+}
+
+func (s *SubscriptionMemoryStore) Delete(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.data[id]
+	if !ok || !tenant.Owns(ctx, sub.TeamID) {
+		return &NoSubscriptionError{ID: id}
+	}
+	delete(s.data, id)
+
+	return nil
+}
+
+// MaxDeliveries is how many of the most recent deliveries are kept for each subscription.
+const MaxDeliveries = 100
+
+type DeliveryMemoryStore struct {
+	mu   sync.RWMutex
+	data map[uuid.UUID][]webhooks.Delivery
+}
+
+func NewDeliveryMemoryStore() *DeliveryMemoryStore {
+	return &DeliveryMemoryStore{
+		data: make(map[uuid.UUID][]webhooks.Delivery),
+	}
+}
+
+func (s *DeliveryMemoryStore) Save(_ context.Context, d webhooks.Delivery) (webhooks.Delivery, error) {
+	if d.ID == uuid.Nil || d.SubscriptionID == uuid.Nil {
+		return webhooks.Delivery{}, ErrNoID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deliveries := append(s.data[d.SubscriptionID], d)
+	if len(deliveries) > MaxDeliveries {
+		deliveries = slices.Clone(deliveries[len(deliveries)-MaxDeliveries:])
+	}
+	s.data[d.SubscriptionID] = deliveries
+
+	return d, nil
+}
+
+func (s *DeliveryMemoryStore) ForSubscription(_ context.Context, subscriptionID uuid.UUID) ([]webhooks.Delivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ret := slices.Clone(s.data[subscriptionID])
+	slices.Reverse(ret)
+
+	return ret, nil
+}
@@ -0,0 +1,140 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/failure"
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+	"github.com/gaqzi/incident-reviewer/internal/webhooks"
+	"github.com/gaqzi/incident-reviewer/internal/webhooks/storage"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+func TestSubscriptionMemoryStore(t *testing.T) {
+	SubscriptionStorageTest(t, context.Background(), func() webhooks.SubscriptionStorage { return storage.NewSubscriptionMemoryStore() })
+}
+
+func TestDeliveryMemoryStore(t *testing.T) {
+	DeliveryStorageTest(t, context.Background(), func() webhooks.DeliveryStorage { return storage.NewDeliveryMemoryStore() })
+}
+
+func subscription(teamID uuid.UUID) webhooks.Subscription {
+	return webhooks.Subscription{ID: a.UUID(), URL: "https://example.com/hook", Secret: "s3cret", TeamID: teamID, CreatedAt: time.Now()}
+}
+
+// SubscriptionStorageTest is a base suite used to test across the implementations of webhooks.SubscriptionStorage.
+func SubscriptionStorageTest(t *testing.T, ctx context.Context, storeFactory func() webhooks.SubscriptionStorage) {
+	teamID := a.UUID()
+	teamCtx := tenant.With(ctx, teamID)
+
+	t.Run("Save", func(t *testing.T) {
+		t.Run("returns an error when the ID isn't set", func(t *testing.T) {
+			_, err := storeFactory().Save(ctx, webhooks.Subscription{})
+
+			require.ErrorIs(t, err, storage.ErrNoID)
+		})
+
+		t.Run("returns an error for another team's subscription", func(t *testing.T) {
+			_, err := storeFactory().Save(ctx, subscription(teamID))
+
+			require.ErrorIs(t, err, storage.ErrOtherTeam)
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("returns the team's subscription", func(t *testing.T) {
+			store := storeFactory()
+			expected, err := store.Save(teamCtx, subscription(teamID))
+			require.NoError(t, err)
+
+			actual, err := store.Get(teamCtx, expected.ID)
+
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		})
+
+		t.Run("returns NoSubscriptionError for another team's subscription", func(t *testing.T) {
+			store := storeFactory()
+			sub, err := store.Save(teamCtx, subscription(teamID))
+			require.NoError(t, err)
+
+			_, err = store.Get(ctx, sub.ID)
+
+			var noSub *storage.NoSubscriptionError
+			require.ErrorAs(t, err, &noSub)
+			require.ErrorIs(t, err, failure.NotFound, "expected it to be a not found failure")
+		})
+	})
+
+	t.Run("All returns the team's subscriptions with the most recent first", func(t *testing.T) {
+		store := storeFactory()
+		first, err := store.Save(teamCtx, subscription(teamID))
+		require.NoError(t, err)
+		second, err := store.Save(teamCtx, subscription(teamID))
+		require.NoError(t, err)
+		other, err := store.Save(ctx, subscription(uuid.Nil))
+		require.NoError(t, err)
+
+		actual, err := store.All(teamCtx)
+		require.NoError(t, err)
+		require.Equal(t, []webhooks.Subscription{second, first}, actual)
+
+		all, err := store.All(tenant.Unscoped(ctx))
+		require.NoError(t, err)
+		require.Equal(t, []webhooks.Subscription{other, second, first}, all)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		t.Run("removes the subscription", func(t *testing.T) {
+			store := storeFactory()
+			sub, err := store.Save(teamCtx, subscription(teamID))
+			require.NoError(t, err)
+
+			require.NoError(t, store.Delete(teamCtx, sub.ID))
+
+			_, err = store.Get(teamCtx, sub.ID)
+			require.Error(t, err)
+		})
+
+		t.Run("returns NoSubscriptionError for another team's subscription", func(t *testing.T) {
+			store := storeFactory()
+			sub, err := store.Save(teamCtx, subscription(teamID))
+			require.NoError(t, err)
+
+			err = store.Delete(ctx, sub.ID)
+
+			var noSub *storage.NoSubscriptionError
+			require.ErrorAs(t, err, &noSub)
+		})
+	})
+}
+
+// DeliveryStorageTest is a base suite used to test across the implementations of webhooks.DeliveryStorage.
+func DeliveryStorageTest(t *testing.T, ctx context.Context, storeFactory func() webhooks.DeliveryStorage) {
+	t.Run("Save returns an error when the IDs aren't set", func(t *testing.T) {
+		_, err := storeFactory().Save(ctx, webhooks.Delivery{ID: a.UUID()})
+
+		require.ErrorIs(t, err, storage.ErrNoID)
+	})
+
+	t.Run("ForSubscription returns the subscription's deliveries with the most recent first", func(t *testing.T) {
+		store := storeFactory()
+		subID := a.UUID()
+		first, err := store.Save(ctx, webhooks.Delivery{ID: a.UUID(), SubscriptionID: subID, Attempt: 1})
+		require.NoError(t, err)
+		second, err := store.Save(ctx, webhooks.Delivery{ID: a.UUID(), SubscriptionID: subID, Attempt: 2})
+		require.NoError(t, err)
+		_, err = store.Save(ctx, webhooks.Delivery{ID: a.UUID(), SubscriptionID: a.UUID(), Attempt: 1})
+		require.NoError(t, err)
+
+		actual, err := store.ForSubscription(ctx, subID)
+
+		require.NoError(t, err)
+		require.Equal(t, []webhooks.Delivery{second, first}, actual)
+	})
+}
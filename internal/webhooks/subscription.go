@@ -0,0 +1,65 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/tenant"
+)
+
+// SignaturePrefix starts the signature of a request's body, which is followed by the hex encoded HMAC-SHA256 of it.
+const SignaturePrefix = "sha256="
+
+// Subscription is a URL that's sent the events it wants.
+type Subscription struct {
+	ID  uuid.UUID `validate:"required"`
+	URL string    `validate:"required,http_url"`
+	// Secret signs every request, so the receiver knows it came from us.
+	Secret string `validate:"required"`
+	// Events are the events that are sent, every event when it's empty.
+	Events []EventType `validate:"dive,oneof=review.created review.updated review.transitioned review.published review.cause_bound review.trigger_bound contributing_cause.changed trigger.changed"`
+	// TeamID is the team the subscription is for, it's only sent what the team can see.
+	TeamID uuid.UUID
+
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+}
+
+func newSubscription(url string, events []EventType) Subscription {
+	return Subscription{
+		ID:        uuid.Must(uuid.NewV7()),
+		URL:       url,
+		Secret:    rand.Text(),
+		Events:    events,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Wants is true when the subscription is for the event's type and its team can see what it's about.
+func (s Subscription) Wants(e Event) bool {
+	if len(s.Events) > 0 && !slices.Contains(s.Events, e.Type) {
+		return false
+	}
+
+	ctx := tenant.With(context.Background(), s.TeamID)
+	if e.Type.IsCatalog() {
+		return tenant.Shares(ctx, e.TeamID)
+	}
+
+	return tenant.Owns(ctx, e.TeamID)
+}
+
+// Sign returns the signature of the body, which is sent in the SignatureHeader.
+func (s Subscription) Sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write(body)
+
+	return SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+func (c *Client) ListContributingCauses(ctx context.Context) ([]Cause, error) {
+	var ret []Cause
+	if err := c.do(ctx, http.MethodGet, "/contributing-causes", nil, nil, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (c *Client) CreateContributingCause(ctx context.Context, in CauseInput) (Cause, error) {
+	var ret Cause
+	err := c.do(ctx, http.MethodPost, "/contributing-causes", nil, in, &ret)
+
+	return ret, err
+}
+
+func (c *Client) GetContributingCause(ctx context.Context, id uuid.UUID) (Cause, error) {
+	var ret Cause
+	err := c.do(ctx, http.MethodGet, "/contributing-causes/"+id.String(), nil, nil, &ret)
+
+	return ret, err
+}
+
+func (c *Client) UpdateContributingCause(ctx context.Context, id uuid.UUID, in CauseInput) (Cause, error) {
+	var ret Cause
+	err := c.do(ctx, http.MethodPut, "/contributing-causes/"+id.String(), nil, in, &ret)
+
+	return ret, err
+}
+
+func (c *Client) ListTriggers(ctx context.Context) ([]Trigger, error) {
+	var ret []Trigger
+	if err := c.do(ctx, http.MethodGet, "/triggers", nil, nil, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (c *Client) CreateTrigger(ctx context.Context, in TriggerInput) (Trigger, error) {
+	var ret Trigger
+	err := c.do(ctx, http.MethodPost, "/triggers", nil, in, &ret)
+
+	return ret, err
+}
+
+func (c *Client) GetTrigger(ctx context.Context, id uuid.UUID) (Trigger, error) {
+	var ret Trigger
+	err := c.do(ctx, http.MethodGet, "/triggers/"+id.String(), nil, nil, &ret)
+
+	return ret, err
+}
+
+func (c *Client) UpdateTrigger(ctx context.Context, id uuid.UUID, in TriggerInput) (Trigger, error) {
+	var ret Trigger
+	err := c.do(ctx, http.MethodPut, "/triggers/"+id.String(), nil, in, &ret)
+
+	return ret, err
+}
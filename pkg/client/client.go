@@ -0,0 +1,186 @@
+// Package client is a Go client for the incident reviewer's JSON API, see api.V1.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gaqzi/incident-reviewer/internal/app/api"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+// The types are the ones the API itself uses, so the client can't drift from it.
+type (
+	Review            = api.Review
+	ReviewInput       = api.ReviewInput
+	BoundCause        = api.BoundCause
+	BoundCauseInput   = api.BoundCauseInput
+	BoundTrigger      = api.BoundTrigger
+	BoundTriggerInput = api.BoundTriggerInput
+	Votes             = api.Votes
+	Cause             = api.Cause
+	CauseInput        = api.CauseInput
+	Trigger           = api.Trigger
+	TriggerInput      = api.TriggerInput
+	State             = reviewing.State
+)
+
+const (
+	StateDraft            = reviewing.StateDraft
+	StateInReview         = reviewing.StateInReview
+	StateAwaitingApproval = reviewing.StateAwaitingApproval
+	StatePublished        = reviewing.StatePublished
+	StateArchived         = reviewing.StateArchived
+)
+
+type Client struct {
+	baseURL *url.URL
+	http    *http.Client
+	token   string
+	retries int
+	backoff time.Duration
+}
+
+type Option func(c *Client)
+
+// WithToken authenticates every request with the token.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient uses hc for the requests instead of a client with a 30 second timeout.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// WithRetries changes how many times a failed request is retried and how long to wait before the first retry,
+// the wait doubles for every retry after that. Only requests that are safe to repeat are retried.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client for the incident reviewer at baseURL, like https://incidents.example.com.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base url, needs a scheme and host: %q", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/api/v1"
+
+	c := &Client{
+		baseURL: u,
+		http:    &http.Client{Timeout: 30 * time.Second},
+		retries: 3,
+		backoff: 200 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// do sends the request and decodes the response into out, unless out is nil.
+// Requests that are safe to repeat are retried when the server is unavailable or asks to slow down.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in any, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	wait := c.backoff
+	for attempt := 0; ; attempt++ {
+		err := c.attempt(ctx, method, u.String(), body, out)
+		if err == nil || attempt >= c.retries || !retryable(method, err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, method, u string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errorFrom(resp)
+	}
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// retryable is true for requests that can be repeated without doing it twice and failed in a way that might pass next time.
+func retryable(method string, err error) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut:
+	default:
+		return false
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		// Couldn't reach the server or the response was cut off
+		return true
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
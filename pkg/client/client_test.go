@@ -0,0 +1,177 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	accountstorage "github.com/gaqzi/incident-reviewer/internal/accounts/storage"
+	"github.com/gaqzi/incident-reviewer/internal/app/api"
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
+	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
+	contribstorage "github.com/gaqzi/incident-reviewer/internal/normalized/contributing/storage"
+	"github.com/gaqzi/incident-reviewer/internal/normalized/storage"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+	reviewstorage "github.com/gaqzi/incident-reviewer/internal/reviewing/storage"
+	"github.com/gaqzi/incident-reviewer/pkg/client"
+	"github.com/gaqzi/incident-reviewer/test/a"
+)
+
+// newRouter is the API with the real services and a token for an admin.
+func newRouter(t *testing.T) (http.Handler, string) {
+	t.Helper()
+	systemCtx := actor.With(context.Background(), actor.System)
+	users := accounts.NewService(accountstorage.NewUserMemoryStore(), accountstorage.NewSessionMemoryStore(), accountstorage.NewTokenMemoryStore())
+	admin, err := users.Save(systemCtx, a.User().WithRole(actor.RoleAdmin).Build())
+	require.NoError(t, err)
+	_, token, err := users.CreateToken(actor.With(systemCtx, admin.Actor()), "client tests", accounts.ScopeReadWrite)
+	require.NoError(t, err)
+
+	causes := contributing.NewCauseService(contribstorage.NewCauseMemoryStore())
+	triggers := normalized.NewTriggerService(storage.NewTriggerMemoryStore())
+	reviews, err := reviewing.NewService(reviewstorage.NewMemoryStore(), causes, triggers)
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.With(api.Authenticate(users)).Route("/api/v1", api.V1(reviews, causes, triggers))
+
+	return r, token
+}
+
+func newClient(t *testing.T, url string, token string, opts ...client.Option) *client.Client {
+	t.Helper()
+	c, err := client.New(url, append([]client.Option{client.WithToken(token), client.WithRetries(3, time.Millisecond)}, opts...)...)
+	require.NoError(t, err)
+
+	return c
+}
+
+func validReview() client.ReviewInput {
+	return client.ReviewInput{
+		URL:                 "https://example.com/incidents/1",
+		Title:               "From the client",
+		Description:         "A review made by automation",
+		Impact:              "Nobody noticed",
+		Where:               "Everywhere",
+		ReportProximalCause: "A cause",
+		ReportTrigger:       "A trigger",
+	}
+}
+
+func TestClient(t *testing.T) {
+	router, token := newRouter(t)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	ctx := context.Background()
+	c := newClient(t, server.URL, token)
+
+	t.Run("reviewing with causes and triggers from the catalogs", func(t *testing.T) {
+		cause, err := c.CreateContributingCause(ctx, client.CauseInput{Name: "Config change", Description: "Someone changed the config", Category: "Change"})
+		require.NoError(t, err)
+		trigger, err := c.CreateTrigger(ctx, client.TriggerInput{Name: "Deploy", Description: "A new version went out"})
+		require.NoError(t, err)
+		triggers, err := c.ListTriggers(ctx)
+		require.NoError(t, err)
+		require.Len(t, triggers, 1)
+		require.Equal(t, trigger.ID, triggers[0].ID)
+
+		review, err := c.CreateReview(ctx, validReview())
+		require.NoError(t, err)
+		require.Equal(t, client.StateDraft, review.State)
+
+		bound, err := c.BindContributingCause(ctx, review.ID, client.BoundCauseInput{ContributingCauseID: cause.ID, Why: "It was changed"})
+		require.NoError(t, err)
+		bound, err = c.UpdateBoundContributingCause(ctx, review.ID, bound.ID, client.BoundCauseInput{ContributingCauseID: cause.ID, Why: "It was changed", IsProximalCause: true})
+		require.NoError(t, err)
+		require.True(t, bound.IsProximalCause)
+
+		boundTrigger, err := c.BindTrigger(ctx, review.ID, client.BoundTriggerInput{TriggerID: trigger.ID, Why: "It was deployed"})
+		require.NoError(t, err)
+		got, err := c.GetBoundTrigger(ctx, review.ID, boundTrigger.ID)
+		require.NoError(t, err)
+		require.Equal(t, "It was deployed", got.Why)
+
+		review, err = c.TransitionReview(ctx, review.ID, client.StateInReview)
+		require.NoError(t, err)
+		require.Equal(t, client.StateInReview, review.State)
+
+		inReview, err := c.ListReviews(ctx, client.StateInReview)
+		require.NoError(t, err)
+		require.Len(t, inReview, 1)
+		require.Len(t, inReview[0].BoundCauses, 1)
+		require.Len(t, inReview[0].BoundTriggers, 1)
+	})
+
+	t.Run("errors can be checked by kind", func(t *testing.T) {
+		_, err := c.GetReview(ctx, uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, client.ErrNotFound)
+
+		_, err = c.CreateReview(ctx, client.ReviewInput{Title: "Only a title"})
+		require.ErrorIs(t, err, client.ErrValidation)
+		var apiErr *client.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, "Can't be empty.", apiErr.Fields["url"])
+
+		_, err = newClient(t, server.URL, accounts.TokenPrefix+"not-the-token").ListReviews(ctx, "")
+		require.ErrorIs(t, err, client.ErrUnauthorized)
+	})
+}
+
+func TestClient_retries(t *testing.T) {
+	router, token := newRouter(t)
+	var requests, failures atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failures.Load() > 0 {
+			failures.Add(-1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		router.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	c := newClient(t, server.URL, token)
+
+	t.Run("requests that are safe to repeat are retried while the server is unavailable", func(t *testing.T) {
+		requests.Store(0)
+		failures.Store(2)
+
+		_, err := c.ListTriggers(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("gives up after the retries", func(t *testing.T) {
+		requests.Store(0)
+		failures.Store(10)
+
+		_, err := c.ListTriggers(ctx)
+
+		var apiErr *client.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+		require.Equal(t, int32(4), requests.Load(), "expected the first request and three retries")
+	})
+
+	t.Run("creating isn't retried since it could create twice", func(t *testing.T) {
+		requests.Store(0)
+		failures.Store(1)
+
+		_, err := c.CreateReview(ctx, validReview())
+
+		require.Error(t, err)
+		require.Equal(t, int32(1), requests.Load())
+	})
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gaqzi/incident-reviewer/internal/app/api"
+)
+
+var (
+	ErrUnauthorized = errors.New("not signed in")
+	ErrForbidden    = errors.New("not allowed")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflicts with the current state")
+	ErrValidation   = errors.New("validation failed")
+)
+
+// Error is what the API answered when a request failed, use errors.Is with the Err variables to check the kind.
+type Error struct {
+	StatusCode int
+	Message    string
+	// Fields has what's wrong with each field when validation failed.
+	Fields map[string]string
+}
+
+func (e *Error) Error() string {
+	if len(e.Fields) > 0 {
+		return fmt.Sprintf("api error %d: %s: %v", e.StatusCode, e.Message, e.Fields)
+	}
+
+	return fmt.Sprintf("api error %d: %s", e.StatusCode, e.Message)
+}
+
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrValidation:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	default:
+		return false
+	}
+}
+
+func errorFrom(resp *http.Response) error {
+	ret := &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return ret
+	}
+	var decoded struct {
+		Error api.Error `json:"error"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil || decoded.Error.Message == "" {
+		// Not from the API, like a proxy in front of it
+		return ret
+	}
+	ret.Message = decoded.Error.Message
+	ret.Fields = decoded.Error.Fields
+
+	return ret
+}
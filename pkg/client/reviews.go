@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/app/api"
+)
+
+// ListReviews lists the reviews, only those in state unless it's empty.
+func (c *Client) ListReviews(ctx context.Context, state State) ([]Review, error) {
+	query := url.Values{}
+	if state != "" {
+		query.Set("state", string(state))
+	}
+
+	var ret []Review
+	if err := c.do(ctx, http.MethodGet, "/reviews", query, nil, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (c *Client) CreateReview(ctx context.Context, in ReviewInput) (Review, error) {
+	var ret Review
+	err := c.do(ctx, http.MethodPost, "/reviews", nil, in, &ret)
+
+	return ret, err
+}
+
+func (c *Client) GetReview(ctx context.Context, id uuid.UUID) (Review, error) {
+	var ret Review
+	err := c.do(ctx, http.MethodGet, "/reviews/"+id.String(), nil, nil, &ret)
+
+	return ret, err
+}
+
+func (c *Client) UpdateReview(ctx context.Context, id uuid.UUID, in ReviewInput) (Review, error) {
+	var ret Review
+	err := c.do(ctx, http.MethodPut, "/reviews/"+id.String(), nil, in, &ret)
+
+	return ret, err
+}
+
+// TransitionReview moves the review to the state, Review.NextStates has the states it can move to.
+func (c *Client) TransitionReview(ctx context.Context, id uuid.UUID, to State) (Review, error) {
+	var ret Review
+	err := c.do(ctx, http.MethodPost, "/reviews/"+id.String()+"/state", nil, api.TransitionInput{State: to}, &ret)
+
+	return ret, err
+}
+
+func (c *Client) BindContributingCause(ctx context.Context, reviewID uuid.UUID, in BoundCauseInput) (BoundCause, error) {
+	var ret BoundCause
+	err := c.do(ctx, http.MethodPost, "/reviews/"+reviewID.String()+"/contributing-causes", nil, in, &ret)
+
+	return ret, err
+}
+
+func (c *Client) GetBoundContributingCause(ctx context.Context, reviewID uuid.UUID, boundCauseID uuid.UUID) (BoundCause, error) {
+	var ret BoundCause
+	err := c.do(ctx, http.MethodGet, "/reviews/"+reviewID.String()+"/contributing-causes/"+boundCauseID.String(), nil, nil, &ret)
+
+	return ret, err
+}
+
+func (c *Client) UpdateBoundContributingCause(ctx context.Context, reviewID uuid.UUID, boundCauseID uuid.UUID, in BoundCauseInput) (BoundCause, error) {
+	var ret BoundCause
+	err := c.do(ctx, http.MethodPut, "/reviews/"+reviewID.String()+"/contributing-causes/"+boundCauseID.String(), nil, in, &ret)
+
+	return ret, err
+}
+
+func (c *Client) BindTrigger(ctx context.Context, reviewID uuid.UUID, in BoundTriggerInput) (BoundTrigger, error) {
+	var ret BoundTrigger
+	err := c.do(ctx, http.MethodPost, "/reviews/"+reviewID.String()+"/triggers", nil, in, &ret)
+
+	return ret, err
+}
+
+func (c *Client) GetBoundTrigger(ctx context.Context, reviewID uuid.UUID, boundTriggerID uuid.UUID) (BoundTrigger, error) {
+	var ret BoundTrigger
+	err := c.do(ctx, http.MethodGet, "/reviews/"+reviewID.String()+"/triggers/"+boundTriggerID.String(), nil, nil, &ret)
+
+	return ret, err
+}
+
+func (c *Client) UpdateBoundTrigger(ctx context.Context, reviewID uuid.UUID, boundTriggerID uuid.UUID, in BoundTriggerInput) (BoundTrigger, error) {
+	var ret BoundTrigger
+	err := c.do(ctx, http.MethodPut, "/reviews/"+reviewID.String()+"/triggers/"+boundTriggerID.String(), nil, in, &ret)
+
+	return ret, err
+}
@@ -0,0 +1,39 @@
+package a
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+)
+
+type BuilderActor struct {
+	a actor.Actor
+}
+
+func Actor() BuilderActor {
+	return BuilderActor{}.
+		IsValid()
+}
+
+func (b BuilderActor) IsValid() BuilderActor {
+	b.a.ID = User().Build().ID
+	b.a.Role = actor.RoleFacilitator
+
+	return b
+}
+
+func (b BuilderActor) WithID(id uuid.UUID) BuilderActor {
+	b.a.ID = id
+
+	return b
+}
+
+func (b BuilderActor) WithRole(role actor.Role) BuilderActor {
+	b.a.Role = role
+
+	return b
+}
+
+func (b BuilderActor) Build() actor.Actor {
+	return b.a
+}
@@ -0,0 +1,83 @@
+package a
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+type BuilderComment struct {
+	c reviewing.Comment
+}
+
+func Comment() BuilderComment {
+	return BuilderComment{}.
+		IsValid().
+		IsSaved()
+}
+
+func (b BuilderComment) IsValid() BuilderComment {
+	b.c.Subject = reviewing.CommentSubject{Kind: reviewing.CommentOnReview, ID: Review().Build().ID}
+	b.c.AuthorID = User().Build().ID
+	b.c.Body = "Are we sure this was the only thing that went wrong?"
+
+	return b
+}
+
+func (b BuilderComment) IsSaved() BuilderComment {
+	createdAt, err := time.Parse(time.RFC3339Nano, "2025-04-03T10:02:11.1337Z")
+	if err != nil {
+		panic("failed to parse example timestamp: " + err.Error())
+	}
+
+	b.c.ID = uuid.MustParse("0195fb1c-6a0e-7d3f-8b52-c4e9a1d27f60")
+	b.c.CreatedAt = createdAt
+	b.c.UpdatedAt = createdAt
+
+	return b
+}
+
+func (b BuilderComment) IsNotSaved() BuilderComment {
+	b.c.ID = uuid.Nil
+	b.c.CreatedAt = time.Time{}
+	b.c.UpdatedAt = time.Time{}
+
+	return b
+}
+
+func (b BuilderComment) WithID(id uuid.UUID) BuilderComment {
+	b.c.ID = id
+
+	return b
+}
+
+func (b BuilderComment) WithAuthor(id uuid.UUID) BuilderComment {
+	b.c.AuthorID = id
+
+	return b
+}
+
+func (b BuilderComment) WithBody(body string) BuilderComment {
+	b.c.Body = body
+
+	return b
+}
+
+func (b BuilderComment) WithSubject(kind reviewing.CommentSubjectKind, id uuid.UUID) BuilderComment {
+	b.c.Subject = reviewing.CommentSubject{Kind: kind, ID: id}
+
+	return b
+}
+
+func (b BuilderComment) IsReplyTo(parent reviewing.Comment) BuilderComment {
+	b.c.ParentID = parent.ID
+	b.c.Subject = parent.Subject
+
+	return b
+}
+
+func (b BuilderComment) Build() reviewing.Comment {
+	return b.c
+}
@@ -60,6 +60,11 @@ func (b BuilderContributingCause) WithID(id uuid.UUID) BuilderContributingCause
 	return b
 }
 
+func (b BuilderContributingCause) WithTeam(id uuid.UUID) BuilderContributingCause {
+	b.c.TeamID = id
+	return b
+}
+
 func (b BuilderContributingCause) WithName(n string) BuilderContributingCause {
 	b.c.Name = n
 
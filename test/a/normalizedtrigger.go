@@ -48,6 +48,11 @@ func (b BuilderNormalizedTrigger) WithID(uuid uuid.UUID) BuilderNormalizedTrigge
 	return b
 }
 
+func (b BuilderNormalizedTrigger) WithTeam(id uuid.UUID) BuilderNormalizedTrigger {
+	b.t.TeamID = id
+	return b
+}
+
 func (b BuilderNormalizedTrigger) WithName(n string) BuilderNormalizedTrigger {
 	b.t.Name = n
 	return b
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/gaqzi/incident-reviewer/internal/normalized"
 	"github.com/gaqzi/incident-reviewer/internal/normalized/contributing"
 	"github.com/gaqzi/incident-reviewer/internal/reviewing"
 )
@@ -43,6 +44,7 @@ func (b BuilderReview) IsValid() BuilderReview {
 	b.r.Where = "At land"
 	b.r.ReportProximalCause = "Broken"
 	b.r.ReportTrigger = "Special operation"
+	b.r.State = reviewing.StateDraft
 
 	return b
 }
@@ -83,8 +85,34 @@ func (b BuilderReview) WithURL(url string) BuilderReview {
 	return b
 }
 
+// WithState prepares the reviewing.Review in the state without recording a transition.
+// Note: call it after adding causes since they can't be bound to a read-only review.
+func (b BuilderReview) WithState(state reviewing.State) BuilderReview {
+	b.r.State = state
+
+	return b
+}
+
 // Modify allows you to specify a custom override while preparing.
 // Note: consider naming your pattern and adding it to the builder.
+func (b BuilderReview) WithFacilitator(ids ...uuid.UUID) BuilderReview {
+	b.r.Facilitators = append(b.r.Facilitators, ids...)
+
+	return b
+}
+
+func (b BuilderReview) WithTeam(id uuid.UUID) BuilderReview {
+	b.r.TeamID = id
+
+	return b
+}
+
+func (b BuilderReview) WithParticipant(ids ...uuid.UUID) BuilderReview {
+	b.r.Participants = append(b.r.Participants, ids...)
+
+	return b
+}
+
 func (b BuilderReview) Modify(mods ...func(r *reviewing.Review)) BuilderReview {
 	for _, mod := range mods {
 		mod(&b.r)
@@ -109,6 +137,11 @@ func (b BuilderReview) WithContributingCause(rcs ...reviewing.BoundCause) Builde
 	return b
 }
 
+func (b BuilderReview) WithComment(cs ...reviewing.Comment) BuilderReview {
+	b.r.Comments = append(b.r.Comments, cs...)
+	return b
+}
+
 func (b BuilderReview) WithBoundTrigger(rt reviewing.BoundTrigger) BuilderReview {
 	b.r.BoundTriggers = append(b.r.BoundTriggers, rt)
 	return b
@@ -145,6 +178,7 @@ func (b BuilderBoundCause) IsValid() BuilderBoundCause {
 	b.rc.ID = uuid.MustParse("0193f6e0-a83b-71aa-a712-b0f7e0521108")
 	b.rc.Cause = ContributingCause().Build()
 	b.rc.Why = "We rely on basic internet infrastructure like everyone else"
+	b.rc.BoundAt = time.Date(2024, 12, 17, 19, 0, 0, 0, time.UTC)
 
 	return b
 }
@@ -155,6 +189,18 @@ func (b BuilderBoundCause) WithIsProximalCause(tf bool) BuilderBoundCause {
 	return b
 }
 
+func (b BuilderBoundCause) WithBoundAt(t time.Time) BuilderBoundCause {
+	b.rc.BoundAt = t
+
+	return b
+}
+
+func (b BuilderBoundCause) WithVote(vs ...reviewing.Vote) BuilderBoundCause {
+	b.rc.Votes = append(b.rc.Votes, vs...)
+
+	return b
+}
+
 func (b BuilderBoundCause) Build() reviewing.BoundCause {
 	return b.rc
 }
@@ -167,6 +213,7 @@ func (b BuilderBoundTrigger) IsSaved() BuilderBoundTrigger {
 	b.bt.ID = uuid.MustParse("0193f6e0-a83b-71aa-a712-b0f7e0521108")
 	b.bt.UnboundTrigger = UnboundTrigger().Build()
 	b.bt.Trigger = NormalizedTrigger().Build()
+	b.bt.BoundAt = time.Date(2024, 12, 17, 19, 0, 0, 0, time.UTC)
 
 	return b
 }
@@ -185,6 +232,26 @@ func (b BuilderBoundTrigger) WithID(id uuid.UUID) BuilderBoundTrigger {
 	return b
 }
 
+func (b BuilderBoundTrigger) WithTrigger(t normalized.Trigger) BuilderBoundTrigger {
+	b.bt.Trigger = t
+	return b
+}
+
+func (b BuilderBoundTrigger) WithWhy(why string) BuilderBoundTrigger {
+	b.bt.Why = why
+	return b
+}
+
+func (b BuilderBoundTrigger) WithBoundAt(t time.Time) BuilderBoundTrigger {
+	b.bt.BoundAt = t
+	return b
+}
+
+func (b BuilderBoundTrigger) WithVote(vs ...reviewing.Vote) BuilderBoundTrigger {
+	b.bt.Votes = append(b.bt.Votes, vs...)
+	return b
+}
+
 func BoundTrigger() BuilderBoundTrigger {
 	return BuilderBoundTrigger{}.
 		IsSaved()
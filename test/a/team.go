@@ -0,0 +1,64 @@
+package a
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/teams"
+)
+
+type BuilderTeam struct {
+	t teams.Team
+}
+
+func (b BuilderTeam) IsValid() BuilderTeam {
+	b.t.ID = uuid.MustParse("01966b2e-0cbf-7a6e-9d2b-5bd1a3c0f3a1") // UUIDv7, just a value, no particular meaning
+	b.t.Name = "Site Reliability"
+
+	return b
+}
+
+func (b BuilderTeam) IsSaved() BuilderTeam {
+	createdAt, err := time.Parse(time.RFC3339Nano, "2025-03-06T07:25:30.1337Z")
+	if err != nil {
+		panic("failed to parse example timestamp: " + err.Error())
+	}
+
+	b.t.CreatedAt = createdAt
+	b.t.UpdatedAt = createdAt
+
+	return b
+}
+
+func (b BuilderTeam) IsNotSaved() BuilderTeam {
+	b.t.CreatedAt = time.Time{}
+	b.t.UpdatedAt = time.Time{}
+
+	return b
+}
+
+func (b BuilderTeam) WithID(id uuid.UUID) BuilderTeam {
+	b.t.ID = id
+	return b
+}
+
+func (b BuilderTeam) WithName(n string) BuilderTeam {
+	b.t.Name = n
+	return b
+}
+
+func (b BuilderTeam) WithMember(ids ...uuid.UUID) BuilderTeam {
+	b.t.Members = append(b.t.Members, ids...)
+	return b
+}
+
+func (b BuilderTeam) Build() teams.Team {
+	return b.t
+}
+
+func Team() BuilderTeam {
+	return BuilderTeam{}.
+		IsValid().
+		IsSaved()
+}
@@ -0,0 +1,68 @@
+package a
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+type BuilderTemplate struct {
+	t reviewing.Template
+}
+
+func (b BuilderTemplate) IsValid() BuilderTemplate {
+	b.t.ID = uuid.MustParse("0197a1c4-5e2f-7b3d-8c4e-2f6a9b1d3e5c") // UUIDv7, just a value, no particular meaning
+	b.t.Name = "Security incident"
+	b.t.Guidance = "Look into how the access was gained and how long it went unnoticed."
+	b.t.Fields = []reviewing.TemplateField{
+		{Name: "Data exposed", Prompt: "What data could have been accessed?"},
+	}
+
+	return b
+}
+
+func (b BuilderTemplate) IsSaved() BuilderTemplate {
+	createdAt, err := time.Parse(time.RFC3339Nano, "2025-03-06T07:25:30.1337Z")
+	if err != nil {
+		panic("failed to parse example timestamp: " + err.Error())
+	}
+
+	b.t.CreatedAt = createdAt
+	b.t.UpdatedAt = createdAt
+
+	return b
+}
+
+func (b BuilderTemplate) IsNotSaved() BuilderTemplate {
+	b.t.CreatedAt = time.Time{}
+	b.t.UpdatedAt = time.Time{}
+
+	return b
+}
+
+func (b BuilderTemplate) WithID(id uuid.UUID) BuilderTemplate {
+	b.t.ID = id
+	return b
+}
+
+func (b BuilderTemplate) WithName(n string) BuilderTemplate {
+	b.t.Name = n
+	return b
+}
+
+func (b BuilderTemplate) WithFields(fs ...reviewing.TemplateField) BuilderTemplate {
+	b.t.Fields = fs
+	return b
+}
+
+func (b BuilderTemplate) Build() reviewing.Template {
+	return b.t
+}
+
+func Template() BuilderTemplate {
+	return BuilderTemplate{}.
+		IsValid().
+		IsSaved()
+}
@@ -0,0 +1,94 @@
+package a
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+)
+
+type BuilderUser struct {
+	u accounts.User
+}
+
+func User() BuilderUser {
+	return BuilderUser{}.
+		IsValid().
+		IsSaved()
+}
+
+func (b BuilderUser) IsValid() BuilderUser {
+	b.u.ID = uuid.MustParse("0196a3e1-5c2f-7b8d-a4e6-9f1d3c7b2a50")
+	b.u.Email = "facilitator@example.com"
+	b.u.Name = "Fran Facilitator"
+	b.u.Role = actor.RoleFacilitator
+
+	return b
+}
+
+func (b BuilderUser) IsSaved() BuilderUser {
+	createdAt, err := time.Parse(time.RFC3339Nano, "2025-05-06T10:20:30.4050Z")
+	if err != nil {
+		panic("failed to parse example timestamp: " + err.Error())
+	}
+	b.u.CreatedAt = createdAt
+	b.u.UpdatedAt = createdAt
+
+	return b
+}
+
+func (b BuilderUser) IsNotSaved() BuilderUser {
+	b.u.CreatedAt = time.Time{}
+	b.u.UpdatedAt = time.Time{}
+
+	return b
+}
+
+func (b BuilderUser) WithID(id uuid.UUID) BuilderUser {
+	b.u.ID = id
+
+	return b
+}
+
+func (b BuilderUser) WithEmail(email string) BuilderUser {
+	b.u.Email = email
+
+	return b
+}
+
+func (b BuilderUser) WithRole(role actor.Role) BuilderUser {
+	b.u.Role = role
+
+	return b
+}
+
+func (b BuilderUser) WithPassword(password string) BuilderUser {
+	u, err := b.u.SetPassword(password)
+	if err != nil {
+		panic("failed to set password: " + err.Error())
+	}
+	b.u = u
+
+	return b
+}
+
+func (b BuilderUser) WithIdentity(issuer, subject string) BuilderUser {
+	b.u.Issuer = issuer
+	b.u.Subject = subject
+
+	return b
+}
+
+func (b BuilderUser) Modify(mods ...func(u *accounts.User)) BuilderUser {
+	for _, mod := range mods {
+		mod(&b.u)
+	}
+
+	return b
+}
+
+func (b BuilderUser) Build() accounts.User {
+	return b.u
+}
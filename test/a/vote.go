@@ -0,0 +1,68 @@
+package a
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+type BuilderVote struct {
+	v reviewing.Vote
+}
+
+func Vote() BuilderVote {
+	return BuilderVote{}.
+		IsValid().
+		IsSaved()
+}
+
+func (b BuilderVote) IsValid() BuilderVote {
+	b.v.VoterID = User().Build().ID
+	b.v.Direction = reviewing.VoteFor
+	b.v.Reason = "It's what the graphs show"
+
+	return b
+}
+
+func (b BuilderVote) IsSaved() BuilderVote {
+	createdAt, err := time.Parse(time.RFC3339Nano, "2025-04-02T09:12:45.1337Z")
+	if err != nil {
+		panic("failed to parse example timestamp: " + err.Error())
+	}
+
+	b.v.ID = uuid.MustParse("0195f5d2-3a8b-7c41-9d2e-5b1f0c7a9e34")
+	b.v.CreatedAt = createdAt
+
+	return b
+}
+
+func (b BuilderVote) IsNotSaved() BuilderVote {
+	b.v.ID = uuid.Nil
+	b.v.CreatedAt = time.Time{}
+
+	return b
+}
+
+func (b BuilderVote) IsAgainst() BuilderVote {
+	b.v.Direction = reviewing.VoteAgainst
+
+	return b
+}
+
+func (b BuilderVote) WithVoter(id uuid.UUID) BuilderVote {
+	b.v.VoterID = id
+
+	return b
+}
+
+func (b BuilderVote) WithReason(reason string) BuilderVote {
+	b.v.Reason = reason
+
+	return b
+}
+
+func (b BuilderVote) Build() reviewing.Vote {
+	return b.v
+}
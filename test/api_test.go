@@ -0,0 +1,197 @@
+package test_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/internal/app"
+	"github.com/gaqzi/incident-reviewer/internal/app/api"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+func TestAPI(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cfg := app.NewConfig()
+	cfg.Addr = "localhost:0"
+	cfg.AttachmentsDir = t.TempDir()
+	cfg.OutboxDir = t.TempDir()
+	cfg.SecureCookies = false // the test server isn't using TLS
+	cfg.AdminPassword = "a password for the api"
+	server, err := app.Start(ctx, cfg)
+	require.NoError(t, err, "failed to start the server")
+	defer (func() { _ = server.Stop(context.Background()) })()
+	baseURL := "http://" + server.Config.Addr + "/api/v1"
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	client := &http.Client{Jar: jar}
+
+	do := func(t *testing.T, method, path string, in any, out any) *http.Response {
+		t.Helper()
+		var body bytes.Buffer
+		if in != nil {
+			require.NoError(t, json.NewEncoder(&body).Encode(in))
+		}
+		req, err := http.NewRequestWithContext(ctx, method, baseURL+path, &body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+
+		return resp
+	}
+
+	t.Run("without signing in it's unauthorized", func(t *testing.T) {
+		var out struct{ Error api.Error }
+		resp := do(t, http.MethodGet, "/reviews", nil, &out)
+
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		require.Equal(t, http.StatusUnauthorized, out.Error.Status)
+	})
+
+	t.Run("the OpenAPI document is available without signing in", func(t *testing.T) {
+		var doc api.Document
+		resp, err := client.Get("http://" + server.Config.Addr + "/api/openapi.json")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, doc.Paths, "/reviews/{id}")
+	})
+
+	resp, err := client.PostForm("http://"+server.Config.Addr+"/login", url.Values{"email": {cfg.AdminEmail}, "password": {cfg.AdminPassword}})
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected to be signed in")
+
+	t.Run("a review missing fields says which fields are wrong", func(t *testing.T) {
+		var out struct{ Error api.Error }
+		resp := do(t, http.MethodPost, "/reviews", api.ReviewInput{Title: "Only a title"}, &out)
+
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		require.Equal(t, "Can't be empty.", out.Error.Fields["url"])
+		require.Equal(t, "Can't be empty.", out.Error.Fields["reportTrigger"])
+		require.NotContains(t, out.Error.Fields, "title")
+	})
+
+	t.Run("a review that doesn't exist is not found", func(t *testing.T) {
+		resp := do(t, http.MethodGet, "/reviews/0190a0a0-0000-7000-8000-000000000000", nil, nil)
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("creating a review and binding a contributing cause to it", func(t *testing.T) {
+		var review api.Review
+		resp := do(t, http.MethodPost, "/reviews", api.ReviewInput{
+			URL:                 "https://example.com/incidents/1",
+			Title:               "Through the API",
+			Description:         "A review made by **a tool**",
+			Impact:              "Nobody noticed",
+			Where:               "Everywhere",
+			ReportProximalCause: "A cause",
+			ReportTrigger:       "A trigger",
+		}, &review)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Equal(t, reviewing.StateDraft, review.State)
+
+		var causes []api.Cause
+		do(t, http.MethodGet, "/contributing-causes", nil, &causes)
+		require.NotEmpty(t, causes, "expected the seeded contributing causes")
+
+		var bound api.BoundCause
+		resp = do(t, http.MethodPost, "/reviews/"+review.ID.String()+"/contributing-causes", api.BoundCauseInput{
+			ContributingCauseID: causes[0].ID,
+			Why:                 "It's the [first one](https://example.com/causes)",
+		}, &bound)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Equal(t, causes[0].ID, bound.ContributingCause.ID)
+
+		var got api.Review
+		do(t, http.MethodGet, "/reviews/"+review.ID.String(), nil, &got)
+		require.Len(t, got.BoundCauses, 1)
+		require.Equal(t, bound.ID, got.BoundCauses[0].ID)
+		require.Equal(t, "A review made by a tool", got.DescriptionText, "expected a plain text version of what's written as Markdown")
+		require.Equal(t, "It's the first one (https://example.com/causes)", got.BoundCauses[0].WhyText)
+	})
+
+	t.Run("a read-only API token can read but not change anything", func(t *testing.T) {
+		resp, err := client.PostForm("http://"+server.Config.Addr+"/tokens", url.Values{"name": {"a script"}, "scope": {string(accounts.ScopeReadOnly)}})
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		require.NoError(t, err)
+		match := regexp.MustCompile(`<code class="secret">([^<]+)</code>`).FindSubmatch(body)
+		require.NotNil(t, match, "expected the new token to be shown")
+
+		withToken := func(method, path string, in any) *http.Response {
+			var body bytes.Buffer
+			require.NoError(t, json.NewEncoder(&body).Encode(in))
+			req, err := http.NewRequestWithContext(ctx, method, baseURL+path, &body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+string(match[1]))
+
+			// Without the cookies of the session, so only the token signs in
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			return resp
+		}
+
+		require.Equal(t, http.StatusOK, withToken(http.MethodGet, "/reviews", nil).StatusCode)
+		require.Equal(t, http.StatusForbidden, withToken(http.MethodPost, "/reviews", api.ReviewInput{Title: "Not allowed"}).StatusCode)
+	})
+
+	t.Run("a body that isn't JSON is not accepted", func(t *testing.T) {
+		resp, err := client.Post(baseURL+"/reviews", "application/x-www-form-urlencoded", bytes.NewBufferString("title=nope"))
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+
+		require.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+
+	// Last, since every review created after it has to fill in the custom field
+	t.Run("a required custom field is reported like the other fields", func(t *testing.T) {
+		resp, err := client.PostForm("http://"+server.Config.Addr+"/custom-fields", url.Values{"name": {"Region"}, "kind": {"text"}, "required": {"on"}})
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, "expected the custom field to be created")
+
+		var out struct{ Error api.Error }
+		resp = do(t, http.MethodPost, "/reviews", api.ReviewInput{
+			URL:                 "https://example.com/incidents/2",
+			Title:               "Without the region",
+			Description:         "A review made by a tool",
+			Impact:              "Nobody noticed",
+			Where:               "Everywhere",
+			ReportProximalCause: "A cause",
+			ReportTrigger:       "A trigger",
+		}, &out)
+
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		require.Len(t, out.Error.Fields, 1, "expected only the custom field to be wrong")
+		for _, msg := range out.Error.Fields {
+			require.Equal(t, "Can't be empty.", msg)
+		}
+	})
+}
@@ -0,0 +1,214 @@
+package test_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/app"
+)
+
+// selectOptionStartingWith picks the option of the select whose text starts with prefix,
+// by value since selecting by long labels breaks in firefox.
+func selectOptionStartingWith(t *testing.T, sel playwright.Locator, prefix string) {
+	t.Helper()
+
+	value, err := sel.Locator("option").Filter(playwright.LocatorFilterOptions{HasText: prefix}).First().GetAttribute("value")
+	require.NoError(t, err, "failed to find an option starting with %q", prefix)
+	_, err = sel.SelectOption(playwright.SelectOptionValues{Values: &[]string{value}})
+	require.NoError(t, err, "failed to select %q", prefix)
+}
+
+func TestReviewingWithoutJavaScript(t *testing.T) {
+	t.Run("Every form works as a plain form post", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		cfg := app.NewConfig()
+		cfg.Addr = "localhost:0" // bind to localhost to avoid firewall warnings
+		cfg.AttachmentsDir = t.TempDir()
+		cfg.OutboxDir = t.TempDir()
+		cfg.AdminPassword = "a password for testing"
+		server, err := app.Start(ctx, cfg)
+		require.NoError(t, err, "failed to start the server")
+		defer (func() { _ = server.Stop(context.Background()) })()
+
+		pw, err := playwright.Run()
+		require.NoError(t, err, "could not start playwright")
+		defer (func() { _ = pw.Stop() })()
+		browser, err := getBrowser(pw).Launch(playwright.BrowserTypeLaunchOptions{
+			Headless: playwright.Bool(Headful),
+		})
+		require.NoError(t, err, "failed to launch the browser")
+		browserCtx, err := browser.NewContext(playwright.BrowserNewContextOptions{
+			JavaScriptEnabled: playwright.Bool(false),
+		})
+		require.NoError(t, err, "failed to create a browser context without javascript")
+		page, err := browserCtx.NewPage()
+		require.NoError(t, err, "could not create page")
+		assert := playwright.NewPlaywrightAssertions()
+		flash := page.Locator(".flash")
+
+		_, err = page.Goto("http://" + server.Config.Addr + "/reviews")
+		require.NoError(t, err, "failed to open page")
+		require.NoError(t, page.Locator(`.login [name="email"]`).Fill(cfg.AdminEmail))
+		require.NoError(t, page.Locator(`.login [name="password"]`).Fill(cfg.AdminPassword))
+		require.NoError(t, page.Locator(`.login button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(page.Locator(`.session .currentUser`)).ToHaveText("Admin"))
+
+		// Creating a review sends us back to the listing, where the flash links to it
+		form := page.Locator(".new form")
+		require.NoError(t, form.Locator(`[name="url"]`).Fill("https://example.com/incident/2"))
+		require.NoError(t, form.Locator(`[name="title"]`).Fill("Checkout down after a certificate expired"))
+		require.NoError(t, form.Locator(`[name="description"]`).Fill("Nobody could pay for about an hour"))
+		require.NoError(t, form.Locator(`[name="impact"]`).Fill("All orders for an hour"))
+		require.NoError(t, form.Locator(`[name="where"]`).Fill("Everywhere"))
+		require.NoError(t, form.Locator(`[name="reportProximalCause"]`).Fill("An expired certificate"))
+		require.NoError(t, form.Locator(`[name="reportTrigger"]`).Fill("The date changing"))
+		require.NoError(t, form.Locator(`[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("created"))
+		require.NoError(t, assert.Locator(page.Locator(".listing ul li")).ToHaveCount(1))
+
+		require.NoError(t, flash.Locator("a").Click())
+		require.NoError(t, assert.Locator(page.Locator(".details .title")).ToHaveText("Checkout down after a certificate expired"))
+		require.NoError(t, assert.Locator(flash).ToHaveCount(0), "expected the flash to only be shown once")
+
+		causesForm := page.Locator(`contributing-causes form.new`)
+		selectOptionStartingWith(t, causesForm.Locator(`[name="contributingCauseID"]`), "Third party outage")
+		require.NoError(t, causesForm.Locator(`[name="why"]`).Fill("The certificate was issued by them"))
+		require.NoError(t, causesForm.Locator(`button.bind[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("Added the contributing cause"))
+		firstCause := page.Locator(`contributing-causes ul.listing li`).First()
+		require.NoError(t, assert.Locator(firstCause.Locator(".contributingCause")).ToContainText("Third party outage"))
+
+		// Editing is a page of its own that sends us back to the review
+		require.NoError(t, firstCause.Locator(`button.edit`).Click())
+		require.NoError(t, page.Locator(`.edit [name="why"]`).Fill("They issued it and we didn't monitor it"))
+		require.NoError(t, page.Locator(`.edit button.bind[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("Saved the contributing cause"))
+		require.NoError(t, assert.Locator(firstCause.Locator(".why")).ToHaveText("They issued it and we didn't monitor it"))
+
+		require.NoError(t, firstCause.Locator(`form.vote button.vote-up`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("Counted your vote"))
+		require.NoError(t, assert.Locator(firstCause.Locator(`form.vote .vote-up .count`)).ToHaveText("1"))
+
+		// Proposing a new cause leaves the review and comes back to it with the cause to pick
+		require.NoError(t, causesForm.Locator(`.propose`).Click())
+		newCauseForm := page.Locator(`form[action="/contributing-causes"]`)
+		require.NoError(t, newCauseForm.Locator(`[name="name"]`).Fill("__Expired certificate__"))
+		require.NoError(t, newCauseForm.Locator(`[name="description"]`).Fill("A certificate wasn't renewed in time"))
+		_, err = newCauseForm.Locator(`[name="category"]`).SelectOption(playwright.SelectOptionValues{Values: &[]string{"Deployment"}})
+		require.NoError(t, err)
+		require.NoError(t, newCauseForm.Locator(`button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("__Expired certificate__"))
+		require.NoError(t, assert.Locator(page.Locator(".details .title")).ToHaveText("Checkout down after a certificate expired"))
+		require.NoError(t, assert.Locator(causesForm.Locator(`option`).Filter(playwright.LocatorFilterOptions{HasText: "__Expired certificate__"})).ToHaveCount(1))
+
+		// Triggers work the same way
+		triggerForm := page.Locator(`#triggers form.new`)
+		selectOptionStartingWith(t, triggerForm.Locator(`[name="triggerID"]`), "Traffic increase")
+		require.NoError(t, triggerForm.Locator(`[name="why"]`).Fill("Everyone retried their payment"))
+		require.NoError(t, triggerForm.Locator(`button.bind[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("Added the trigger"))
+		firstTrigger := page.Locator(`#triggers ul.listing li`).First()
+		require.NoError(t, assert.Locator(firstTrigger.Locator(".name")).ToContainText("Traffic increase"))
+
+		require.NoError(t, firstTrigger.Locator(`button.edit`).Click())
+		require.NoError(t, page.Locator(`.edit [name="why"]`).Fill("Everyone retried their payment, many times"))
+		require.NoError(t, page.Locator(`.edit button.bind[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("Saved the trigger"))
+		require.NoError(t, assert.Locator(firstTrigger.Locator(".why")).ToHaveText("Everyone retried their payment, many times"))
+
+		require.NoError(t, triggerForm.Locator(`.propose`).Click())
+		newTriggerForm := page.Locator(`form[action="/triggers"]`)
+		require.NoError(t, newTriggerForm.Locator(`[name="name"]`).Fill("__Date change__"))
+		require.NoError(t, newTriggerForm.Locator(`[name="description"]`).Fill("The clock passing a point in time"))
+		require.NoError(t, newTriggerForm.Locator(`button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("__Date change__"))
+		require.NoError(t, assert.Locator(triggerForm.Locator(`option`).Filter(playwright.LocatorFilterOptions{HasText: "__Date change__"})).ToHaveCount(1))
+
+		// And the discussion
+		discussion := page.Locator(`.discussion .comments`).First()
+		require.NoError(t, discussion.Locator(`details summary`).Last().Click())
+		require.NoError(t, discussion.Locator(`form.comment [name="body"]`).Fill("Who gets the expiry alerts?"))
+		require.NoError(t, discussion.Locator(`form.comment button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("Added your comment"))
+		require.NoError(t, assert.Locator(discussion.Locator(`ul.threads > li.comment .body`).First()).ToHaveText("Who gets the expiry alerts?"))
+
+		// The bound cause links to how it's been used on the reviews
+		require.NoError(t, firstCause.Locator(`.contributingCause a`).Click())
+		require.NoError(t, assert.Locator(page.Locator(`.catalogEntry .name`)).ToHaveText("Third party outage"))
+		require.NoError(t, assert.Locator(page.Locator(`.usage .reviews`)).ToHaveText("1"))
+		require.NoError(t, assert.Locator(page.Locator(`.usage .uses .why`)).ToHaveText("They issued it and we didn't monitor it"))
+
+		// A review created from a template is asked what the template asks
+		_, err = page.Goto("http://" + server.Config.Addr + "/review-templates")
+		require.NoError(t, err, "failed to open page")
+		templateForm := page.Locator(`form.new-template`)
+		require.NoError(t, templateForm.Locator(`[name="name"]`).Fill("Security incident"))
+		require.NoError(t, templateForm.Locator(`[name="guidance"]`).Fill("Check who could have had access"))
+		require.NoError(t, templateForm.Locator(`[name="fields"]`).Fill("Data exposed: What data could have been read?"))
+		selectOptionStartingWith(t, templateForm.Locator(`[name="suggestedCauseIDs"]`), "Third party outage")
+		require.NoError(t, templateForm.Locator(`button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("Created the review template"))
+
+		_, err = page.Goto("http://" + server.Config.Addr + "/reviews")
+		require.NoError(t, err, "failed to open page")
+		selectOptionStartingWith(t, page.Locator(`form.template [name="template"]`), "Security incident")
+		newForm := page.Locator(`.new form[method="post"]`)
+		require.NoError(t, page.Locator(`form.template button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(newForm.Locator(`.guidance`)).ToHaveText("Check who could have had access"))
+		require.NoError(t, newForm.Locator(`[name="url"]`).Fill("https://example.com/incident/3"))
+		require.NoError(t, newForm.Locator(`[name="title"]`).Fill("Customer emails readable by anyone"))
+		require.NoError(t, newForm.Locator(`[name="description"]`).Fill("A bucket was public"))
+		require.NoError(t, newForm.Locator(`[name="impact"]`).Fill("Every customer's email"))
+		require.NoError(t, newForm.Locator(`[name="where"]`).Fill("Storage"))
+		require.NoError(t, newForm.Locator(`[name="reportProximalCause"]`).Fill("A public bucket"))
+		require.NoError(t, newForm.Locator(`[name="reportTrigger"]`).Fill("A policy change"))
+		require.NoError(t, newForm.Locator(`.answer textarea`).Fill("Email addresses"))
+		require.NoError(t, newForm.Locator(`[type="submit"]`).Click())
+		require.NoError(t, flash.Locator("a").Click())
+		require.NoError(t, assert.Locator(page.Locator(`.details .answers .answer`)).ToHaveText("Email addresses"))
+		require.NoError(t, assert.Locator(causesForm.Locator(`optgroup.suggested option`)).ToContainText("Third party outage"))
+
+		// Custom fields are filled in on the review and can be filtered by on the listing
+		_, err = page.Goto("http://" + server.Config.Addr + "/custom-fields")
+		require.NoError(t, err, "failed to open page")
+		fieldForm := page.Locator(`form.new-field`)
+		require.NoError(t, fieldForm.Locator(`[name="name"]`).Fill("Region"))
+		_, err = fieldForm.Locator(`[name="kind"]`).SelectOption(playwright.SelectOptionValues{Values: &[]string{"enum"}})
+		require.NoError(t, err)
+		require.NoError(t, fieldForm.Locator(`[name="options"]`).Fill("EU\nUS"))
+		require.NoError(t, fieldForm.Locator(`button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("Created the custom field"))
+
+		_, err = page.Goto("http://" + server.Config.Addr + "/reviews")
+		require.NoError(t, err, "failed to open page")
+		require.NoError(t, page.Locator(`.listing ul li a`).Filter(playwright.LocatorFilterOptions{HasText: "Customer emails readable by anyone"}).Click())
+		require.NoError(t, page.Locator(`.details form[method="GET"] button[type="submit"]`).Click())
+		_, err = page.Locator(`.details li.custom select`).SelectOption(playwright.SelectOptionValues{Values: &[]string{"EU"}})
+		require.NoError(t, err)
+		require.NoError(t, page.Locator(`.details form[method="POST"] button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(page.Locator(`.details .custom .value`)).ToHaveText("EU"))
+
+		// Files are attached with a plain multipart form post and can be removed again
+		attachments := page.Locator(`section#attachments`)
+		require.NoError(t, attachments.Locator(`summary`).Click())
+		require.NoError(t, attachments.Locator(`[name="file"]`).SetInputFiles([]playwright.InputFile{{Name: "bucket-policy.txt", MimeType: "text/plain", Buffer: []byte("Principal: *")}}))
+		require.NoError(t, attachments.Locator(`form.attach button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("Attached bucket-policy.txt"))
+		require.NoError(t, assert.Locator(attachments.Locator(`.attachment .name`)).ToHaveText("bucket-policy.txt"))
+		require.NoError(t, attachments.Locator(`.attachment button.remove`).Click())
+		require.NoError(t, assert.Locator(flash).ToContainText("Removed bucket-policy.txt"))
+		require.NoError(t, assert.Locator(attachments.Locator(`.attachment`)).ToHaveCount(0))
+
+		_, err = page.Goto("http://" + server.Config.Addr + "/reviews")
+		require.NoError(t, err, "failed to open page")
+		_, err = page.Locator(`form.filter select`).Last().SelectOption(playwright.SelectOptionValues{Values: &[]string{"EU"}})
+		require.NoError(t, err)
+		require.NoError(t, page.Locator(`form.filter button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(page.Locator(".listing ul li")).ToHaveCount(1))
+	})
+}
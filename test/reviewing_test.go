@@ -45,6 +45,9 @@ func TestReviewing(t *testing.T) {
 		defer cancel()
 		cfg := app.NewConfig()
 		cfg.Addr = "localhost:0" // bind to localhost to avoid firewall warnings
+		cfg.AttachmentsDir = t.TempDir()
+		cfg.OutboxDir = t.TempDir()
+		cfg.AdminPassword = "a password for testing"
 		server, err := app.Start(ctx, cfg)
 		require.NoError(t, err, "failed to start the server")
 		defer (func() { _ = server.Stop(context.Background()) })()
@@ -63,6 +66,12 @@ func TestReviewing(t *testing.T) {
 		_, err = page.Goto("http://" + server.Config.Addr + "/reviews")
 		require.NoError(t, err, "failed to open page")
 
+		// Going to the reviews without being signed in sends us to sign in first, and then back to the reviews
+		require.NoError(t, page.Locator(`.login [name="email"]`).Fill(cfg.AdminEmail))
+		require.NoError(t, page.Locator(`.login [name="password"]`).Fill(cfg.AdminPassword))
+		require.NoError(t, page.Locator(`.login button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(page.Locator(`.session .currentUser`)).ToHaveText("Admin"))
+
 		require.NoError(t, assert.Locator(page.Locator(".listing ul li")).ToHaveCount(0), "expected to not have any reviews before creating one")
 
 		form := page.Locator(".new form")
@@ -101,7 +110,12 @@ func TestReviewing(t *testing.T) {
 		require.NoError(t, page.Locator(`.details form button[type="submit"]`).Click())
 
 		require.NoError(t, page.Locator(`.details form [name="title"]`).Fill("Broken cable undersea"))
+		require.NoError(t, page.Locator(`.details form [name="impact"]`).Fill("Higher latency for:\n\n- **Latvia**\n- Estonia"))
+		impactPreview := page.Locator(`.details form [name="impact"] + details.preview`)
+		require.NoError(t, impactPreview.Locator(`summary`).Click())
+		require.NoError(t, assert.Locator(impactPreview.Locator(`.rendered li strong`)).ToHaveText("Latvia"))
 		require.NoError(t, page.Locator(`.details form button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(page.Locator(`.details .impact li`)).ToHaveCount(2))
 
 		require.NoError(t, assert.Locator(page.Locator(`.details .title`)).ToHaveText("Broken cable undersea"))
 		newCreatedAt, err := page.Locator(".details .createdAt").GetAttribute("datetime")
@@ -158,7 +172,7 @@ func TestReviewing(t *testing.T) {
 		// but first, let's fill in the why for the new cause first, and make sure it stays around while we add the new cause,
 		// so that we don't lose important information while saving stuff.
 		require.NoError(t, causesForm.Locator(`[name="why"]`).Fill("look, it just fits!"))
-		require.NoError(t, causesForm.Locator(`#causes button.propose`).Click())
+		require.NoError(t, causesForm.Locator(`#causes .propose`).Click())
 
 		newCauseForm := causesForm.Locator("#causes form")
 		require.NoError(t, newCauseForm.Locator(`[name="name"]`).Fill("__Inconceivable__"))
@@ -188,6 +202,12 @@ func TestReviewing(t *testing.T) {
 		require.NoError(t, firstCause.Locator(`button.bind[type="submit"]`).Click())
 		require.NoError(t, assert.Locator(firstCause.Locator(".why")).ToContainText("I want to say something else now"))
 
+		// Vote for the cause with a reason and see the tally update
+		require.NoError(t, firstCause.Locator(`form.vote [name="reason"]`).Fill("The graphs line up"))
+		require.NoError(t, firstCause.Locator(`form.vote button.vote-up`).Click())
+		require.NoError(t, assert.Locator(firstCause.Locator(`form.vote .vote-up .count`)).ToHaveText("1"))
+		require.NoError(t, assert.Locator(firstCause.Locator(`.reasonsFor li`)).ToContainText("The graphs line up"))
+
 		// Add a trigger
 		triggerForm := page.Locator(`#triggers form.new`)
 		options, err = triggerForm.Locator(`[name="triggerID"] option`).All() // TODO: extract selecting an option into a helper
@@ -236,7 +256,7 @@ func TestReviewing(t *testing.T) {
 		// but first, let's fill in the why for the new trigger first, and make sure it stays around while we add the new trigger,
 		// so that we don't lose important information while saving stuff.
 		require.NoError(t, triggerForm.Locator(`[name="why"]`).Fill("this is a critical trigger!"))
-		require.NoError(t, triggerForm.Locator(`button.propose`).Click())
+		require.NoError(t, triggerForm.Locator(`.propose`).Click())
 
 		newTriggerForm := triggerForm.Locator("form")
 		require.NoError(t, newTriggerForm.Locator(`[name="name"]`).Fill("__New Trigger__"))
@@ -278,6 +298,19 @@ func TestReviewing(t *testing.T) {
 		}
 		require.True(t, hasNewTrigger, "expected to have found the new trigger in the list of options, found triggers: %s", foundTriggers)
 
+		// Discuss the review and reply to ourselves
+		discussion := page.Locator(`.discussion .comments`).First()
+		require.NoError(t, discussion.Locator(`details summary`).Last().Click())
+		require.NoError(t, discussion.Locator(`form.comment [name="body"]`).Fill("Should we also look at the deploy?"))
+		require.NoError(t, discussion.Locator(`form.comment button[type="submit"]`).Click())
+		firstComment := discussion.Locator(`ul.threads > li.comment`).First()
+		require.NoError(t, assert.Locator(firstComment.Locator(`.body`)).ToHaveText("Should we also look at the deploy?"))
+
+		require.NoError(t, firstComment.Locator(`details:has(form.reply) summary`).Click())
+		require.NoError(t, firstComment.Locator(`form.reply [name="body"]`).Fill("Yes, it went out right before"))
+		require.NoError(t, firstComment.Locator(`form.reply button[type="submit"]`).Click())
+		require.NoError(t, assert.Locator(firstComment.Locator(`ul.replies .body`)).ToHaveText("Yes, it went out right before"))
+
 		require.NoError(t, pw.Stop(), "failed to stop playwright")
 	})
 }
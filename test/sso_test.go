@@ -0,0 +1,92 @@
+package test_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/accounts"
+	"github.com/gaqzi/incident-reviewer/internal/app"
+	"github.com/gaqzi/incident-reviewer/internal/platform/actor"
+	"github.com/gaqzi/incident-reviewer/test"
+)
+
+func TestSingleSignOn(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	idp, err := test.StartOIDCProvider()
+	require.NoError(t, err)
+	defer idp.Close()
+
+	cfg := app.NewConfig()
+	cfg.Addr = "localhost:0"
+	cfg.AttachmentsDir = t.TempDir()
+	cfg.OutboxDir = t.TempDir()
+	cfg.SecureCookies = false // the test server isn't using TLS
+	cfg.LocalLogin = false
+	cfg.OIDC.IssuerURL = idp.URL
+	cfg.OIDC.ClientID = idp.ClientID
+	cfg.OIDC.ClientSecret = idp.ClientSecret
+	cfg.GroupRoles = accounts.GroupRoles{"sre": actor.RoleFacilitator}
+	server, err := app.Start(ctx, cfg)
+	require.NoError(t, err, "failed to start the server")
+	defer (func() { _ = server.Stop(context.Background()) })()
+	baseURL := "http://" + server.Config.Addr
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	client := &http.Client{Jar: jar}
+
+	get := func(t *testing.T, path string) (*http.Response, string) {
+		t.Helper()
+		resp, err := client.Get(baseURL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp, string(body)
+	}
+
+	t.Run("only signing in through the provider is offered when local login is off", func(t *testing.T) {
+		resp, body := get(t, "/reviews")
+
+		require.Equal(t, "/login", resp.Request.URL.Path, "expected to be sent to sign in")
+		require.Contains(t, body, `href="/login/oidc?next=%2freviews"`)
+		require.NotContains(t, body, `name="password"`)
+
+		resp, err := client.PostForm(baseURL+"/login", url.Values{"email": {"admin@example.com"}, "password": {"a password"}})
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+
+	t.Run("signing in through the provider comes back signed in to where you were going", func(t *testing.T) {
+		idp.SignInAs(map[string]any{
+			"sub":    "1234",
+			"email":  "fran@example.com",
+			"name":   "Fran Facilitator",
+			"groups": []string{"sre"},
+		})
+
+		resp, body := get(t, "/login/oidc?next=/reviews")
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "/reviews", resp.Request.URL.Path)
+		require.Contains(t, body, "Fran Facilitator")
+		require.Contains(t, body, "(facilitator)", "expected the role from the groups")
+	})
+
+	t.Run("a callback that wasn't started here is rejected", func(t *testing.T) {
+		resp, _ := get(t, "/login/oidc/callback?code=made-up&state=made-up")
+
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
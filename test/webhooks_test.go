@@ -0,0 +1,126 @@
+package test_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/incident-reviewer/internal/app"
+	"github.com/gaqzi/incident-reviewer/internal/app/api"
+	"github.com/gaqzi/incident-reviewer/internal/intake"
+	"github.com/gaqzi/incident-reviewer/internal/reviewing"
+)
+
+func TestIncidentWebhooks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	sourcesPath := filepath.Join(t.TempDir(), "incident-webhooks.json")
+	require.NoError(t, os.WriteFile(sourcesPath, []byte(`[{"name": "pagerduty", "secret": "s3cret", "mapper": "pagerduty"}]`), 0o600))
+	source := intake.Source{Secret: "s3cret"}
+
+	cfg := app.NewConfig()
+	cfg.Addr = "localhost:0"
+	cfg.AttachmentsDir = t.TempDir()
+	cfg.OutboxDir = t.TempDir()
+	cfg.SecureCookies = false // the test server isn't using TLS
+	cfg.AdminPassword = "a password for the webhooks"
+	cfg.IncidentSourcesPath = sourcesPath
+	server, err := app.Start(ctx, cfg)
+	require.NoError(t, err, "failed to start the server")
+	defer (func() { _ = server.Stop(context.Background()) })()
+	baseURL := "http://" + server.Config.Addr
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	client := &http.Client{Jar: jar}
+
+	deliver := func(t *testing.T, source string, payload []byte, signature string) (*http.Response, api.WebhookResult) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/webhooks/incidents/"+source, bytes.NewReader(payload))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(api.SignatureHeader, signature)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var out api.WebhookResult
+		if resp.StatusCode < http.StatusBadRequest {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		}
+
+		return resp, out
+	}
+
+	payload := []byte(`{"event": {
+		"event_type": "incident.resolved",
+		"occurred_at": "2025-05-06T11:30:00Z",
+		"data": {
+			"id": "PGR0VU2",
+			"title": "Checkout is failing",
+			"html_url": "https://acme.pagerduty.com/incidents/PGR0VU2",
+			"urgency": "high",
+			"created_at": "2025-05-06T10:00:00Z",
+			"service": {"summary": "Payments API"}
+		}
+	}}`)
+
+	var reviewID string
+	t.Run("a resolved incident creates a draft review", func(t *testing.T) {
+		resp, out := deliver(t, "pagerduty", payload, source.Sign(payload))
+
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Equal(t, intake.OutcomeCreated, out.Outcome)
+		require.NotNil(t, out.ReviewID)
+		reviewID = out.ReviewID.String()
+	})
+
+	t.Run("delivering the incident again doesn't create another review", func(t *testing.T) {
+		resp, out := deliver(t, "pagerduty", payload, source.Sign(payload))
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, intake.OutcomeDuplicate, out.Outcome)
+		require.Equal(t, reviewID, out.ReviewID.String())
+	})
+
+	t.Run("a payload that isn't signed with the secret is refused", func(t *testing.T) {
+		resp, _ := deliver(t, "pagerduty", payload, intake.Source{Secret: "guessed"}.Sign(payload))
+
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("a source that isn't configured isn't found", func(t *testing.T) {
+		resp, _ := deliver(t, "opsgenie", payload, source.Sign(payload))
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("the draft has what the incident tool knew", func(t *testing.T) {
+		resp, err := client.PostForm(baseURL+"/login", url.Values{"email": {cfg.AdminEmail}, "password": {cfg.AdminPassword}})
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		resp, err = client.Get(baseURL + "/api/v1/reviews/" + reviewID)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var review api.Review
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&review))
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, reviewing.StateDraft, review.State)
+		require.Equal(t, "Checkout is failing", review.Title)
+		require.Equal(t, "Payments API", review.Where)
+		require.NotNil(t, review.IncidentResolvedAt)
+		require.Equal(t, time.Date(2025, 5, 6, 11, 30, 0, 0, time.UTC), review.IncidentResolvedAt.UTC())
+	})
+}